@@ -83,67 +83,302 @@ func (as *ActorSystem) GetAllUsers() map[int]*User {
 package engine
 
 import (
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/remote"
 )
 
 type ActorSystem struct {
-	RootContext    *actor.RootContext
-	UserActor      *actor.PID
-	SubredditActor *actor.PID
-	PostActor      *actor.PID
+	RootContext       *actor.RootContext
+	UserActor         *actor.PID
+	SubredditActor    *actor.PID
+	PostActor         *actor.PID
+	NotificationActor *actor.PID
+	WebhookActor      *actor.PID
+	MediaActor        *actor.PID
+	SavedActor        *actor.PID
+	ReportActor       *actor.PID
+	VoteAuditActor    *actor.PID
+	Logger            *slog.Logger
+	RequestTimeout    time.Duration // how long request/response calls wait for an actor
+	Store             Store         // state the engine actors restore from when (re)started
+	Webhooks          WebhookOptions
+	Media             MediaOptions
+	Spam              SpamOptions
+	VoteAudit         VoteAuditOptions
+	Admins            map[int]bool  // users who may read admin reports
+	FlushInterval     time.Duration // how often a Store with unsaved writes is flushed; zero leaves it to Shutdown
+	FlushAfter        int           // flush as soon as this many writes are unsaved; zero turns it off
+
+	deadLetters atomic.Int64
+	failures    atomic.Int64
+	remote      *remote.Remote // set by StartRemote
+	flushStop   chan struct{}  // closed by Shutdown to stop flushPeriodically
+	flushDone   chan struct{}
 }
 
-func NewActorSystem() *ActorSystem {
-	system := actor.NewActorSystem()
-	rootContext := system.Root
-	return &ActorSystem{RootContext: rootContext}
+// Health counts the failures the supervisor has handled and messages nobody received
+type Health struct {
+	ActorFailures int64 `json:"actor_failures"`
+	DeadLetters   int64 `json:"dead_letters"`
 }
 
+func NewActorSystem(logger *slog.Logger) *ActorSystem {
+	rootContext := newProtoActorSystem(logger).Root
+	return &ActorSystem{RootContext: rootContext, Logger: logger, RequestTimeout: 5 * time.Second, Store: NewMemoryStore(), Webhooks: DefaultWebhookOptions(), Media: DefaultMediaOptions(), Spam: DefaultSpamOptions(), VoteAudit: DefaultVoteAuditOptions()}
+}
+
+// newProtoActorSystem routes protoactor's own logs through the same handler as the engine
+func newProtoActorSystem(logger *slog.Logger) *actor.ActorSystem {
+	config := actor.Configure(actor.WithLoggerFactory(func(system *actor.ActorSystem) *slog.Logger {
+		return logger.With("lib", "Proto.Actor", "system", system.ID)
+	}))
+	return actor.NewActorSystemWithConfig(config)
+}
+
+// engineSupervisor restarts a crashed engine actor, which then reloads its state from the Store.
+// An actor failing more than 10 times within a minute is stopped.
+var engineSupervisor = actor.NewOneForOneStrategy(10, time.Minute, actor.DefaultDecider)
+
 func (as *ActorSystem) SetupActors() {
-	userProps := actor.PropsFromProducer(func() actor.Actor { return &UserActor{users: make(map[int]*User)} })
-	subredditProps := actor.PropsFromProducer(func() actor.Actor { return &SubredditActor{subreddits: make(map[string]*Subreddit)} })
-	postProps := actor.PropsFromProducer(func() actor.Actor { return &PostActor{posts: make(map[int]*Post)} })
+	as.monitor()
+	as.flushPeriodically()
 
+	userProps := actor.PropsFromProducer(func() actor.Actor {
+		return &UserActor{store: as.Store, logger: as.Logger.With("actor", "user")}
+	}, append(tracingMiddleware("UserActor"), actor.WithGuardian(engineSupervisor))...)
 	as.UserActor = as.RootContext.Spawn(userProps)
+
+	subredditProps := actor.PropsFromProducer(func() actor.Actor {
+		return &SubredditActor{store: as.Store, logger: as.Logger.With("actor", "subreddit")}
+	}, append(tracingMiddleware("SubredditActor"), actor.WithGuardian(engineSupervisor))...)
 	as.SubredditActor = as.RootContext.Spawn(subredditProps)
+
+	webhookProps := actor.PropsFromProducer(func() actor.Actor {
+		return &WebhookActor{subredditActor: as.SubredditActor, options: as.Webhooks, timeout: as.RequestTimeout, store: as.Store, logger: as.Logger.With("actor", "webhook")}
+	}, append(tracingMiddleware("WebhookActor"), actor.WithGuardian(engineSupervisor))...)
+	as.WebhookActor = as.RootContext.Spawn(webhookProps)
+
+	notificationProps := actor.PropsFromProducer(func() actor.Actor {
+		return &NotificationActor{userActor: as.UserActor, timeout: as.RequestTimeout, store: as.Store, logger: as.Logger.With("actor", "notification")}
+	}, append(tracingMiddleware("NotificationActor"), actor.WithGuardian(engineSupervisor))...)
+	as.NotificationActor = as.RootContext.Spawn(notificationProps)
+
+	reportProps := actor.PropsFromProducer(func() actor.Actor {
+		return &ReportActor{store: as.Store, logger: as.Logger.With("actor", "report")}
+	}, append(tracingMiddleware("ReportActor"), actor.WithGuardian(engineSupervisor))...)
+	as.ReportActor = as.RootContext.Spawn(reportProps)
+
+	voteAuditProps := actor.PropsFromProducer(func() actor.Actor {
+		return &VoteAuditActor{subredditActor: as.SubredditActor, options: as.VoteAudit, timeout: as.RequestTimeout, store: as.Store, logger: as.Logger.With("actor", "vote_audit")}
+	}, append(tracingMiddleware("VoteAuditActor"), actor.WithGuardian(engineSupervisor))...)
+	as.VoteAuditActor = as.RootContext.Spawn(voteAuditProps)
+	// With analysis off PostActor counts every vote, but past findings stay readable
+	var voteAuditActor *actor.PID
+	if as.VoteAudit.Enabled {
+		voteAuditActor = as.VoteAuditActor
+	}
+
+	// PostActor gets the PIDs it reports to up front so a restarted instance still has them
+	postProps := actor.PropsFromProducer(func() actor.Actor {
+		return &PostActor{
+			userActor:         as.UserActor,
+			subredditActor:    as.SubredditActor,
+			notificationActor: as.NotificationActor,
+			reportActor:       as.ReportActor,
+			voteAuditActor:    voteAuditActor,
+			spamOptions:       as.Spam,
+			timeout:           as.RequestTimeout,
+			store:             as.Store,
+			logger:            as.Logger.With("actor", "post"),
+		}
+	}, append(tracingMiddleware("PostActor"), actor.WithGuardian(engineSupervisor))...)
 	as.PostActor = as.RootContext.Spawn(postProps)
 
+	mediaProps := actor.PropsFromProducer(func() actor.Actor {
+		return &MediaActor{postActor: as.PostActor, options: as.Media, timeout: as.RequestTimeout, store: as.Store, logger: as.Logger.With("actor", "media")}
+	}, append(tracingMiddleware("MediaActor"), actor.WithGuardian(engineSupervisor))...)
+	as.MediaActor = as.RootContext.Spawn(mediaProps)
+
+	savedProps := actor.PropsFromProducer(func() actor.Actor {
+		return &SavedActor{store: as.Store, logger: as.Logger.With("actor", "saved")}
+	}, append(tracingMiddleware("SavedActor"), actor.WithGuardian(engineSupervisor))...)
+	as.SavedActor = as.RootContext.Spawn(savedProps)
+
 	// Link UserActor to PostActor
 	as.RootContext.Send(as.PostActor, &AssignUserActor{UserActor: as.UserActor})
 }
 
+// monitor logs supervised failures and dead letters from the event stream and counts them.
+func (as *ActorSystem) monitor() {
+	as.RootContext.ActorSystem().EventStream.Subscribe(func(evt interface{}) {
+		switch e := evt.(type) {
+		case *actor.SupervisorEvent:
+			as.failures.Add(1)
+			as.Logger.Error("actor failed", "actor", e.Child.String(), "reason", fmt.Sprint(e.Reason), "directive", e.Directive.String())
+		case *actor.DeadLetterEvent:
+			as.deadLetters.Add(1)
+			as.Logger.Warn("dead letter", "target", e.PID.String(), "message", fmt.Sprintf("%T", actor.UnwrapEnvelopeMessage(e.Message)))
+		}
+	})
+}
+
+// flushCheck is how often flushPeriodically looks at the Store's unsaved writes
+const flushCheck = time.Second
+
+// pendingStore is a Store that keeps writes until it is flushed, like FileStore
+type pendingStore interface {
+	Store
+	Pending() int
+}
+
+// flushPeriodically flushes the Store while the engine runs, once FlushInterval has passed
+// since the last flush or FlushAfter writes are waiting, whichever comes first, so a crash
+// loses no more than that. Stores without unsaved writes, like MemoryStore, are left alone.
+func (as *ActorSystem) flushPeriodically() {
+	store, ok := as.Store.(pendingStore)
+	if !ok || (as.FlushInterval <= 0 && as.FlushAfter <= 0) {
+		return
+	}
+	check := flushCheck
+	if as.FlushInterval > 0 {
+		check = min(check, as.FlushInterval)
+	}
+	as.flushStop, as.flushDone = make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(as.flushDone)
+		ticker := time.NewTicker(check)
+		defer ticker.Stop()
+		last := time.Now()
+		for {
+			select {
+			case <-as.flushStop:
+				return
+			case now := <-ticker.C:
+				pending := store.Pending()
+				due := as.FlushInterval > 0 && now.Sub(last) >= as.FlushInterval
+				full := as.FlushAfter > 0 && pending >= as.FlushAfter
+				if pending == 0 || !due && !full {
+					continue
+				}
+				if err := store.Flush(); err != nil {
+					as.Logger.Error("store flush failed", "writes", pending, "error", err)
+					continue
+				}
+				last = now
+				as.Logger.Debug("store flushed", "writes", pending)
+			}
+		}
+	}()
+}
+
+// Health reports the failure and dead letter counts since startup.
+func (as *ActorSystem) Health() Health {
+	return Health{ActorFailures: as.failures.Load(), DeadLetters: as.deadLetters.Load()}
+}
+
+// Shutdown stops the engine actors once their mailboxes have drained, then the actor system
+// and the periodic flush, and flushes the Store.
+// PostActor goes first because it feeds the others, so the updates it emits are still applied.
+// The remote listener and the actor system are torn down even when an actor fails to stop in
+// time, so a failed graceful stop does not leave them running.
+func (as *ActorSystem) Shutdown(ctx context.Context) error {
+	stopErr := as.stopActors(ctx)
+	if stopErr != nil {
+		as.Logger.Error("engine actors did not stop cleanly", "error", stopErr)
+	}
+
+	if as.remote != nil {
+		// Only wait for the listener to drain when the actors behind it stopped
+		as.remote.Shutdown(stopErr == nil)
+	}
+	as.RootContext.ActorSystem().Shutdown()
+	as.Logger.Info("actor system stopped")
+	if as.flushStop != nil {
+		close(as.flushStop)
+		<-as.flushDone
+	}
+	// Still save whatever state the actors managed to write
+	return errors.Join(stopErr, as.Store.Flush())
+}
+
+func (as *ActorSystem) stopActors(ctx context.Context) error {
+	for _, pid := range []*actor.PID{as.SavedActor, as.MediaActor, as.PostActor, as.VoteAuditActor, as.ReportActor, as.NotificationActor, as.WebhookActor, as.SubredditActor, as.UserActor} {
+		future := as.RootContext.PoisonFuture(pid)
+		done := make(chan error, 1)
+		go func() { done <- future.Wait() }()
+
+		select {
+		case err := <-done:
+			if err != nil {
+				return fmt.Errorf("stopping actor %s: %w", pid.Id, err)
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
 // Public methods for REST API interaction
-func (as *ActorSystem) RegisterUser(msg RegisterUser) {
-	as.RootContext.Send(as.UserActor, &msg)
+func (as *ActorSystem) RegisterUser(ctx context.Context, msg RegisterUser) {
+	as.send(ctx, as.UserActor, &msg)
 }
 
-func (as *ActorSystem) CreateSubreddit(msg CreateSubreddit) {
-	as.RootContext.Send(as.SubredditActor, &msg)
+func (as *ActorSystem) CreateSubreddit(ctx context.Context, msg CreateSubreddit) {
+	as.send(ctx, as.SubredditActor, &msg)
 }
 
-func (as *ActorSystem) CreatePost(msg PostMessage) {
-	as.RootContext.Send(as.PostActor, &msg)
+func (as *ActorSystem) JoinSubreddit(ctx context.Context, msg JoinSubreddit) {
+	as.send(ctx, as.SubredditActor, &msg)
 }
 
-func (as *ActorSystem) AddComment(msg CommentMessage) {
-	as.RootContext.Send(as.PostActor, &msg)
+func (as *ActorSystem) LeaveSubreddit(ctx context.Context, msg LeaveSubreddit) {
+	as.send(ctx, as.SubredditActor, &msg)
 }
 
-func (as *ActorSystem) VotePost(msg Vote) {
-	as.RootContext.Send(as.PostActor, &msg)
+func (as *ActorSystem) CreatePost(ctx context.Context, msg PostMessage) {
+	as.send(ctx, as.PostActor, &msg)
+}
+
+func (as *ActorSystem) AddComment(ctx context.Context, msg CommentMessage) {
+	as.send(ctx, as.PostActor, &msg)
+}
+
+func (as *ActorSystem) EditPost(ctx context.Context, msg EditPost) {
+	as.send(ctx, as.PostActor, &msg)
+}
+
+func (as *ActorSystem) DeletePost(ctx context.Context, msg DeletePost) {
+	as.send(ctx, as.PostActor, &msg)
+}
+
+func (as *ActorSystem) EditComment(ctx context.Context, msg EditComment) {
+	as.send(ctx, as.PostActor, &msg)
+}
+
+func (as *ActorSystem) DeleteComment(ctx context.Context, msg DeleteComment) {
+	as.send(ctx, as.PostActor, &msg)
+}
+
+func (as *ActorSystem) VotePost(ctx context.Context, msg Vote) {
+	as.send(ctx, as.PostActor, &msg)
 }
 
 // Corrected Method for Fetching All Users
-func (as *ActorSystem) GetAllUsers() map[int]*User {
+func (as *ActorSystem) GetAllUsers(ctx context.Context, msg GetAllUsers) map[int]*User {
 	// Make a synchronous request
-	future := as.RootContext.RequestFuture(as.UserActor, &GetAllUsers{}, 5*time.Second)
+	future := as.requestFuture(ctx, as.UserActor, &msg, as.RequestTimeout)
 	result, err := future.Result()
 
 	if err != nil {
-		log.Printf("Error fetching users: %v\n", err)
+		as.Logger.Error("error fetching users", "request_id", msg.RequestID, "error", err)
 		return nil
 	}
 
@@ -152,6 +387,185 @@ func (as *ActorSystem) GetAllUsers() map[int]*User {
 		return users
 	}
 
-	log.Println("Unexpected result type while fetching users")
+	as.Logger.Error("unexpected result type while fetching users", "request_id", msg.RequestID)
 	return nil
 }
+
+// GetUser fetches a single user; the bool is false when it does not exist or the request failed.
+func (as *ActorSystem) GetUser(ctx context.Context, msg GetUser) (*User, bool) {
+	result, err := as.requestFuture(ctx, as.UserActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching user", "request_id", msg.RequestID, "user_id", msg.UserID, "error", err)
+		return nil, false
+	}
+	user, ok := result.(*User)
+	return user, ok && user != nil
+}
+
+// Search runs a full-text query against the PostActor's index.
+func (as *ActorSystem) Search(ctx context.Context, msg Search) (SearchResults, error) {
+	result, err := as.requestFuture(ctx, as.PostActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error searching", "request_id", msg.RequestID, "error", err)
+		return SearchResults{}, err
+	}
+	results, ok := result.(SearchResults)
+	if !ok {
+		return SearchResults{}, fmt.Errorf("unexpected search result %T", result)
+	}
+	return results, nil
+}
+
+// ListSubreddits, AutocompleteSubreddits and TrendingSubreddits ask SubredditActor for a listing.
+func (as *ActorSystem) ListSubreddits(ctx context.Context, msg ListSubreddits) ([]SubredditSummary, error) {
+	return as.subredditListing(ctx, &msg, msg.RequestID)
+}
+
+func (as *ActorSystem) AutocompleteSubreddits(ctx context.Context, msg AutocompleteSubreddits) ([]SubredditSummary, error) {
+	return as.subredditListing(ctx, &msg, msg.RequestID)
+}
+
+func (as *ActorSystem) TrendingSubreddits(ctx context.Context, msg GetTrendingSubreddits) ([]SubredditSummary, error) {
+	return as.subredditListing(ctx, &msg, msg.RequestID)
+}
+
+func (as *ActorSystem) subredditListing(ctx context.Context, msg interface{}, requestID string) ([]SubredditSummary, error) {
+	result, err := as.requestFuture(ctx, as.SubredditActor, msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error listing subreddits", "request_id", requestID, "error", err)
+		return nil, err
+	}
+	summaries, ok := result.([]SubredditSummary)
+	if !ok {
+		return nil, fmt.Errorf("unexpected subreddit listing %T", result)
+	}
+	return summaries, nil
+}
+
+// Notifications returns a page of a user's notifications, newest first.
+func (as *ActorSystem) Notifications(ctx context.Context, msg GetNotifications) (NotificationPage, error) {
+	result, err := as.requestFuture(ctx, as.NotificationActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching notifications", "request_id", msg.RequestID, "user_id", msg.UserID, "error", err)
+		return NotificationPage{}, err
+	}
+	page, ok := result.(NotificationPage)
+	if !ok {
+		return NotificationPage{}, fmt.Errorf("unexpected notification page %T", result)
+	}
+	return page, nil
+}
+
+func (as *ActorSystem) MarkNotificationsRead(ctx context.Context, msg MarkNotificationsRead) {
+	as.send(ctx, as.NotificationActor, &msg)
+}
+
+// RegisterWebhook adds a webhook to a subreddit on behalf of one of its moderators. The
+// returned Webhook carries the secret its deliveries are signed with.
+func (as *ActorSystem) RegisterWebhook(ctx context.Context, msg RegisterWebhook) (Webhook, error) {
+	webhooks, err := as.webhookRequest(ctx, &msg, msg.RequestID)
+	if err != nil {
+		return Webhook{}, err
+	}
+	return webhooks[0], nil
+}
+
+// ListWebhooks returns a subreddit's webhooks, without their secrets, to one of its moderators.
+func (as *ActorSystem) ListWebhooks(ctx context.Context, msg ListWebhooks) ([]Webhook, error) {
+	return as.webhookRequest(ctx, &msg, msg.RequestID)
+}
+
+func (as *ActorSystem) DeleteWebhook(ctx context.Context, msg DeleteWebhook) error {
+	_, err := as.webhookRequest(ctx, &msg, msg.RequestID)
+	return err
+}
+
+func (as *ActorSystem) webhookRequest(ctx context.Context, msg interface{}, requestID string) ([]Webhook, error) {
+	result, err := as.requestFuture(ctx, as.WebhookActor, msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error managing webhooks", "request_id", requestID, "error", err)
+		return nil, err
+	}
+	response, ok := result.(webhookResponse)
+	if !ok {
+		return nil, fmt.Errorf("unexpected webhook response %T", result)
+	}
+	return response.webhooks, response.err
+}
+
+// Feed returns a page of posts from msg.Subreddits, or from the subreddits msg.UserID joined,
+// each with the user flair its author picked in its subreddit. Posts msg.UserID hid are left out.
+func (as *ActorSystem) Feed(ctx context.Context, msg GetFeed) ([]PostSummary, error) {
+	if msg.UserID != 0 && len(msg.Subreddits) == 0 {
+		result, err := as.requestFuture(ctx, as.SubredditActor, &GetMemberships{UserID: msg.UserID, RequestID: msg.RequestID}, as.RequestTimeout).Result()
+		if err != nil {
+			as.Logger.Error("error fetching memberships", "request_id", msg.RequestID, "user_id", msg.UserID, "error", err)
+			return nil, err
+		}
+		names, ok := result.([]string)
+		if !ok {
+			return nil, fmt.Errorf("unexpected memberships %T", result)
+		}
+		if len(names) == 0 {
+			return []PostSummary{}, nil
+		}
+		msg.Subreddits = names
+	}
+	if msg.UserID != 0 {
+		hidden, err := as.hiddenPosts(ctx, msg.UserID, msg.RequestID)
+		if err != nil {
+			return nil, err
+		}
+		msg.Hidden = hidden
+	}
+
+	result, err := as.requestFuture(ctx, as.PostActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching feed", "request_id", msg.RequestID, "error", err)
+		return nil, err
+	}
+	posts, ok := result.([]PostSummary)
+	if !ok {
+		return nil, fmt.Errorf("unexpected feed %T", result)
+	}
+	if err := as.addAuthorFlairs(ctx, posts, msg.RequestID); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// FindLink returns the ID of the live link post of msg.URL in msg.Subreddit, or 0.
+// msg.URL must already be normalized, as ValidatePost leaves it.
+func (as *ActorSystem) FindLink(ctx context.Context, msg FindLink) (int, error) {
+	result, err := as.requestFuture(ctx, as.PostActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error looking up link", "request_id", msg.RequestID, "url", msg.URL, "error", err)
+		return 0, err
+	}
+	id, ok := result.(int)
+	if !ok {
+		return 0, fmt.Errorf("unexpected link lookup %T", result)
+	}
+	return id, nil
+}
+
+// DomainPosts returns a page of the posts linking to msg.Domain, newest first.
+func (as *ActorSystem) DomainPosts(ctx context.Context, msg GetDomainPosts) ([]PostSummary, error) {
+	if msg.UserID != 0 {
+		hidden, err := as.hiddenPosts(ctx, msg.UserID, msg.RequestID)
+		if err != nil {
+			return nil, err
+		}
+		msg.Hidden = hidden
+	}
+	result, err := as.requestFuture(ctx, as.PostActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching domain posts", "request_id", msg.RequestID, "domain", msg.Domain, "error", err)
+		return nil, err
+	}
+	posts, ok := result.([]PostSummary)
+	if !ok {
+		return nil, fmt.Errorf("unexpected domain posts %T", result)
+	}
+	return posts, nil
+}
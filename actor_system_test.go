@@ -0,0 +1,175 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// faultyStore panics when asked to save the user or post named panicOn, standing in for a
+// handler bug that takes an engine actor down halfway through a message.
+type faultyStore struct {
+	Store
+	panicOn string
+}
+
+func (s *faultyStore) SaveUser(user *User) {
+	if user.Username == s.panicOn {
+		panic("faulty store: " + user.Username)
+	}
+	s.Store.SaveUser(user)
+}
+
+func (s *faultyStore) SavePost(post *Post) {
+	if post.Title == s.panicOn {
+		panic("faulty store: " + post.Title)
+	}
+	s.Store.SavePost(post)
+}
+
+// newTestActorSystem starts the engine on store, after configure has set any options
+func newTestActorSystem(t *testing.T, store Store, configure ...func(as *ActorSystem)) *ActorSystem {
+	t.Helper()
+	as := NewActorSystem(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	as.Store = store
+	as.RequestTimeout = time.Second
+	for _, option := range configure {
+		option(as)
+	}
+	as.SetupActors()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		as.Shutdown(ctx)
+	})
+	return as
+}
+
+// eventually polls check until it holds, failing the test after a few seconds
+func eventually(t *testing.T, what string, check func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if check() {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestUserActorRestartsAfterPanic(t *testing.T) {
+	ctx := context.Background()
+	as := newTestActorSystem(t, &faultyStore{Store: NewMemoryStore(), panicOn: "boom"})
+
+	as.RegisterUser(ctx, RegisterUser{Username: "alice"})
+	as.RegisterUser(ctx, RegisterUser{Username: "boom"})
+	as.RegisterUser(ctx, RegisterUser{Username: "bob"})
+
+	eventually(t, "the supervisor to handle the panic", func() bool { return as.Health().ActorFailures == 1 })
+	// The restarted actor reloaded the Store, which never saw the user whose save panicked
+	alice, ok := as.GetUser(ctx, GetUser{UserID: 1})
+	if !ok || alice.Username != "alice" {
+		t.Fatalf("user 1 = %v, %v; want alice", alice, ok)
+	}
+	bob, ok := as.GetUser(ctx, GetUser{UserID: 2})
+	if !ok || bob.Username != "bob" {
+		t.Fatalf("user 2 = %v, %v; want bob", bob, ok)
+	}
+	if users := as.GetAllUsers(ctx, GetAllUsers{}); len(users) != 2 {
+		t.Fatalf("got %d users after the restart, want 2", len(users))
+	}
+}
+
+func TestPostActorRestartsAfterPanic(t *testing.T) {
+	ctx := context.Background()
+	as := newTestActorSystem(t, &faultyStore{Store: NewMemoryStore(), panicOn: "boom"})
+
+	as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "first", Content: "hello"})
+	as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "boom", Content: "crash"})
+	as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "second", Content: "still here"})
+
+	var titles []string
+	eventually(t, "both posts to be served", func() bool {
+		posts, err := as.Feed(ctx, GetFeed{Subreddits: []string{"golang"}})
+		if err != nil {
+			return false
+		}
+		titles = titles[:0]
+		for _, post := range posts {
+			titles = append(titles, post.Title)
+		}
+		return len(titles) == 2
+	})
+	if titles[0] != "second" || titles[1] != "first" {
+		t.Fatalf("feed = %v, want [second first]", titles)
+	}
+	if failures := as.Health().ActorFailures; failures != 1 {
+		t.Fatalf("supervisor handled %d failures, want 1", failures)
+	}
+
+	// Votes still reach the restarted actor
+	as.VotePost(ctx, Vote{UserID: 2, Target: "post", ID: 1, Type: "upvote"})
+	eventually(t, "the vote to count", func() bool {
+		post, err := as.livePost(ctx, 1, "")
+		return err == nil && post.Upvotes == 1
+	})
+}
+
+func TestSupervisorStopsActorOverRestartBudget(t *testing.T) {
+	ctx := context.Background()
+	as := newTestActorSystem(t, &faultyStore{Store: NewMemoryStore(), panicOn: "boom"})
+
+	as.RegisterUser(ctx, RegisterUser{Username: "alice"})
+	// engineSupervisor restarts an actor 10 times a minute; the 11th failure stops it
+	for range 11 {
+		as.RegisterUser(ctx, RegisterUser{Username: "boom"})
+	}
+	eventually(t, "the supervisor to handle every panic", func() bool { return as.Health().ActorFailures == 11 })
+
+	if _, ok := as.GetUser(ctx, GetUser{UserID: 1}); ok {
+		t.Fatal("UserActor still answers after exceeding its restart budget")
+	}
+	// The other engine actors are supervised one for one and keep serving
+	as.CreateSubreddit(ctx, CreateSubreddit{Name: "golang", CreatorID: 1})
+	eventually(t, "SubredditActor to serve", func() bool {
+		subreddits, err := as.ListSubreddits(ctx, ListSubreddits{})
+		return err == nil && len(subreddits) == 1
+	})
+}
+
+func TestFileStoreFlushesWhileRunning(t *testing.T) {
+	for _, tt := range []struct {
+		name          string
+		flushInterval time.Duration
+		flushAfter    int
+	}{
+		{"after an interval", 50 * time.Millisecond, 0},
+		{"after enough writes", 0, 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			path := filepath.Join(t.TempDir(), "state.json")
+			store, err := NewFileStore(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			as := newTestActorSystem(t, store, func(as *ActorSystem) {
+				as.FlushInterval = tt.flushInterval
+				as.FlushAfter = tt.flushAfter
+			})
+
+			as.RegisterUser(ctx, RegisterUser{Username: "alice"})
+			as.CreateSubreddit(ctx, CreateSubreddit{Name: "golang", CreatorID: 1})
+			// Read the file back while the engine is still running
+			eventually(t, "the state file", func() bool {
+				saved, err := NewFileStore(path)
+				return err == nil && len(saved.Users()) == 1 && len(saved.Subreddits()) == 1
+			})
+			if pending := store.Pending(); pending != 0 {
+				t.Fatalf("%d writes pending after the flush", pending)
+			}
+		})
+	}
+}
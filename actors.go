@@ -1,25 +1,41 @@
 package engine
 
 import (
+	"cmp"
 	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
 )
 
 // UserActor
 type UserActor struct {
-	users map[int]*User
-	mu    sync.Mutex
+	users  map[int]*User
+	store  Store
+	logger *slog.Logger
+	mu     sync.Mutex
 }
 
 func (u *UserActor) Receive(ctx actor.Context) {
 	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		// Runs on first start and after every restart by the supervisor
+		u.mu.Lock()
+		u.users = u.store.Users()
+		u.logger.Debug("state restored", "users", len(u.users))
+		u.mu.Unlock()
+
 	case *RegisterUser:
 		u.mu.Lock()
 		id := len(u.users) + 1
-		u.users[id] = &User{ID: id, Username: msg.Username, Password: msg.Password, Karma: 0, PostKarma: 0, CommentKarma: 0}
-		fmt.Printf("User %s registered with ID %d\n", msg.Username, id)
+		u.users[id] = &User{ID: id, Username: msg.Username, Password: msg.Password, Karma: 0, PostKarma: 0, CommentKarma: 0, CreatedAt: time.Now()}
+		u.store.SaveUser(u.users[id])
+		u.logger.Info("user registered", "request_id", msg.RequestID, "user_id", id, "username", msg.Username)
 		u.mu.Unlock()
 
 	case *UpdateKarma:
@@ -27,18 +43,37 @@ func (u *UserActor) Receive(ctx actor.Context) {
 		user, exists := u.users[msg.UserID]
 		if exists {
 			user.Karma += msg.KarmaChange
-			fmt.Printf("User %d's karma updated to %d\n", msg.UserID, user.Karma)
+			u.store.SaveUser(user)
+			publish(ctx, LiveEvent{Type: EventKarma, RecipientID: user.ID, Karma: user.Karma})
+			u.logger.Info("karma updated", "request_id", msg.RequestID, "user_id", msg.UserID, "karma", user.Karma)
 		} else {
-			fmt.Printf("User ID %d does not exist\n", msg.UserID)
+			u.logger.Warn("user does not exist", "request_id", msg.RequestID, "user_id", msg.UserID)
 		}
 		u.mu.Unlock()
 	case *GetAllUsers:
 		u.mu.Lock()
-		fmt.Println("Current Karma for all users:")
+		users := make(map[int]*User, len(u.users))
 		for id, user := range u.users {
-			fmt.Printf("User %d (%s): Karma: %d\n", id, user.Username, user.Karma)
+			users[id] = user.clone()
+		}
+		u.logger.Debug("listing users", "request_id", msg.RequestID, "count", len(users))
+		u.mu.Unlock()
+		ctx.Respond(users)
+	case *GetUser:
+		u.mu.Lock()
+		var found *User
+		if msg.Username != "" {
+			for _, user := range u.users {
+				if strings.EqualFold(user.Username, msg.Username) {
+					found = user.clone()
+					break
+				}
+			}
+		} else if user, exists := u.users[msg.UserID]; exists {
+			found = user.clone()
 		}
 		u.mu.Unlock()
+		ctx.Respond(found)
 	}
 
 }
@@ -46,93 +81,1011 @@ func (u *UserActor) Receive(ctx actor.Context) {
 // SubredditActor
 type SubredditActor struct {
 	subreddits map[string]*Subreddit
+	names      *nameTrie
+	activity   map[string][]activityEvent // recent activity per subreddit, for trending
+	store      Store
+	logger     *slog.Logger
 	mu         sync.Mutex
 }
 
 func (s *SubredditActor) Receive(ctx actor.Context) {
 	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		s.mu.Lock()
+		s.subreddits = s.store.Subreddits()
+		s.names = newNameTrie()
+		s.activity = make(map[string][]activityEvent)
+		for name := range s.subreddits {
+			s.names.insert(name)
+		}
+		s.logger.Debug("state restored", "subreddits", len(s.subreddits))
+		s.mu.Unlock()
+
 	case *CreateSubreddit:
 		s.mu.Lock()
 		if _, exists := s.subreddits[msg.Name]; exists {
-			fmt.Printf("Subreddit %s already exists\n", msg.Name)
+			s.logger.Warn("subreddit already exists", "request_id", msg.RequestID, "subreddit", msg.Name)
 		} else {
 			id := len(s.subreddits) + 1
+			now := time.Now()
 			s.subreddits[msg.Name] = &Subreddit{
-				ID:      id,
-				Name:    msg.Name,
-				Members: make(map[int]bool),
-				Posts:   []int{},
+				ID:           id,
+				Name:         msg.Name,
+				Members:      make(map[int]bool),
+				Moderators:   make(map[int]bool),
+				Posts:        []int{},
+				CreatedAt:    now,
+				LastActivity: now,
+			}
+			if msg.CreatorID != 0 {
+				s.subreddits[msg.Name].Members[msg.CreatorID] = true
+				s.subreddits[msg.Name].Moderators[msg.CreatorID] = true
+			}
+			s.names.insert(msg.Name)
+			s.store.SaveSubreddit(s.subreddits[msg.Name])
+			s.logger.Info("subreddit created", "request_id", msg.RequestID, "subreddit", msg.Name, "creator_id", msg.CreatorID)
+		}
+		s.mu.Unlock()
+
+	case *GetSubreddit:
+		s.mu.Lock()
+		var found *Subreddit
+		if subreddit, exists := s.subreddits[msg.Name]; exists {
+			found = subreddit.clone()
+		}
+		s.mu.Unlock()
+		ctx.Respond(found)
+
+	case *GetMemberships:
+		s.mu.Lock()
+		names := []string{}
+		for name, subreddit := range s.subreddits {
+			if subreddit.Members[msg.UserID] {
+				names = append(names, name)
+			}
+		}
+		s.mu.Unlock()
+		sort.Strings(names)
+		ctx.Respond(names)
+
+	case *JoinSubreddit:
+		s.mu.Lock()
+		if subreddit, exists := s.subreddits[msg.Name]; exists && subreddit.Banned[msg.UserID] {
+			s.logger.Warn("banned user may not join", "request_id", msg.RequestID, "subreddit", msg.Name, "user_id", msg.UserID)
+		} else if exists {
+			subreddit.Members[msg.UserID] = true
+			s.store.SaveSubreddit(subreddit)
+			s.logger.Info("user joined subreddit", "request_id", msg.RequestID, "subreddit", msg.Name, "user_id", msg.UserID)
+		} else {
+			s.logger.Warn("subreddit does not exist", "request_id", msg.RequestID, "subreddit", msg.Name)
+		}
+		s.mu.Unlock()
+
+	case *LeaveSubreddit:
+		s.mu.Lock()
+		if subreddit, exists := s.subreddits[msg.Name]; exists {
+			delete(subreddit.Members, msg.UserID)
+			s.store.SaveSubreddit(subreddit)
+			s.logger.Info("user left subreddit", "request_id", msg.RequestID, "subreddit", msg.Name, "user_id", msg.UserID)
+		} else {
+			s.logger.Warn("subreddit does not exist", "request_id", msg.RequestID, "subreddit", msg.Name)
+		}
+		s.mu.Unlock()
+
+	case *SubredditActivity:
+		s.mu.Lock()
+		if subreddit, exists := s.subreddits[msg.Name]; exists {
+			subreddit.LastActivity = msg.At
+			if msg.Kind == "post" {
+				subreddit.Posts = append(subreddit.Posts, msg.PostID)
+			}
+			s.store.SaveSubreddit(subreddit)
+			s.activity[msg.Name] = append(pruneActivity(s.activity[msg.Name], msg.At), activityEvent{kind: msg.Kind, at: msg.At})
+		}
+		s.mu.Unlock()
+
+	case *ListSubreddits:
+		s.mu.Lock()
+		summaries := make([]SubredditSummary, 0, len(s.subreddits))
+		for _, subreddit := range s.subreddits {
+			summaries = append(summaries, summarize(subreddit))
+		}
+		s.mu.Unlock()
+		sortSubreddits(summaries, msg.Sort)
+		ctx.Respond(paginate(summaries, msg.Page, msg.PageSize, DefaultListPageSize, MaxListPageSize))
+
+	case *AutocompleteSubreddits:
+		s.mu.Lock()
+		var summaries []SubredditSummary
+		for _, name := range s.names.withPrefix(msg.Prefix) {
+			summaries = append(summaries, summarize(s.subreddits[name]))
+		}
+		s.mu.Unlock()
+		// Bigger communities first, as users most likely mean those
+		sortSubreddits(summaries, SortMembers)
+		ctx.Respond(paginate(summaries, 1, msg.Limit, 10, MaxListPageSize))
+
+	case *GetTrendingSubreddits:
+		s.mu.Lock()
+		now := time.Now()
+		var summaries []SubredditSummary
+		for name, events := range s.activity {
+			s.activity[name] = pruneActivity(events, now)
+			if score := trendingScore(s.activity[name], now); score > 0 {
+				summary := summarize(s.subreddits[name])
+				summary.TrendingScore = score
+				summaries = append(summaries, summary)
 			}
-			fmt.Printf("Subreddit %s created\n", msg.Name)
 		}
 		s.mu.Unlock()
+		sort.Slice(summaries, func(i, j int) bool {
+			if summaries[i].TrendingScore != summaries[j].TrendingScore {
+				return summaries[i].TrendingScore > summaries[j].TrendingScore
+			}
+			return summaries[i].Name < summaries[j].Name
+		})
+		ctx.Respond(paginate(summaries, 1, msg.Limit, 10, MaxListPageSize))
+
+	case *CreateFlair:
+		s.mu.Lock()
+		subreddit, exists := s.subreddits[msg.Subreddit]
+		switch {
+		case !exists:
+			ctx.Respond(flairResponse{err: ErrNoSuchSubreddit})
+		case !subreddit.Moderators[msg.UserID]:
+			ctx.Respond(flairResponse{err: ErrNotModerator})
+		case len(subreddit.Flairs) >= MaxFlairTemplates:
+			ctx.Respond(flairResponse{err: fmt.Errorf("%w: r/%s already has %d flair templates", ErrInvalidFlair, subreddit.Name, MaxFlairTemplates)})
+		default:
+			subreddit.NextFlairID++
+			template := FlairTemplate{ID: subreddit.NextFlairID, Type: msg.Type, Text: msg.Text, Color: msg.Color, ModOnly: msg.ModOnly}
+			subreddit.Flairs = append(subreddit.Flairs, template)
+			s.store.SaveSubreddit(subreddit)
+			s.logger.Info("flair created", "request_id", msg.RequestID, "subreddit", subreddit.Name, "flair_id", template.ID, "type", template.Type)
+			ctx.Respond(flairResponse{flairs: []FlairTemplate{template}})
+		}
+		s.mu.Unlock()
+
+	case *ListFlairs:
+		s.mu.Lock()
+		if subreddit, exists := s.subreddits[msg.Subreddit]; exists {
+			flairs := []FlairTemplate{}
+			for _, template := range subreddit.Flairs {
+				if msg.Type == "" || template.Type == msg.Type {
+					flairs = append(flairs, template)
+				}
+			}
+			ctx.Respond(flairResponse{flairs: flairs})
+		} else {
+			ctx.Respond(flairResponse{err: ErrNoSuchSubreddit})
+		}
+		s.mu.Unlock()
+
+	case *DeleteFlair:
+		s.mu.Lock()
+		subreddit, exists := s.subreddits[msg.Subreddit]
+		switch {
+		case !exists:
+			ctx.Respond(flairResponse{err: ErrNoSuchSubreddit})
+		case !subreddit.Moderators[msg.UserID]:
+			ctx.Respond(flairResponse{err: ErrNotModerator})
+		default:
+			i := slices.IndexFunc(subreddit.Flairs, func(template FlairTemplate) bool { return template.ID == msg.FlairID })
+			if i < 0 {
+				ctx.Respond(flairResponse{err: ErrNoSuchFlair})
+				break
+			}
+			subreddit.Flairs = slices.Delete(subreddit.Flairs, i, i+1)
+			for userID, flairID := range subreddit.UserFlairs {
+				if flairID == msg.FlairID {
+					delete(subreddit.UserFlairs, userID)
+				}
+			}
+			s.store.SaveSubreddit(subreddit)
+			s.logger.Info("flair deleted", "request_id", msg.RequestID, "subreddit", subreddit.Name, "flair_id", msg.FlairID)
+			ctx.Respond(flairResponse{})
+		}
+		s.mu.Unlock()
+
+	case *SetUserFlair:
+		s.mu.Lock()
+		if subreddit, exists := s.subreddits[msg.Subreddit]; !exists {
+			ctx.Respond(flairResponse{err: ErrNoSuchSubreddit})
+		} else if msg.FlairID == 0 {
+			delete(subreddit.UserFlairs, msg.UserID)
+			s.store.SaveSubreddit(subreddit)
+			ctx.Respond(flairResponse{})
+		} else if template, err := subreddit.applicableFlair(msg.FlairID, FlairUser, msg.UserID); err != nil {
+			ctx.Respond(flairResponse{err: err})
+		} else {
+			if subreddit.UserFlairs == nil {
+				subreddit.UserFlairs = make(map[int]int)
+			}
+			subreddit.UserFlairs[msg.UserID] = template.ID
+			s.store.SaveSubreddit(subreddit)
+			s.logger.Info("user flair set", "request_id", msg.RequestID, "subreddit", subreddit.Name, "user_id", msg.UserID, "flair_id", template.ID)
+			ctx.Respond(flairResponse{flairs: []FlairTemplate{*template}})
+		}
+		s.mu.Unlock()
+
+	case *SetReportReasons:
+		s.mu.Lock()
+		if subreddit, exists := s.subreddits[msg.Subreddit]; exists {
+			subreddit.ReportReasons = msg.Reasons
+			s.store.SaveSubreddit(subreddit)
+			s.logger.Info("report reasons set", "request_id", msg.RequestID, "subreddit", subreddit.Name, "user_id", msg.UserID, "reasons", len(msg.Reasons))
+		}
+		s.mu.Unlock()
+
+	case *BanUser:
+		s.mu.Lock()
+		if subreddit, exists := s.subreddits[msg.Subreddit]; exists {
+			if subreddit.Banned == nil {
+				subreddit.Banned = make(map[int]bool)
+			}
+			subreddit.Banned[msg.BannedID] = true
+			delete(subreddit.Members, msg.BannedID)
+			s.store.SaveSubreddit(subreddit)
+			s.logger.Info("user banned", "request_id", msg.RequestID, "subreddit", subreddit.Name, "user_id", msg.BannedID, "moderator_id", msg.UserID)
+		}
+		s.mu.Unlock()
+
+	case *UnbanUser:
+		s.mu.Lock()
+		if subreddit, exists := s.subreddits[msg.Subreddit]; exists && subreddit.Banned[msg.BannedID] {
+			delete(subreddit.Banned, msg.BannedID)
+			s.store.SaveSubreddit(subreddit)
+			s.logger.Info("user unbanned", "request_id", msg.RequestID, "subreddit", subreddit.Name, "user_id", msg.BannedID, "moderator_id", msg.UserID)
+		}
+		s.mu.Unlock()
+
+	case *SetAutoModRules:
+		s.mu.Lock()
+		if subreddit, exists := s.subreddits[msg.Subreddit]; exists {
+			subreddit.AutoModRules = msg.Rules
+			s.store.SaveSubreddit(subreddit)
+			s.logger.Info("AutoModerator rules set", "request_id", msg.RequestID, "subreddit", subreddit.Name, "user_id", msg.UserID, "rules", len(msg.Rules))
+		}
+		s.mu.Unlock()
+
+	case *ResolveFlair:
+		s.mu.Lock()
+		if subreddit, exists := s.subreddits[msg.Subreddit]; !exists {
+			ctx.Respond(flairResponse{err: ErrNoSuchSubreddit})
+		} else if msg.FlairID == 0 {
+			ctx.Respond(flairResponse{moderator: subreddit.Moderators[msg.UserID]})
+		} else if template, err := subreddit.applicableFlair(msg.FlairID, FlairPost, msg.UserID); err != nil {
+			ctx.Respond(flairResponse{err: err})
+		} else {
+			ctx.Respond(flairResponse{flairs: []FlairTemplate{*template}, moderator: subreddit.Moderators[msg.UserID]})
+		}
+		s.mu.Unlock()
+
+	case *GetUserFlairs:
+		s.mu.Lock()
+		flairs := make(map[string]map[int]FlairTemplate)
+		for _, name := range msg.Subreddits {
+			subreddit, exists := s.subreddits[name]
+			if !exists || flairs[name] != nil {
+				continue
+			}
+			flairs[name] = make(map[int]FlairTemplate, len(subreddit.UserFlairs))
+			for userID, flairID := range subreddit.UserFlairs {
+				if template := subreddit.flair(flairID, FlairUser); template != nil {
+					flairs[name][userID] = *template
+				}
+			}
+		}
+		s.mu.Unlock()
+		ctx.Respond(flairs)
 	}
 }
 
 // PostActor
 type PostActor struct {
-	posts     map[int]*Post
-	userActor *actor.PID
-	mu        sync.Mutex
+	posts             map[int]*Post
+	comments          map[int]*Comment // every comment by its ID, across all posts
+	index             *searchIndex
+	userActor         *actor.PID
+	subredditActor    *actor.PID
+	notificationActor *actor.PID
+	reportActor       *actor.PID               // where AutoModerator files the items it filters
+	voteAuditActor    *actor.PID               // checks votes before they count; nil counts every vote
+	automod           map[string][]AutoModRule // compiled AutoModerator rules by subreddit
+	spam              *SpamModel
+	spamExamples      []SpamExample // the moderator decisions spam learned from, oldest first
+	spamOptions       SpamOptions
+	timeout           time.Duration
+	store             Store
+	logger            *slog.Logger
+	mu                sync.Mutex
 }
 
 func (p *PostActor) Receive(ctx actor.Context) {
 	switch msg := ctx.Message().(type) {
 
+	case *actor.Started:
+		p.mu.Lock()
+		p.posts = p.store.Posts()
+		p.comments = make(map[int]*Comment)
+		p.index = newSearchIndex()
+		for _, post := range p.posts {
+			if post.ContentHTML == "" {
+				post.ContentHTML = RenderMarkdown(post.Content) // stored before content was rendered
+			}
+			if post.live() {
+				p.index.indexPost(post)
+			}
+			for _, comment := range post.Comments {
+				if comment.ContentHTML == "" {
+					comment.ContentHTML = RenderMarkdown(comment.Content)
+				}
+				p.comments[comment.ID] = comment
+				if post.live() && comment.live() {
+					p.index.indexComment(post, comment)
+				}
+			}
+		}
+		p.automod = make(map[string][]AutoModRule)
+		for name, subreddit := range p.store.Subreddits() {
+			if len(subreddit.AutoModRules) == 0 {
+				continue
+			}
+			if err := compileAutoModRules(subreddit.AutoModRules); err != nil {
+				p.logger.Error("stored AutoModerator rules do not compile", "subreddit", name, "error", err)
+				continue
+			}
+			p.automod[name] = subreddit.AutoModRules
+		}
+		if p.spam = p.store.SpamModel(); p.spam == nil {
+			p.spam = NewSpamModel()
+		}
+		p.spamExamples = p.store.SpamExamples()
+		p.logger.Debug("state restored", "posts", len(p.posts), "comments", len(p.comments), "automod_subreddits", len(p.automod), "spam_examples", len(p.spamExamples))
+		p.mu.Unlock()
+
 	case *AssignUserActor:
 		p.userActor = msg.UserActor
-		fmt.Println("UserActor assigned to PostActor")
+		p.logger.Debug("UserActor assigned to PostActor")
 
 	case *PostMessage:
-		p.mu.Lock()
-		id := len(p.posts) + 1
-		p.posts[id] = &Post{
-			ID:        id,
-			UserID:    msg.UserID,
-			Subreddit: msg.Subreddit,
-			Content:   msg.Content,
+		if err := ValidatePost(msg); err != nil {
+			p.logger.Warn("post rejected", "request_id", msg.RequestID, "subreddit", msg.Subreddit, "user_id", msg.UserID, "error", err)
+			return
 		}
-		fmt.Printf("Post %d created in subreddit %s by user %d\n", id, msg.Subreddit, msg.UserID)
-		p.mu.Unlock()
+		p.withAuthor(ctx, msg.Subreddit, msg.UserID, msg.RequestID, func(author *User) { p.createPost(ctx, msg, author) })
 
 	case *CommentMessage:
 		p.mu.Lock()
 		post, exists := p.posts[msg.PostID]
+		p.mu.Unlock()
 		if !exists {
-			fmt.Printf("Post ID %d does not exist\n", msg.PostID)
-			p.mu.Unlock()
+			p.logger.Warn("post does not exist", "request_id", msg.RequestID, "post_id", msg.PostID)
 			return
 		}
-		commentID := len(post.Comments) + 1
-		comment := &Comment{
-			ID:       commentID,
-			PostID:   msg.PostID,
-			ParentID: msg.ParentID,
-			UserID:   msg.UserID,
-			Content:  msg.Content,
+		p.withAuthor(ctx, post.Subreddit, msg.UserID, msg.RequestID, func(author *User) { p.createComment(ctx, msg, author) })
+
+	case *EditPost:
+		p.mu.Lock()
+		post := p.ownPost(msg.PostID, msg.UserID, msg.RequestID)
+		p.mu.Unlock()
+		if post != nil {
+			p.withAuthor(ctx, post.Subreddit, msg.UserID, msg.RequestID, func(author *User) { p.editPost(ctx, msg, author) })
+		}
+
+	case *DeletePost:
+		p.mu.Lock()
+		if post := p.ownPost(msg.PostID, msg.UserID, msg.RequestID); post != nil {
+			p.deletePost(ctx, post, msg.UserID, false)
+			p.logger.Info("post deleted", "request_id", msg.RequestID, "post_id", post.ID, "user_id", msg.UserID)
 		}
-		post.Comments = append(post.Comments, comment)
-		fmt.Printf("Comment added to post %d by user %d\n", msg.PostID, msg.UserID)
 		p.mu.Unlock()
 
-	case *Vote:
+	case *EditComment:
 		p.mu.Lock()
-		if msg.Target == "post" {
-			post, exists := p.posts[msg.ID]
-			if !exists {
-				fmt.Printf("Post ID %d does not exist\n", msg.ID)
-				p.mu.Unlock()
-				return
+		subreddit := ""
+		if comment := p.ownComment(msg.CommentID, msg.UserID, msg.RequestID); comment != nil {
+			subreddit = p.posts[comment.PostID].Subreddit
+		}
+		p.mu.Unlock()
+		if subreddit != "" {
+			p.withAuthor(ctx, subreddit, msg.UserID, msg.RequestID, func(author *User) { p.editComment(ctx, msg, author) })
+		}
+
+	case *DeleteComment:
+		p.mu.Lock()
+		if comment := p.ownComment(msg.CommentID, msg.UserID, msg.RequestID); comment != nil {
+			p.deleteComment(ctx, comment, msg.UserID, false)
+			p.logger.Info("comment deleted", "request_id", msg.RequestID, "comment_id", comment.ID, "user_id", msg.UserID)
+		}
+		p.mu.Unlock()
+
+	case *GetFeed:
+		p.mu.Lock()
+		posts := withoutHidden(feed(p.posts, msg.Subreddits), msg.Hidden)
+		p.mu.Unlock()
+		if msg.FlairID != 0 {
+			posts = filterFlair(posts, msg.FlairID)
+		}
+		ctx.Respond(paginate(posts, msg.Page, msg.PageSize, DefaultFeedPageSize, MaxFeedPageSize))
+
+	case *getMediaRefs:
+		p.mu.Lock()
+		refs := map[string]bool{}
+		for _, post := range p.posts {
+			if !post.Deleted {
+				for _, media := range post.Media {
+					refs[media.ID] = true
+				}
 			}
-			if msg.Type == "upvote" {
-				post.Upvotes++
-				ctx.Send(p.userActor, &UpdateKarma{UserID: post.UserID, KarmaChange: 1})
-			} else if msg.Type == "downvote" {
-				post.Downvotes++
-				ctx.Send(p.userActor, &UpdateKarma{UserID: post.UserID, KarmaChange: -1})
+		}
+		p.mu.Unlock()
+		ctx.Respond(refs)
+
+	case *GetPost:
+		p.mu.Lock()
+		var found *Post
+		if post, exists := p.posts[msg.PostID]; exists {
+			found = post.clone()
+		}
+		p.mu.Unlock()
+		ctx.Respond(found)
+
+	case *GetComment:
+		p.mu.Lock()
+		var found *Comment
+		if comment, exists := p.comments[msg.CommentID]; exists {
+			copied := *comment
+			copied.Replies = nil
+			found = &copied
+		}
+		p.mu.Unlock()
+		ctx.Respond(found)
+
+	case *resolveSaved:
+		p.mu.Lock()
+		items := make([]SavedItem, 0, len(msg.items))
+		for _, item := range msg.items {
+			switch item.Type {
+			case SavedPost:
+				if post, exists := p.posts[item.ID]; exists {
+					summary := summarizePost(post)
+					item.Post = &summary
+				}
+			case SavedComment:
+				if comment, exists := p.comments[item.ID]; exists {
+					summary := summarizeComment(p.posts[comment.PostID], comment)
+					item.Comment = &summary
+				}
+			}
+			items = append(items, item)
+		}
+		p.mu.Unlock()
+		ctx.Respond(items)
+
+	case *resolveReported:
+		p.mu.Lock()
+		reports := make([]*Report, 0, len(msg.reports))
+		for _, report := range msg.reports {
+			switch report.Target {
+			case ReportPost:
+				if post, exists := p.posts[report.ID]; exists {
+					summary := summarizePost(post)
+					report.Post = &summary
+				}
+			case ReportComment:
+				if comment, exists := p.comments[report.ID]; exists {
+					summary := summarizeComment(p.posts[comment.PostID], comment)
+					report.Comment = &summary
+				}
 			}
-			fmt.Printf("Post %d %sd by user %d\n", msg.ID, msg.Type, msg.UserID)
+			reports = append(reports, report)
 		}
 		p.mu.Unlock()
+		ctx.Respond(reports)
+
+	case *RemoveContent:
+		p.mu.Lock()
+		notification := Notification{Type: NotificationModAction, FromUserID: msg.ModeratorID}
+		recipient := 0
+		switch msg.Target {
+		case ReportPost:
+			if post, exists := p.posts[msg.ID]; exists && !post.Deleted {
+				notification.PostID, notification.Subreddit = post.ID, post.Subreddit
+				notification.Excerpt = excerpt(cmp.Or(post.Title, post.Content))
+				recipient = post.UserID
+				p.learnSpam(SpamExample{Text: postSpamText(post), Spam: true, Target: ReportPost, ID: post.ID, Subreddit: post.Subreddit, At: time.Now()}, msg.RequestID)
+				p.deletePost(ctx, post, msg.ModeratorID, true)
+			}
+		case ReportComment:
+			if comment, exists := p.comments[msg.ID]; exists && !comment.Deleted {
+				post := p.posts[comment.PostID]
+				notification.PostID, notification.CommentID, notification.Subreddit = post.ID, comment.ID, post.Subreddit
+				notification.Excerpt = excerpt(comment.Content)
+				recipient = comment.UserID
+				p.learnSpam(SpamExample{Text: comment.Content, Spam: true, Target: ReportComment, ID: comment.ID, Subreddit: post.Subreddit, At: time.Now()}, msg.RequestID)
+				p.deleteComment(ctx, comment, msg.ModeratorID, true)
+			}
+		}
+		if recipient == 0 {
+			p.logger.Warn("removed content does not exist", "request_id", msg.RequestID, "target", msg.Target, "id", msg.ID)
+		} else {
+			if p.notificationActor != nil && recipient != msg.ModeratorID {
+				ctx.Send(p.notificationActor, &Notify{RecipientID: recipient, Notification: notification})
+			}
+			p.logger.Info("content removed by moderator", "request_id", msg.RequestID, "target", msg.Target, "id", msg.ID, "moderator_id", msg.ModeratorID)
+		}
+		p.mu.Unlock()
+
+	case *ApproveContent:
+		p.mu.Lock()
+		p.approve(ctx, msg)
+		p.mu.Unlock()
+
+	case *GetSpamStats:
+		p.mu.Lock()
+		stats := p.spamStats()
+		p.mu.Unlock()
+		ctx.Respond(stats)
+
+	case *SetAutoModRules:
+		p.mu.Lock()
+		if len(msg.Rules) == 0 {
+			delete(p.automod, msg.Subreddit)
+		} else {
+			p.automod[msg.Subreddit] = msg.Rules
+		}
+		p.mu.Unlock()
+		p.logger.Info("AutoModerator rules applied", "request_id", msg.RequestID, "subreddit", msg.Subreddit, "rules", len(msg.Rules))
+
+	case *SetPostFlair:
+		p.mu.Lock()
+		if post, exists := p.posts[msg.PostID]; !exists || post.Deleted {
+			ctx.Respond(flairResponse{err: ErrNoSuchPost})
+		} else {
+			post.Flair = msg.Flair
+			p.store.SavePost(post)
+			p.logger.Info("post flair set", "request_id", msg.RequestID, "post_id", post.ID, "user_id", msg.UserID, "flair_id", msg.FlairID)
+			ctx.Respond(flairResponse{})
+		}
+		p.mu.Unlock()
+
+	case *Crosspost:
+		p.withAuthor(ctx, msg.Subreddit, msg.UserID, msg.RequestID, func(author *User) {
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			post, err := p.crosspost(ctx, msg, author)
+			if err != nil {
+				p.logger.Warn("crosspost rejected", "request_id", msg.RequestID, "post_id", msg.PostID, "subreddit", msg.Subreddit, "user_id", msg.UserID, "error", err)
+				ctx.Respond(crosspostResponse{err: err})
+				return
+			}
+			p.logger.Info("post crossposted", "request_id", msg.RequestID, "post_id", post.ID, "parent_id", post.CrosspostOf.PostID, "subreddit", post.Subreddit, "user_id", msg.UserID)
+			ctx.Respond(crosspostResponse{post: summarizePost(post)})
+		})
+
+	case *FindLink:
+		p.mu.Lock()
+		id := 0
+		if post := findLink(p.posts, msg.Subreddit, msg.URL); post != nil {
+			id = post.ID
+		}
+		p.mu.Unlock()
+		ctx.Respond(id)
+
+	case *GetDomainPosts:
+		p.mu.Lock()
+		posts := withoutHidden(domainPosts(p.posts, LinkDomain(msg.Domain)), msg.Hidden)
+		p.mu.Unlock()
+		ctx.Respond(paginate(posts, msg.Page, msg.PageSize, DefaultFeedPageSize, MaxFeedPageSize))
+
+	case *Search:
+		p.mu.Lock()
+		results := p.index.search(msg.Query, msg.AuthorID, msg.Page, msg.PageSize)
+		p.mu.Unlock()
+		p.logger.Debug("search", "request_id", msg.RequestID, "terms", msg.Query.Terms, "total", results.Total)
+		ctx.Respond(results)
+
+	case *Vote:
+		if msg.Target != "post" {
+			return
+		}
+		p.mu.Lock()
+		post, exists := p.posts[msg.ID]
+		p.mu.Unlock()
+		if !exists {
+			p.logger.Warn("post does not exist", "request_id", msg.RequestID, "post_id", msg.ID)
+			return
+		}
+		p.auditVote(ctx, msg, post.Subreddit, func(reasons []string) {
+			p.mu.Lock()
+			p.countVote(ctx, msg, reasons)
+			p.mu.Unlock()
+		})
+	}
+}
+
+// countVote applies a post vote to the score and the author's karma, unless vote analysis
+// found reasons to discount it; p.mu must be held.
+func (p *PostActor) countVote(ctx actor.Context, msg *Vote, reasons []string) {
+	post, exists := p.posts[msg.ID]
+	switch {
+	case !exists:
+		return
+	case len(reasons) > 0:
+		p.logger.Info("post vote discounted", "request_id", msg.RequestID, "post_id", msg.ID, "vote", msg.Type, "user_id", msg.UserID, "reasons", reasons)
+		return
+	}
+	karmaChange := 0
+	if msg.Type == "upvote" {
+		post.Upvotes++
+		karmaChange = 1
+	} else if msg.Type == "downvote" {
+		post.Downvotes++
+		karmaChange = -1
+	}
+	p.store.SavePost(post)
+	p.reportActivity(ctx, post.Subreddit, "vote", post.ID)
+	publish(ctx, LiveEvent{Type: EventPostScore, Subreddit: post.Subreddit, PostID: post.ID, UserID: msg.UserID, Score: post.Upvotes - post.Downvotes})
+	if karmaChange != 0 {
+		if p.userActor == nil {
+			p.logger.Error("no UserActor assigned, karma not updated", "request_id", msg.RequestID, "user_id", post.UserID)
+		} else {
+			ctx.Send(p.userActor, &UpdateKarma{UserID: post.UserID, KarmaChange: karmaChange, RequestID: msg.RequestID})
+		}
+	}
+	p.logger.Info("post voted", "request_id", msg.RequestID, "post_id", msg.ID, "vote", msg.Type, "user_id", msg.UserID)
+}
+
+// ownPost returns the live post postID when userID wrote it, logging why not otherwise.
+func (p *PostActor) ownPost(postID, userID int, requestID string) *Post {
+	post, exists := p.posts[postID]
+	switch {
+	case !exists || post.Deleted:
+		p.logger.Warn("post does not exist", "request_id", requestID, "post_id", postID)
+		return nil
+	case post.UserID != userID:
+		p.logger.Warn("user is not the author of the post", "request_id", requestID, "post_id", postID, "user_id", userID)
+		return nil
+	}
+	return post
+}
+
+// ownComment returns the live comment commentID when userID wrote it, logging why not otherwise.
+func (p *PostActor) ownComment(commentID, userID int, requestID string) *Comment {
+	comment, exists := p.comments[commentID]
+	switch {
+	case !exists || comment.Deleted:
+		p.logger.Warn("comment does not exist", "request_id", requestID, "comment_id", commentID)
+		return nil
+	case comment.UserID != userID:
+		p.logger.Warn("user is not the author of the comment", "request_id", requestID, "comment_id", commentID, "user_id", userID)
+		return nil
+	}
+	return comment
+}
+
+// createPost adds the post msg describes once AutoModerator has seen it; author is nil when
+// the subreddit's rules don't look at authors or the author is unknown.
+func (p *PostActor) createPost(ctx actor.Context, msg *PostMessage, author *User) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if msg.Kind == PostLink {
+		if existing := findLink(p.posts, msg.Subreddit, msg.URL); existing != nil {
+			p.logger.Warn("duplicate link", "request_id", msg.RequestID, "subreddit", msg.Subreddit, "url", msg.URL, "post_id", existing.ID)
+			return
+		}
+	}
+	id := len(p.posts) + 1
+	post := &Post{
+		ID:        id,
+		UserID:    msg.UserID,
+		Subreddit: msg.Subreddit,
+		Title:     msg.Title,
+		Kind:      msg.Kind,
+		URL:       msg.URL,
+		Content:   msg.Content,
+		Media:     append([]Media(nil), msg.Media...),
+		Flair:     msg.Flair,
+	}
+	if msg.URL != "" {
+		post.Domain = domainOf(msg.URL)
+	}
+	verdict, spamScore, spam := p.screenPost(post, author)
+	p.addPost(ctx, post)
+	if post.live() {
+		p.notifyMentions(ctx, msg.Content, Notification{FromUserID: msg.UserID, PostID: id, Subreddit: msg.Subreddit, Excerpt: excerpt(msg.Content)})
+	}
+	p.logger.Info("post created", "request_id", msg.RequestID, "post_id", id, "subreddit", msg.Subreddit, "user_id", msg.UserID)
+	p.enforceAutoMod(ctx, post, nil, verdict, msg.RequestID)
+	if spam {
+		p.holdSpam(ctx, ReportPost, post.ID, post.Subreddit, spamScore, msg.RequestID)
+	}
+}
+
+// screen runs a post or comment past the subreddit's AutoModerator rules and then, unless
+// they remove it, the spam filter; p.mu must be held.
+func (p *PostActor) screen(subreddit string, item AutoModItem, spamText string, author *User) (verdict AutoModVerdict, spamScore float64, spam bool) {
+	verdict = evaluateAutoMod(p.automod[subreddit], item, author)
+	if verdict.Removal == "" {
+		spamScore, spam = p.scoreSpam(spamText)
+	}
+	return verdict, spamScore, spam
+}
+
+func postAutoModItem(post *Post) AutoModItem {
+	item := AutoModItem{Type: AutoModPost, Title: post.Title, Content: post.Content, URL: post.URL}
+	if post.Flair != nil {
+		item.Flair = post.Flair.Text
+	}
+	return item
+}
+
+// screenPost runs a new post past AutoModerator and then the spam filter and applies what they
+// decided, rendering its content; p.mu must be held. The caller adds the post, then carries
+// out the rest of the verdict with enforceAutoMod and holds spam with holdSpam.
+func (p *PostActor) screenPost(post *Post, author *User) (verdict AutoModVerdict, spamScore float64, spam bool) {
+	verdict, spamScore, spam = p.screen(post.Subreddit, postAutoModItem(post), postSpamText(post), author)
+	switch {
+	case verdict.Removal == AutoModRemove:
+		post.Deleted = true
+		post.Content = "[removed]"
+	case verdict.Removal == AutoModFilter || spam:
+		post.Filtered = true
+	}
+	post.Locked = verdict.Lock
+	if verdict.Flair != nil {
+		post.Flair = verdict.Flair
+	}
+	post.ContentHTML = RenderMarkdown(post.Content)
+	return verdict, spamScore, spam
+}
+
+// createComment is createPost for comments.
+func (p *PostActor) createComment(ctx actor.Context, msg *CommentMessage, author *User) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	post, exists := p.posts[msg.PostID]
+	switch {
+	case !exists || !post.live():
+		p.logger.Warn("post does not exist", "request_id", msg.RequestID, "post_id", msg.PostID)
+		return
+	case post.Locked:
+		p.logger.Warn("post is locked", "request_id", msg.RequestID, "post_id", msg.PostID, "user_id", msg.UserID)
+		return
+	}
+	comment := &Comment{
+		ID:       len(p.comments) + 1,
+		PostID:   msg.PostID,
+		ParentID: msg.ParentID,
+		UserID:   msg.UserID,
+		Content:  msg.Content,
+	}
+	verdict, spamScore, spam := p.screen(post.Subreddit, AutoModItem{Type: AutoModComment, Content: comment.Content}, comment.Content, author)
+	switch {
+	case verdict.Removal == AutoModRemove:
+		comment.Deleted = true
+		comment.Content = "[removed]"
+	case verdict.Removal == AutoModFilter || spam:
+		comment.Filtered = true
+	}
+	comment.ContentHTML = RenderMarkdown(comment.Content)
+	p.addComment(ctx, post, comment)
+	p.logger.Info("comment added", "request_id", msg.RequestID, "post_id", msg.PostID, "comment_id", comment.ID, "user_id", msg.UserID)
+	p.enforceAutoMod(ctx, post, comment, verdict, msg.RequestID)
+	if spam {
+		p.holdSpam(ctx, ReportComment, comment.ID, post.Subreddit, spamScore, msg.RequestID)
+	}
+}
+
+// editPost replaces a post's content once AutoModerator and the spam filter have seen the new
+// content, as they saw the original. An edit they remove or hold takes the post down; they
+// only reply to new posts.
+func (p *PostActor) editPost(ctx actor.Context, msg *EditPost, author *User) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	post := p.ownPost(msg.PostID, msg.UserID, msg.RequestID)
+	if post == nil {
+		return
+	}
+	post.Content = msg.Content
+	verdict, spamScore, spam := p.screen(post.Subreddit, postAutoModItem(post), postSpamText(post), author)
+	verdict.Replies = nil
+	if verdict.Lock {
+		post.Locked = true
+	}
+	if verdict.Flair != nil {
+		post.Flair = verdict.Flair
+	}
+	switch {
+	case verdict.Removal == AutoModRemove:
+		p.deletePost(ctx, post, AutoModeratorID, true)
+	case verdict.Removal == AutoModFilter || spam:
+		wasLive := post.live()
+		post.Filtered = true
+		post.ContentHTML = RenderMarkdown(post.Content)
+		p.store.SavePost(post)
+		p.index.remove(postDocKey(post.ID))
+		if wasLive {
+			// Listeners lose sight of the post until a moderator approves it
+			publish(ctx, LiveEvent{Type: EventPostDeleted, Subreddit: post.Subreddit, PostID: post.ID, UserID: AutoModeratorID, Removed: true})
+		}
+	default:
+		post.ContentHTML = RenderMarkdown(post.Content)
+		p.store.SavePost(post)
+		if post.live() {
+			p.index.indexPost(post)
+			publish(ctx, LiveEvent{Type: EventPostEdited, Subreddit: post.Subreddit, PostID: post.ID, UserID: msg.UserID, Content: post.Content, ContentHTML: post.ContentHTML})
+		}
+	}
+	p.logger.Info("post edited", "request_id", msg.RequestID, "post_id", post.ID, "user_id", msg.UserID)
+	p.enforceAutoMod(ctx, post, nil, verdict, msg.RequestID)
+	if spam {
+		p.holdSpam(ctx, ReportPost, post.ID, post.Subreddit, spamScore, msg.RequestID)
+	}
+}
+
+// editComment is editPost for comments.
+func (p *PostActor) editComment(ctx actor.Context, msg *EditComment, author *User) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	comment := p.ownComment(msg.CommentID, msg.UserID, msg.RequestID)
+	if comment == nil {
+		return
+	}
+	post := p.posts[comment.PostID]
+	comment.Content = msg.Content
+	verdict, spamScore, spam := p.screen(post.Subreddit, AutoModItem{Type: AutoModComment, Content: comment.Content}, comment.Content, author)
+	verdict.Replies = nil
+	switch {
+	case verdict.Removal == AutoModRemove:
+		p.deleteComment(ctx, comment, AutoModeratorID, true)
+	case verdict.Removal == AutoModFilter || spam:
+		wasLive := post.live() && comment.live()
+		comment.Filtered = true
+		comment.ContentHTML = RenderMarkdown(comment.Content)
+		p.store.SavePost(post)
+		p.index.remove(commentDocKey(comment.ID))
+		if wasLive {
+			publish(ctx, LiveEvent{Type: EventCommentDeleted, Subreddit: post.Subreddit, PostID: post.ID, CommentID: comment.ID, UserID: AutoModeratorID, Removed: true})
+		}
+	default:
+		comment.ContentHTML = RenderMarkdown(comment.Content)
+		p.store.SavePost(post)
+		if post.live() && comment.live() {
+			p.index.indexComment(post, comment)
+			publish(ctx, LiveEvent{Type: EventCommentEdited, Subreddit: post.Subreddit, PostID: post.ID, CommentID: comment.ID, UserID: msg.UserID, Content: comment.Content, ContentHTML: comment.ContentHTML})
+		}
+	}
+	p.logger.Info("comment edited", "request_id", msg.RequestID, "comment_id", comment.ID, "user_id", msg.UserID)
+	p.enforceAutoMod(ctx, post, comment, verdict, msg.RequestID)
+	if spam {
+		p.holdSpam(ctx, ReportComment, comment.ID, post.Subreddit, spamScore, msg.RequestID)
+	}
+}
+
+// addPost stores a new post, and indexes and announces it when it is live; p.mu must be held.
+func (p *PostActor) addPost(ctx actor.Context, post *Post) {
+	p.posts[post.ID] = post
+	p.store.SavePost(post)
+	if post.live() {
+		p.announcePost(ctx, post)
+	}
+}
+
+func (p *PostActor) announcePost(ctx actor.Context, post *Post) {
+	p.index.indexPost(post)
+	p.reportActivity(ctx, post.Subreddit, "post", post.ID)
+	publish(ctx, LiveEvent{Type: EventPostCreated, Subreddit: post.Subreddit, PostID: post.ID, UserID: post.UserID, Content: post.Content, ContentHTML: post.ContentHTML})
+}
+
+// addComment stores a new comment on post, and indexes and announces it when both are live;
+// p.mu must be held.
+func (p *PostActor) addComment(ctx actor.Context, post *Post, comment *Comment) {
+	post.Comments = append(post.Comments, comment)
+	p.comments[comment.ID] = comment
+	p.store.SavePost(post)
+	if post.live() && comment.live() {
+		p.announceComment(ctx, post, comment)
+	}
+}
+
+func (p *PostActor) announceComment(ctx actor.Context, post *Post, comment *Comment) {
+	p.index.indexComment(post, comment)
+	p.reportActivity(ctx, post.Subreddit, "comment", post.ID)
+	publish(ctx, LiveEvent{Type: EventCommentCreated, Subreddit: post.Subreddit, PostID: post.ID, CommentID: comment.ID, UserID: comment.UserID, Content: comment.Content, ContentHTML: comment.ContentHTML})
+	p.notifyReply(ctx, post, comment)
+}
+
+// live reports whether the post is visible: neither deleted nor held by AutoModerator
+func (p *Post) live() bool {
+	return !p.Deleted && !p.Filtered
+}
+
+func (c *Comment) live() bool {
+	return !c.Deleted && !c.Filtered
+}
+
+// deletePost replaces a post's content with a placeholder, unindexes it and announces its
+// deletion by userID: its author, or a moderator or AutoModerator when removed is set; p.mu
+// must be held.
+func (p *PostActor) deletePost(ctx actor.Context, post *Post, userID int, removed bool) {
+	wasLive := post.live()
+	post.Deleted = true
+	post.Content = deletedPlaceholder(removed)
+	post.ContentHTML = RenderMarkdown(post.Content)
+	p.store.SavePost(post)
+	p.index.remove(postDocKey(post.ID))
+	if post.CrosspostOf != nil {
+		if parent, exists := p.posts[post.CrosspostOf.PostID]; exists && parent.Crossposts > 0 {
+			parent.Crossposts--
+			p.store.SavePost(parent)
+		}
+	}
+	if wasLive {
+		publish(ctx, LiveEvent{Type: EventPostDeleted, Subreddit: post.Subreddit, PostID: post.ID, UserID: userID, Removed: removed})
+	}
+}
+
+// deleteComment is deletePost for comments; p.mu must be held.
+func (p *PostActor) deleteComment(ctx actor.Context, comment *Comment, userID int, removed bool) {
+	wasLive := comment.live()
+	comment.Deleted = true
+	comment.Content = deletedPlaceholder(removed)
+	comment.ContentHTML = RenderMarkdown(comment.Content)
+	post := p.posts[comment.PostID]
+	p.store.SavePost(post)
+	p.index.remove(commentDocKey(comment.ID))
+	if wasLive {
+		publish(ctx, LiveEvent{Type: EventCommentDeleted, Subreddit: post.Subreddit, PostID: post.ID, CommentID: comment.ID, UserID: userID, Removed: removed})
+	}
+}
+
+// deletedPlaceholder is the content left of a post or comment its author deleted, or one that
+// was removed.
+func deletedPlaceholder(removed bool) string {
+	if removed {
+		return "[removed]"
+	}
+	return "[deleted]"
+}
+
+// reportActivity tells SubredditActor about a post, comment or vote so it can rank subreddits.
+func (p *PostActor) reportActivity(ctx actor.Context, subreddit, kind string, postID int) {
+	if p.subredditActor != nil {
+		ctx.Send(p.subredditActor, &SubredditActivity{Name: subreddit, Kind: kind, PostID: postID, At: time.Now()})
+	}
+}
+
+// notifyReply tells the author of the post or parent comment about a new comment, and
+// anyone the comment mentions.
+func (p *PostActor) notifyReply(ctx actor.Context, post *Post, comment *Comment) {
+	notification := Notification{
+		Type:       NotificationPostReply,
+		FromUserID: comment.UserID,
+		PostID:     post.ID,
+		CommentID:  comment.ID,
+		Subreddit:  post.Subreddit,
+		Excerpt:    excerpt(comment.Content),
+	}
+	recipient := post.UserID
+	if parent, exists := p.comments[comment.ParentID]; exists && parent.PostID == post.ID {
+		notification.Type = NotificationCommentReply
+		recipient = parent.UserID
+	}
+	if p.notificationActor != nil && recipient != comment.UserID && recipient != AutoModeratorID {
+		ctx.Send(p.notificationActor, &Notify{RecipientID: recipient, Notification: notification})
+	}
+	p.notifyMentions(ctx, comment.Content, notification)
+}
+
+// notifyMentions sends a mention notification for every u/name in content.
+func (p *PostActor) notifyMentions(ctx actor.Context, content string, notification Notification) {
+	if p.notificationActor == nil {
+		return
+	}
+	notification.Type = NotificationMention
+	for _, name := range mentions(content) {
+		ctx.Send(p.notificationActor, &Notify{RecipientName: name, Notification: notification})
 	}
 }
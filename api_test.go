@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reddit_clone2/apiclient"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestAPIClient serves the REST API of a fresh engine and returns the generated client for it
+func newTestAPIClient(t *testing.T) *apiclient.ClientWithResponses {
+	t.Helper()
+	useTestEngine(t)
+	server := httptest.NewServer(newRouter())
+	t.Cleanup(server.Close)
+	api, err := apiclient.New(server.URL, server.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return api
+}
+
+func TestAPIClientRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	api := newTestAPIClient(t)
+
+	if _, err := api.RegisterUserWithResponse(ctx, apiclient.RegisterUserRequest{Username: "alice", Password: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	creator := 1
+	if _, err := api.CreateSubredditWithResponse(ctx, apiclient.CreateSubredditRequest{Name: "golang", CreatorID: &creator}); err != nil {
+		t.Fatal(err)
+	}
+	title := "hello"
+	posted, err := api.CreatePostWithResponse(ctx, apiclient.CreatePostRequest{UserID: 1, Subreddit: "golang", Title: &title, Content: "from the client"}, apiclient.WithRequestID("test-post"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := posted.HTTPResponse.Header.Get("X-Request-ID"); got != "test-post" {
+		t.Errorf("X-Request-ID = %q, want test-post", got)
+	}
+
+	eventually(t, "the post listed", func() bool {
+		listed, err := api.ListSubredditPostsWithResponse(ctx, "golang", &apiclient.ListSubredditPostsParams{})
+		return err == nil && listed.JSON200 != nil && len(*listed.JSON200) == 1 && *(*listed.JSON200)[0].Title == "hello"
+	})
+}
+
+func TestAPIClientErrors(t *testing.T) {
+	ctx := context.Background()
+	api := newTestAPIClient(t)
+
+	content := strings.Repeat("x", settings.Engine.MaxContentLength+1)
+	_, err := api.CreatePostWithResponse(ctx, apiclient.CreatePostRequest{UserID: 1, Subreddit: "golang", Content: content})
+	var apiErr *apiclient.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest || apiErr.Message == "" {
+		t.Fatalf("overlong post: %v, want an *apiclient.Error with 400 and a message", err)
+	}
+}
+
+func TestAPIClientRetryAfter(t *testing.T) {
+	ctx := context.Background()
+	api := newTestAPIClient(t)
+	settings.RateLimit.VotesPerMinute, settings.RateLimit.Burst = 6, 0
+
+	vote := apiclient.VoteRequest{UserID: 1, Target: apiclient.VoteRequestTargetPost, ID: 1, Type: apiclient.Upvote}
+	if _, err := api.VoteWithResponse(ctx, vote); err != nil {
+		t.Fatal(err)
+	}
+	_, err := api.VoteWithResponse(ctx, vote)
+	var apiErr *apiclient.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests || apiErr.RetryAfter != 10*time.Second {
+		t.Fatalf("second vote: %v, want an *apiclient.Error with 429 and a 10s RetryAfter", err)
+	}
+}
@@ -0,0 +1,8171 @@
+// Package apiclient provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/deepmap/oapi-codegen/v2 version v2.1.0 DO NOT EDIT.
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for AutoModItemType.
+const (
+	AutoModItemTypeComment AutoModItemType = "comment"
+	AutoModItemTypePost    AutoModItemType = "post"
+)
+
+// Defines values for AutoModRuleAction.
+const (
+	AutoModRuleActionFilter AutoModRuleAction = "filter"
+	AutoModRuleActionFlair  AutoModRuleAction = "flair"
+	AutoModRuleActionLock   AutoModRuleAction = "lock"
+	AutoModRuleActionRemove AutoModRuleAction = "remove"
+	AutoModRuleActionReply  AutoModRuleAction = "reply"
+)
+
+// Defines values for AutoModRuleType.
+const (
+	AutoModRuleTypeComment AutoModRuleType = "comment"
+	AutoModRuleTypePost    AutoModRuleType = "post"
+)
+
+// Defines values for AutoModVerdictRemoval.
+const (
+	AutoModVerdictRemovalFilter AutoModVerdictRemoval = "filter"
+	AutoModVerdictRemovalRemove AutoModVerdictRemoval = "remove"
+)
+
+// Defines values for CreateFlairRequestType.
+const (
+	CreateFlairRequestTypePost CreateFlairRequestType = "post"
+	CreateFlairRequestTypeUser CreateFlairRequestType = "user"
+)
+
+// Defines values for CreatePostRequestKind.
+const (
+	CreatePostRequestKindImage CreatePostRequestKind = "image"
+	CreatePostRequestKindLink  CreatePostRequestKind = "link"
+	CreatePostRequestKindText  CreatePostRequestKind = "text"
+)
+
+// Defines values for FlairType.
+const (
+	FlairTypePost FlairType = "post"
+	FlairTypeUser FlairType = "user"
+)
+
+// Defines values for PostSummaryKind.
+const (
+	PostSummaryKindImage PostSummaryKind = "image"
+	PostSummaryKindLink  PostSummaryKind = "link"
+	PostSummaryKindText  PostSummaryKind = "text"
+)
+
+// Defines values for RegisterWebhookRequestEvents.
+const (
+	CommentCreated RegisterWebhookRequestEvents = "comment_created"
+	ContentDeleted RegisterWebhookRequestEvents = "content_deleted"
+	ContentRemoved RegisterWebhookRequestEvents = "content_removed"
+	PostCreated    RegisterWebhookRequestEvents = "post_created"
+)
+
+// Defines values for ReportTarget.
+const (
+	ReportTargetComment ReportTarget = "comment"
+	ReportTargetPost    ReportTarget = "post"
+)
+
+// Defines values for ReportContentRequestTarget.
+const (
+	ReportContentRequestTargetComment ReportContentRequestTarget = "comment"
+	ReportContentRequestTargetPost    ReportContentRequestTarget = "post"
+)
+
+// Defines values for ResolveReportsRequestAction.
+const (
+	Approve ResolveReportsRequestAction = "approve"
+	Ignore  ResolveReportsRequestAction = "ignore"
+	Remove  ResolveReportsRequestAction = "remove"
+)
+
+// Defines values for SavedItemType.
+const (
+	SavedItemTypeComment SavedItemType = "comment"
+	SavedItemTypePost    SavedItemType = "post"
+)
+
+// Defines values for SearchHitType.
+const (
+	SearchHitTypeComment SearchHitType = "comment"
+	SearchHitTypePost    SearchHitType = "post"
+)
+
+// Defines values for VoteRequestTarget.
+const (
+	VoteRequestTargetComment VoteRequestTarget = "comment"
+	VoteRequestTargetPost    VoteRequestTarget = "post"
+)
+
+// Defines values for VoteRequestType.
+const (
+	Downvote VoteRequestType = "downvote"
+	Upvote   VoteRequestType = "upvote"
+)
+
+// Defines values for ListSubredditsParamsSort.
+const (
+	Activity ListSubredditsParamsSort = "activity"
+	Members  ListSubredditsParamsSort = "members"
+	Newest   ListSubredditsParamsSort = "newest"
+)
+
+// Defines values for ListFlairsParamsType.
+const (
+	ListFlairsParamsTypePost ListFlairsParamsType = "post"
+	ListFlairsParamsTypeUser ListFlairsParamsType = "user"
+)
+
+// Defines values for ResolveReportsParamsTarget.
+const (
+	ResolveReportsParamsTargetComment ResolveReportsParamsTarget = "comment"
+	ResolveReportsParamsTargetPost    ResolveReportsParamsTarget = "post"
+)
+
+// Defines values for GetSavedParamsType.
+const (
+	GetSavedParamsTypeComment GetSavedParamsType = "comment"
+	GetSavedParamsTypePost    GetSavedParamsType = "post"
+)
+
+// ActingUser defines model for ActingUser.
+type ActingUser struct {
+	UserID int `json:"UserID"`
+}
+
+// AddCommentRequest defines model for AddCommentRequest.
+type AddCommentRequest struct {
+	Content string `json:"Content"`
+
+	// ParentID Comment replied to; 0 for a top-level comment
+	ParentID *int `json:"ParentID,omitempty"`
+	PostID   int  `json:"PostID"`
+	UserID   int  `json:"UserID"`
+}
+
+// AutoModItem defines model for AutoModItem.
+type AutoModItem struct {
+	Content *string `json:"content,omitempty"`
+
+	// Flair Text of the post flair
+	Flair *string         `json:"flair,omitempty"`
+	Title *string         `json:"title,omitempty"`
+	Type  AutoModItemType `json:"type"`
+	Url   *string         `json:"url,omitempty"`
+}
+
+// AutoModItemType defines model for AutoModItem.Type.
+type AutoModItemType string
+
+// AutoModRule A rule matches a new post or comment when every condition it sets holds; author
+// conditions only hold for registered users. Every matching rule applies, in order:
+// remove wins over filter, and the first flair rule sets the flair.
+type AutoModRule struct {
+	// Action filter holds the item in the moderator queue until a moderator approves it; flair and lock need type post
+	Action AutoModRuleAction `json:"action"`
+
+	// AuthorAccountAgeBelow A duration such as 72h
+	AuthorAccountAgeBelow *string `json:"author_account_age_below,omitempty"`
+	AuthorKarmaBelow      *int    `json:"author_karma_below,omitempty"`
+	BodyRegex             *string `json:"body_regex,omitempty"`
+
+	// Domains Link and image posts to these domains or their subdomains
+	Domains *[]string `json:"domains,omitempty"`
+
+	// Flairs Posts with one of these post flairs, by text
+	Flairs *[]string `json:"flairs,omitempty"`
+	Name   string    `json:"name"`
+
+	// Reply Markdown AutoModerator replies with; required by the reply action
+	Reply         *string `json:"reply,omitempty"`
+	SetFlair      *string `json:"set_flair,omitempty"`
+	SetFlairColor *string `json:"set_flair_color,omitempty"`
+
+	// TitleRegex RE2 syntax; posts only
+	TitleRegex *string `json:"title_regex,omitempty"`
+
+	// Type Posts and comments when left out
+	Type *AutoModRuleType `json:"type,omitempty"`
+}
+
+// AutoModRuleAction filter holds the item in the moderator queue until a moderator approves it; flair and lock need type post
+type AutoModRuleAction string
+
+// AutoModRuleType Posts and comments when left out
+type AutoModRuleType string
+
+// AutoModVerdict defines model for AutoModVerdict.
+type AutoModVerdict struct {
+	Flair *Flair `json:"flair,omitempty"`
+	Lock  *bool  `json:"lock,omitempty"`
+
+	// Reason Name of the rule that decided the removal
+	Reason  *string                `json:"reason,omitempty"`
+	Removal *AutoModVerdictRemoval `json:"removal,omitempty"`
+	Replies *[]string              `json:"replies,omitempty"`
+
+	// Rules Names of the matching rules, in order
+	Rules []string `json:"rules"`
+}
+
+// AutoModVerdictRemoval defines model for AutoModVerdict.Removal.
+type AutoModVerdictRemoval string
+
+// Brigade defines model for Brigade.
+type Brigade struct {
+	Discounted int       `json:"discounted"`
+	From       string    `json:"from"`
+	Into       string    `json:"into"`
+	LastAt     time.Time `json:"last_at"`
+	StartedAt  time.Time `json:"started_at"`
+}
+
+// CommentSummary defines model for CommentSummary.
+type CommentSummary struct {
+	Content     string `json:"content"`
+	ContentHtml string `json:"content_html"`
+	Downvotes   int    `json:"downvotes"`
+	Filtered    *bool  `json:"filtered,omitempty"`
+	Id          int    `json:"id"`
+	ParentId    *int   `json:"parent_id,omitempty"`
+	PostId      int    `json:"post_id"`
+	Score       int    `json:"score"`
+	Subreddit   string `json:"subreddit"`
+	Upvotes     int    `json:"upvotes"`
+	UserId      int    `json:"user_id"`
+}
+
+// CreateFlairRequest defines model for CreateFlairRequest.
+type CreateFlairRequest struct {
+	Color   *string                `json:"Color,omitempty"`
+	ModOnly *bool                  `json:"ModOnly,omitempty"`
+	Text    string                 `json:"Text"`
+	Type    CreateFlairRequestType `json:"Type"`
+
+	// UserID ID of the moderator asking
+	UserID int `json:"UserID"`
+}
+
+// CreateFlairRequestType defines model for CreateFlairRequest.Type.
+type CreateFlairRequestType string
+
+// CreatePostRequest Link and image posts need a Title and URL; text posts may leave the Title out.
+type CreatePostRequest struct {
+	Content string `json:"Content"`
+
+	// FlairID A post flair template of the subreddit
+	FlairID *int                   `json:"FlairID,omitempty"`
+	Kind    *CreatePostRequestKind `json:"Kind,omitempty"`
+
+	// MediaIDs Uploads of the same user to attach; not allowed on link posts
+	MediaIDs  *[]string `json:"MediaIDs,omitempty"`
+	Subreddit string    `json:"Subreddit"`
+	Title     *string   `json:"Title,omitempty"`
+
+	// URL An http or https URL, for link and image posts
+	URL    *string `json:"URL,omitempty"`
+	UserID int     `json:"UserID"`
+}
+
+// CreatePostRequestKind defines model for CreatePostRequest.Kind.
+type CreatePostRequestKind string
+
+// CreateSubredditRequest defines model for CreateSubredditRequest.
+type CreateSubredditRequest struct {
+	// CreatorID Becomes the first member and moderator
+	CreatorID *int   `json:"CreatorID,omitempty"`
+	Name      string `json:"Name"`
+}
+
+// CrosspostParent The post a crosspost was made from, with its author and subreddit
+type CrosspostParent struct {
+	PostId    int    `json:"post_id"`
+	Subreddit string `json:"subreddit"`
+	UserId    int    `json:"user_id"`
+}
+
+// CrosspostRequest defines model for CrosspostRequest.
+type CrosspostRequest struct {
+	// Subreddit The target subreddit
+	Subreddit string `json:"Subreddit"`
+
+	// Title Defaults to the original post's title
+	Title *string `json:"Title,omitempty"`
+
+	// UserID Must be a member of the target subreddit
+	UserID int `json:"UserID"`
+}
+
+// EditContentRequest defines model for EditContentRequest.
+type EditContentRequest struct {
+	Content string `json:"Content"`
+	UserID  int    `json:"UserID"`
+}
+
+// FlaggedAccount defines model for FlaggedAccount.
+type FlaggedAccount struct {
+	Discounted     int       `json:"discounted"`
+	FirstFlaggedAt time.Time `json:"first_flagged_at"`
+	LastFlaggedAt  time.Time `json:"last_flagged_at"`
+
+	// Reasons Discounted votes per reason (lockstep, brigade or fingerprint)
+	Reasons map[string]int `json:"reasons"`
+	UserId  int            `json:"user_id"`
+}
+
+// Flair defines model for Flair.
+type Flair struct {
+	// Color Background color as
+	Color *string `json:"color,omitempty"`
+	Id    int     `json:"id"`
+
+	// ModOnly Only moderators may apply it
+	ModOnly bool      `json:"mod_only"`
+	Text    string    `json:"text"`
+	Type    FlairType `json:"type"`
+}
+
+// FlairType defines model for Flair.Type.
+type FlairType string
+
+// Health defines model for Health.
+type Health struct {
+	ActorFailures int `json:"actor_failures"`
+	DeadLetters   int `json:"dead_letters"`
+}
+
+// LockstepPair defines model for LockstepPair.
+type LockstepPair struct {
+	CoVotes   int       `json:"co_votes"`
+	FlaggedAt time.Time `json:"flagged_at"`
+	LastAt    time.Time `json:"last_at"`
+	Users     []int     `json:"users"`
+}
+
+// MarkNotificationsReadRequest defines model for MarkNotificationsReadRequest.
+type MarkNotificationsReadRequest struct {
+	// IDs Notifications to mark read; all of them when empty
+	IDs *[]int `json:"IDs,omitempty"`
+}
+
+// Media defines model for Media.
+type Media struct {
+	ContentType string    `json:"content_type"`
+	Height      *int      `json:"height,omitempty"`
+	Id          string    `json:"id"`
+	Size        int64     `json:"size"`
+	Thumbnail   bool      `json:"thumbnail"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+	UserId      int       `json:"user_id"`
+	Width       *int      `json:"width,omitempty"`
+}
+
+// MembershipRequest defines model for MembershipRequest.
+type MembershipRequest struct {
+	UserID int `json:"UserID"`
+}
+
+// Notification defines model for Notification.
+type Notification struct {
+	CommentId  *int      `json:"comment_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	Excerpt    string    `json:"excerpt"`
+	FromUserId int       `json:"from_user_id"`
+	Id         int       `json:"id"`
+	PostId     *int      `json:"post_id,omitempty"`
+	Read       bool      `json:"read"`
+	Subreddit  *string   `json:"subreddit,omitempty"`
+	Type       string    `json:"type"`
+}
+
+// NotificationPage defines model for NotificationPage.
+type NotificationPage struct {
+	Notifications []Notification `json:"notifications"`
+	Unread        int            `json:"unread"`
+}
+
+// PostSummary defines model for PostSummary.
+type PostSummary struct {
+	AuthorFlair *Flair `json:"author_flair,omitempty"`
+	Comments    int    `json:"comments"`
+
+	// Content Markdown source
+	Content string `json:"content"`
+
+	// ContentHtml The content rendered to HTML, with r/ and u/ references linked and anything unsafe removed
+	ContentHtml string `json:"content_html"`
+
+	// CrosspostParent The post a crosspost was made from, with its author and subreddit
+	CrosspostParent *CrosspostParent `json:"crosspost_parent,omitempty"`
+
+	// Crossposts Live crossposts of this post
+	Crossposts int     `json:"crossposts"`
+	Domain     *string `json:"domain,omitempty"`
+	Downvotes  int     `json:"downvotes"`
+
+	// Filtered Held by AutoModerator until a moderator approves it; only shown in the moderator queue
+	Filtered *bool           `json:"filtered,omitempty"`
+	Flair    *Flair          `json:"flair,omitempty"`
+	Id       int             `json:"id"`
+	Kind     PostSummaryKind `json:"kind"`
+
+	// Locked New comments are not accepted
+	Locked    *bool    `json:"locked,omitempty"`
+	Media     *[]Media `json:"media,omitempty"`
+	Score     int      `json:"score"`
+	Subreddit string   `json:"subreddit"`
+	Title     *string  `json:"title,omitempty"`
+	Upvotes   int      `json:"upvotes"`
+
+	// Url The normalized URL of a link or image post
+	Url    *string `json:"url,omitempty"`
+	UserId int     `json:"user_id"`
+}
+
+// PostSummaryKind defines model for PostSummary.Kind.
+type PostSummaryKind string
+
+// RegisterUserRequest defines model for RegisterUserRequest.
+type RegisterUserRequest struct {
+	Password string `json:"Password"`
+	Username string `json:"Username"`
+}
+
+// RegisterWebhookRequest defines model for RegisterWebhookRequest.
+type RegisterWebhookRequest struct {
+	Events []RegisterWebhookRequestEvents `json:"Events"`
+	URL    string                         `json:"URL"`
+	UserID int                            `json:"UserID"`
+}
+
+// RegisterWebhookRequestEvents defines model for RegisterWebhookRequest.Events.
+type RegisterWebhookRequestEvents string
+
+// Report The open reports of a post or comment, with the item as it is now
+type Report struct {
+	Comment         *CommentSummary `json:"comment,omitempty"`
+	FirstReportedAt time.Time       `json:"first_reported_at"`
+	Id              int             `json:"id"`
+	LastReportedAt  time.Time       `json:"last_reported_at"`
+	Post            *PostSummary    `json:"post,omitempty"`
+
+	// Reasons Number of reports per reason
+	Reasons   map[string]int `json:"reasons"`
+	Reports   int            `json:"reports"`
+	Subreddit string         `json:"subreddit"`
+	Target    ReportTarget   `json:"target"`
+}
+
+// ReportTarget defines model for Report.Target.
+type ReportTarget string
+
+// ReportContentRequest defines model for ReportContentRequest.
+type ReportContentRequest struct {
+	ID int `json:"ID"`
+
+	// Reason One of the subreddit's report reasons, in any case
+	Reason string                     `json:"Reason"`
+	Target ReportContentRequestTarget `json:"Target"`
+	UserID int                        `json:"UserID"`
+}
+
+// ReportContentRequestTarget defines model for ReportContentRequest.Target.
+type ReportContentRequestTarget string
+
+// ResolveReportsRequest defines model for ResolveReportsRequest.
+type ResolveReportsRequest struct {
+	// Action Ignore keeps the item and stops counting new reports of it
+	Action ResolveReportsRequestAction `json:"Action"`
+
+	// UserID ID of the moderator acting
+	UserID int `json:"UserID"`
+}
+
+// ResolveReportsRequestAction Ignore keeps the item and stops counting new reports of it
+type ResolveReportsRequestAction string
+
+// SavedItem A saved post or comment, as it is now; deleted ones read [deleted]
+type SavedItem struct {
+	Comment *CommentSummary `json:"comment,omitempty"`
+	Id      int             `json:"id"`
+	Post    *PostSummary    `json:"post,omitempty"`
+	SavedAt time.Time       `json:"saved_at"`
+	Type    SavedItemType   `json:"type"`
+}
+
+// SavedItemType defines model for SavedItem.Type.
+type SavedItemType string
+
+// SearchHit defines model for SearchHit.
+type SearchHit struct {
+	CommentId   *int          `json:"comment_id,omitempty"`
+	Content     string        `json:"content"`
+	ContentHtml string        `json:"content_html"`
+	PostId      int           `json:"post_id"`
+	Score       float64       `json:"score"`
+	Subreddit   string        `json:"subreddit"`
+	Title       *string       `json:"title,omitempty"`
+	Type        SearchHitType `json:"type"`
+	UserId      int           `json:"user_id"`
+}
+
+// SearchHitType defines model for SearchHit.Type.
+type SearchHitType string
+
+// SearchResults defines model for SearchResults.
+type SearchResults struct {
+	Hits     []SearchHit `json:"hits"`
+	Page     int         `json:"page"`
+	PageSize int         `json:"page_size"`
+	Total    int         `json:"total"`
+}
+
+// SetFlairRequest defines model for SetFlairRequest.
+type SetFlairRequest struct {
+	// FlairID A flair template of the subreddit, or 0 to clear the flair
+	FlairID int `json:"FlairID"`
+	UserID  int `json:"UserID"`
+}
+
+// SetReportReasonsRequest defines model for SetReportReasonsRequest.
+type SetReportReasonsRequest struct {
+	// Reasons Replaces the subreddit's reasons; empty restores the defaults
+	Reasons []string `json:"Reasons"`
+
+	// UserID ID of the moderator asking
+	UserID int `json:"UserID"`
+}
+
+// SharedFingerprint defines model for SharedFingerprint.
+type SharedFingerprint struct {
+	Discounted  int       `json:"discounted"`
+	Fingerprint string    `json:"fingerprint"`
+	LastAt      time.Time `json:"last_at"`
+	Users       []int     `json:"users"`
+}
+
+// SpamStats defines model for SpamStats.
+type SpamStats struct {
+	Enabled bool `json:"enabled"`
+
+	// Examples Moderator decisions kept for export
+	Examples     int `json:"examples"`
+	HamDocuments int `json:"ham_documents"`
+	MinExamples  int `json:"min_examples"`
+
+	// Ready Whether the filter has seen min_examples spam and non-spam decisions and scores new content
+	Ready         bool      `json:"ready"`
+	SpamDocuments int       `json:"spam_documents"`
+	Threshold     float64   `json:"threshold"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Vocabulary    int       `json:"vocabulary"`
+}
+
+// SubredditSummary defines model for SubredditSummary.
+type SubredditSummary struct {
+	CreatedAt     time.Time `json:"created_at"`
+	LastActivity  time.Time `json:"last_activity"`
+	Members       int       `json:"members"`
+	Name          string    `json:"name"`
+	Posts         int       `json:"posts"`
+	TrendingScore *float64  `json:"trending_score,omitempty"`
+}
+
+// TestAutoModRequest defines model for TestAutoModRequest.
+type TestAutoModRequest struct {
+	// AuthorID Whose karma and account age the rules see
+	AuthorID *int        `json:"AuthorID,omitempty"`
+	Item     AutoModItem `json:"Item"`
+
+	// Rules Rules to try instead of the subreddit's own
+	Rules *[]AutoModRule `json:"Rules,omitempty"`
+
+	// UserID ID of the moderator asking
+	UserID int `json:"UserID"`
+}
+
+// User defines model for User.
+type User struct {
+	CommentKarma int       `json:"CommentKarma"`
+	CreatedAt    time.Time `json:"CreatedAt"`
+	ID           int       `json:"ID"`
+	Karma        int       `json:"Karma"`
+	Password     string    `json:"Password"`
+	PostKarma    int       `json:"PostKarma"`
+	Username     string    `json:"Username"`
+}
+
+// VoteReport defines model for VoteReport.
+type VoteReport struct {
+	Accounts     []FlaggedAccount    `json:"accounts"`
+	Brigades     []Brigade           `json:"brigades"`
+	Discounted   int                 `json:"discounted"`
+	Fingerprints []SharedFingerprint `json:"fingerprints"`
+	Lockstep     []LockstepPair      `json:"lockstep"`
+
+	// Votes Post votes analysed since the engine started
+	Votes int `json:"votes"`
+}
+
+// VoteRequest defines model for VoteRequest.
+type VoteRequest struct {
+	ID     int               `json:"ID"`
+	Target VoteRequestTarget `json:"Target"`
+	Type   VoteRequestType   `json:"Type"`
+	UserID int               `json:"UserID"`
+}
+
+// VoteRequestTarget defines model for VoteRequest.Target.
+type VoteRequestTarget string
+
+// VoteRequestType defines model for VoteRequest.Type.
+type VoteRequestType string
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	CreatedAt           time.Time `json:"created_at"`
+	CreatedBy           int       `json:"created_by"`
+	Disabled            bool      `json:"disabled"`
+	Events              []string  `json:"events"`
+	Id                  int       `json:"id"`
+	Secret              *string   `json:"secret,omitempty"`
+	Subreddit           string    `json:"subreddit"`
+	Url                 string    `json:"url"`
+}
+
+// ID defines model for ID.
+type ID = int
+
+// Limit defines model for Limit.
+type Limit = int
+
+// MediaID defines model for MediaID.
+type MediaID = string
+
+// Name defines model for Name.
+type Name = string
+
+// Page defines model for Page.
+type Page = int
+
+// PageSize defines model for PageSize.
+type PageSize = int
+
+// SubredditListing defines model for SubredditListing.
+type SubredditListing = []SubredditSummary
+
+// GetVoteReportParams defines parameters for GetVoteReport.
+type GetVoteReportParams struct {
+	// User ID of the admin asking
+	User int `form:"user" json:"user"`
+}
+
+// ListDomainPostsParams defines parameters for ListDomainPosts.
+type ListDomainPostsParams struct {
+	// User ID of the user listing; posts they hid are left out
+	User     *int      `form:"user,omitempty" json:"user,omitempty"`
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// UploadMediaMultipartBody defines parameters for UploadMedia.
+type UploadMediaMultipartBody struct {
+	UserID int                `json:"UserID"`
+	File   openapi_types.File `json:"file"`
+}
+
+// SearchParams defines parameters for Search.
+type SearchParams struct {
+	// Q Search terms, optionally with subreddit:, author: and type: (post or comment) filters
+	Q        *string   `form:"q,omitempty" json:"q,omitempty"`
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// StreamParams defines parameters for Stream.
+type StreamParams struct {
+	Subreddit *string `form:"subreddit,omitempty" json:"subreddit,omitempty"`
+	Post      *int    `form:"post,omitempty" json:"post,omitempty"`
+
+	// User Follow this user's karma and notifications
+	User *int `form:"user,omitempty" json:"user,omitempty"`
+}
+
+// ListSubredditsParams defines parameters for ListSubreddits.
+type ListSubredditsParams struct {
+	Sort     *ListSubredditsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+	Page     *Page                     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize                 `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// ListSubredditsParamsSort defines parameters for ListSubreddits.
+type ListSubredditsParamsSort string
+
+// AutocompleteSubredditsParams defines parameters for AutocompleteSubreddits.
+type AutocompleteSubredditsParams struct {
+	Prefix *string `form:"prefix,omitempty" json:"prefix,omitempty"`
+	Limit  *Limit  `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// TrendingSubredditsParams defines parameters for TrendingSubreddits.
+type TrendingSubredditsParams struct {
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetAutoModRulesParams defines parameters for GetAutoModRules.
+type GetAutoModRulesParams struct {
+	// User ID of the moderator asking
+	User int `form:"user" json:"user"`
+}
+
+// SetAutoModRulesJSONBody defines parameters for SetAutoModRules.
+type SetAutoModRulesJSONBody = []AutoModRule
+
+// SetAutoModRulesParams defines parameters for SetAutoModRules.
+type SetAutoModRulesParams struct {
+	// User ID of the moderator asking
+	User int `form:"user" json:"user"`
+}
+
+// ListFlairsParams defines parameters for ListFlairs.
+type ListFlairsParams struct {
+	// Type Only post or only user flair; both when left out
+	Type *ListFlairsParamsType `form:"type,omitempty" json:"type,omitempty"`
+}
+
+// ListFlairsParamsType defines parameters for ListFlairs.
+type ListFlairsParamsType string
+
+// GetModQueueParams defines parameters for GetModQueue.
+type GetModQueueParams struct {
+	// User ID of the moderator asking
+	User     int       `form:"user" json:"user"`
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// ResolveReportsParamsTarget defines parameters for ResolveReports.
+type ResolveReportsParamsTarget string
+
+// ListSubredditPostsParams defines parameters for ListSubredditPosts.
+type ListSubredditPostsParams struct {
+	// Flair ID of a post flair template; only posts with that flair are listed
+	Flair *int `form:"flair,omitempty" json:"flair,omitempty"`
+
+	// User ID of the user listing; posts they hid are left out
+	User     *int      `form:"user,omitempty" json:"user,omitempty"`
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// ListWebhooksParams defines parameters for ListWebhooks.
+type ListWebhooksParams struct {
+	// User ID of the moderator asking
+	User int `form:"user" json:"user"`
+}
+
+// GetNotificationsParams defines parameters for GetNotifications.
+type GetNotificationsParams struct {
+	// Unread Only list unread notifications
+	Unread   *bool     `form:"unread,omitempty" json:"unread,omitempty"`
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// GetSavedParams defines parameters for GetSaved.
+type GetSavedParams struct {
+	// Type Only saved posts or only saved comments; both when left out
+	Type     *GetSavedParamsType `form:"type,omitempty" json:"type,omitempty"`
+	Page     *Page               `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize           `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// GetSavedParamsType defines parameters for GetSaved.
+type GetSavedParamsType string
+
+// AddCommentJSONRequestBody defines body for AddComment for application/json ContentType.
+type AddCommentJSONRequestBody = AddCommentRequest
+
+// DeleteCommentJSONRequestBody defines body for DeleteComment for application/json ContentType.
+type DeleteCommentJSONRequestBody = ActingUser
+
+// EditCommentJSONRequestBody defines body for EditComment for application/json ContentType.
+type EditCommentJSONRequestBody = EditContentRequest
+
+// SaveCommentJSONRequestBody defines body for SaveComment for application/json ContentType.
+type SaveCommentJSONRequestBody = ActingUser
+
+// UnsaveCommentJSONRequestBody defines body for UnsaveComment for application/json ContentType.
+type UnsaveCommentJSONRequestBody = ActingUser
+
+// UploadMediaMultipartRequestBody defines body for UploadMedia for multipart/form-data ContentType.
+type UploadMediaMultipartRequestBody UploadMediaMultipartBody
+
+// CreatePostJSONRequestBody defines body for CreatePost for application/json ContentType.
+type CreatePostJSONRequestBody = CreatePostRequest
+
+// DeletePostJSONRequestBody defines body for DeletePost for application/json ContentType.
+type DeletePostJSONRequestBody = ActingUser
+
+// EditPostJSONRequestBody defines body for EditPost for application/json ContentType.
+type EditPostJSONRequestBody = EditContentRequest
+
+// CrosspostJSONRequestBody defines body for Crosspost for application/json ContentType.
+type CrosspostJSONRequestBody = CrosspostRequest
+
+// SetPostFlairJSONRequestBody defines body for SetPostFlair for application/json ContentType.
+type SetPostFlairJSONRequestBody = SetFlairRequest
+
+// HidePostJSONRequestBody defines body for HidePost for application/json ContentType.
+type HidePostJSONRequestBody = ActingUser
+
+// SavePostJSONRequestBody defines body for SavePost for application/json ContentType.
+type SavePostJSONRequestBody = ActingUser
+
+// UnhidePostJSONRequestBody defines body for UnhidePost for application/json ContentType.
+type UnhidePostJSONRequestBody = ActingUser
+
+// UnsavePostJSONRequestBody defines body for UnsavePost for application/json ContentType.
+type UnsavePostJSONRequestBody = ActingUser
+
+// ReportContentJSONRequestBody defines body for ReportContent for application/json ContentType.
+type ReportContentJSONRequestBody = ReportContentRequest
+
+// CreateSubredditJSONRequestBody defines body for CreateSubreddit for application/json ContentType.
+type CreateSubredditJSONRequestBody = CreateSubredditRequest
+
+// SetAutoModRulesJSONRequestBody defines body for SetAutoModRules for application/json ContentType.
+type SetAutoModRulesJSONRequestBody = SetAutoModRulesJSONBody
+
+// TestAutoModJSONRequestBody defines body for TestAutoMod for application/json ContentType.
+type TestAutoModJSONRequestBody = TestAutoModRequest
+
+// UnbanUserJSONRequestBody defines body for UnbanUser for application/json ContentType.
+type UnbanUserJSONRequestBody = ActingUser
+
+// BanUserJSONRequestBody defines body for BanUser for application/json ContentType.
+type BanUserJSONRequestBody = ActingUser
+
+// SetUserFlairJSONRequestBody defines body for SetUserFlair for application/json ContentType.
+type SetUserFlairJSONRequestBody = SetFlairRequest
+
+// CreateFlairJSONRequestBody defines body for CreateFlair for application/json ContentType.
+type CreateFlairJSONRequestBody = CreateFlairRequest
+
+// DeleteFlairJSONRequestBody defines body for DeleteFlair for application/json ContentType.
+type DeleteFlairJSONRequestBody = ActingUser
+
+// JoinSubredditJSONRequestBody defines body for JoinSubreddit for application/json ContentType.
+type JoinSubredditJSONRequestBody = MembershipRequest
+
+// LeaveSubredditJSONRequestBody defines body for LeaveSubreddit for application/json ContentType.
+type LeaveSubredditJSONRequestBody = MembershipRequest
+
+// ResolveReportsJSONRequestBody defines body for ResolveReports for application/json ContentType.
+type ResolveReportsJSONRequestBody = ResolveReportsRequest
+
+// SetReportReasonsJSONRequestBody defines body for SetReportReasons for application/json ContentType.
+type SetReportReasonsJSONRequestBody = SetReportReasonsRequest
+
+// RegisterWebhookJSONRequestBody defines body for RegisterWebhook for application/json ContentType.
+type RegisterWebhookJSONRequestBody = RegisterWebhookRequest
+
+// DeleteWebhookJSONRequestBody defines body for DeleteWebhook for application/json ContentType.
+type DeleteWebhookJSONRequestBody = ActingUser
+
+// RegisterUserJSONRequestBody defines body for RegisterUser for application/json ContentType.
+type RegisterUserJSONRequestBody = RegisterUserRequest
+
+// MarkNotificationsReadJSONRequestBody defines body for MarkNotificationsRead for application/json ContentType.
+type MarkNotificationsReadJSONRequestBody = MarkNotificationsReadRequest
+
+// VoteJSONRequestBody defines body for Vote for application/json ContentType.
+type VoteJSONRequestBody = VoteRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetVoteReport request
+	GetVoteReport(ctx context.Context, params *GetVoteReportParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddCommentWithBody request with any body
+	AddCommentWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddComment(ctx context.Context, body AddCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteCommentWithBody request with any body
+	DeleteCommentWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	DeleteComment(ctx context.Context, id ID, body DeleteCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// EditCommentWithBody request with any body
+	EditCommentWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	EditComment(ctx context.Context, id ID, body EditCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SaveCommentWithBody request with any body
+	SaveCommentWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SaveComment(ctx context.Context, id ID, body SaveCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnsaveCommentWithBody request with any body
+	UnsaveCommentWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UnsaveComment(ctx context.Context, id ID, body UnsaveCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListDomainPosts request
+	ListDomainPosts(ctx context.Context, domain string, params *ListDomainPostsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHealth request
+	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UploadMediaWithBody request with any body
+	UploadMediaWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMedia request
+	GetMedia(ctx context.Context, id MediaID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetMediaThumbnail request
+	GetMediaThumbnail(ctx context.Context, id MediaID, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreatePostWithBody request with any body
+	CreatePostWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreatePost(ctx context.Context, body CreatePostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeletePostWithBody request with any body
+	DeletePostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	DeletePost(ctx context.Context, id ID, body DeletePostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// EditPostWithBody request with any body
+	EditPostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	EditPost(ctx context.Context, id ID, body EditPostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CrosspostWithBody request with any body
+	CrosspostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Crosspost(ctx context.Context, id ID, body CrosspostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetPostFlairWithBody request with any body
+	SetPostFlairWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetPostFlair(ctx context.Context, id ID, body SetPostFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// HidePostWithBody request with any body
+	HidePostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	HidePost(ctx context.Context, id ID, body HidePostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SavePostWithBody request with any body
+	SavePostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SavePost(ctx context.Context, id ID, body SavePostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnhidePostWithBody request with any body
+	UnhidePostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UnhidePost(ctx context.Context, id ID, body UnhidePostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnsavePostWithBody request with any body
+	UnsavePostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UnsavePost(ctx context.Context, id ID, body UnsavePostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ReportContentWithBody request with any body
+	ReportContentWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ReportContent(ctx context.Context, body ReportContentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Search request
+	Search(ctx context.Context, params *SearchParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSpamStats request
+	GetSpamStats(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Stream request
+	Stream(ctx context.Context, params *StreamParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSubreddits request
+	ListSubreddits(ctx context.Context, params *ListSubredditsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateSubredditWithBody request with any body
+	CreateSubredditWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateSubreddit(ctx context.Context, body CreateSubredditJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AutocompleteSubreddits request
+	AutocompleteSubreddits(ctx context.Context, params *AutocompleteSubredditsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TrendingSubreddits request
+	TrendingSubreddits(ctx context.Context, params *TrendingSubredditsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAutoModRules request
+	GetAutoModRules(ctx context.Context, name Name, params *GetAutoModRulesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetAutoModRulesWithBody request with any body
+	SetAutoModRulesWithBody(ctx context.Context, name Name, params *SetAutoModRulesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetAutoModRules(ctx context.Context, name Name, params *SetAutoModRulesParams, body SetAutoModRulesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TestAutoModWithBody request with any body
+	TestAutoModWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TestAutoMod(ctx context.Context, name Name, body TestAutoModJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnbanUserWithBody request with any body
+	UnbanUserWithBody(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UnbanUser(ctx context.Context, name Name, id ID, body UnbanUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// BanUserWithBody request with any body
+	BanUserWithBody(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BanUser(ctx context.Context, name Name, id ID, body BanUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetUserFlairWithBody request with any body
+	SetUserFlairWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetUserFlair(ctx context.Context, name Name, body SetUserFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListFlairs request
+	ListFlairs(ctx context.Context, name Name, params *ListFlairsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateFlairWithBody request with any body
+	CreateFlairWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateFlair(ctx context.Context, name Name, body CreateFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteFlairWithBody request with any body
+	DeleteFlairWithBody(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	DeleteFlair(ctx context.Context, name Name, id ID, body DeleteFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// JoinSubredditWithBody request with any body
+	JoinSubredditWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	JoinSubreddit(ctx context.Context, name Name, body JoinSubredditJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LeaveSubredditWithBody request with any body
+	LeaveSubredditWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	LeaveSubreddit(ctx context.Context, name Name, body LeaveSubredditJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetModQueue request
+	GetModQueue(ctx context.Context, name Name, params *GetModQueueParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ResolveReportsWithBody request with any body
+	ResolveReportsWithBody(ctx context.Context, name Name, target ResolveReportsParamsTarget, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ResolveReports(ctx context.Context, name Name, target ResolveReportsParamsTarget, id ID, body ResolveReportsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSubredditPosts request
+	ListSubredditPosts(ctx context.Context, name Name, params *ListSubredditPostsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetReportReasons request
+	GetReportReasons(ctx context.Context, name Name, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetReportReasonsWithBody request with any body
+	SetReportReasonsWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetReportReasons(ctx context.Context, name Name, body SetReportReasonsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListWebhooks request
+	ListWebhooks(ctx context.Context, name Name, params *ListWebhooksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegisterWebhookWithBody request with any body
+	RegisterWebhookWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegisterWebhook(ctx context.Context, name Name, body RegisterWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteWebhookWithBody request with any body
+	DeleteWebhookWithBody(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	DeleteWebhook(ctx context.Context, name Name, id ID, body DeleteWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RegisterUserWithBody request with any body
+	RegisterUserWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RegisterUser(ctx context.Context, body RegisterUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetAllUsers request
+	GetAllUsers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetNotifications request
+	GetNotifications(ctx context.Context, id ID, params *GetNotificationsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// MarkNotificationsReadWithBody request with any body
+	MarkNotificationsReadWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	MarkNotificationsRead(ctx context.Context, id ID, body MarkNotificationsReadJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSaved request
+	GetSaved(ctx context.Context, id ID, params *GetSavedParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// VoteWithBody request with any body
+	VoteWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	Vote(ctx context.Context, body VoteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetVoteReport(ctx context.Context, params *GetVoteReportParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetVoteReportRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddCommentWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddCommentRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AddComment(ctx context.Context, body AddCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddCommentRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteCommentWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteCommentRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteComment(ctx context.Context, id ID, body DeleteCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteCommentRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EditCommentWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEditCommentRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EditComment(ctx context.Context, id ID, body EditCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEditCommentRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SaveCommentWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSaveCommentRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SaveComment(ctx context.Context, id ID, body SaveCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSaveCommentRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnsaveCommentWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnsaveCommentRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnsaveComment(ctx context.Context, id ID, body UnsaveCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnsaveCommentRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListDomainPosts(ctx context.Context, domain string, params *ListDomainPostsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListDomainPostsRequest(c.Server, domain, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UploadMediaWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUploadMediaRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetMedia(ctx context.Context, id MediaID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMediaRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetMediaThumbnail(ctx context.Context, id MediaID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetMediaThumbnailRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePostWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePostRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePost(ctx context.Context, body CreatePostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePostRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeletePostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeletePostRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeletePost(ctx context.Context, id ID, body DeletePostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeletePostRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EditPostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEditPostRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) EditPost(ctx context.Context, id ID, body EditPostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEditPostRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CrosspostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCrosspostRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Crosspost(ctx context.Context, id ID, body CrosspostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCrosspostRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPostFlairWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPostFlairRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetPostFlair(ctx context.Context, id ID, body SetPostFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetPostFlairRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) HidePostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHidePostRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) HidePost(ctx context.Context, id ID, body HidePostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHidePostRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SavePostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSavePostRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SavePost(ctx context.Context, id ID, body SavePostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSavePostRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnhidePostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnhidePostRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnhidePost(ctx context.Context, id ID, body UnhidePostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnhidePostRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnsavePostWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnsavePostRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnsavePost(ctx context.Context, id ID, body UnsavePostJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnsavePostRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReportContentWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReportContentRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ReportContent(ctx context.Context, body ReportContentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReportContentRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Search(ctx context.Context, params *SearchParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSearchRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSpamStats(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSpamStatsRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Stream(ctx context.Context, params *StreamParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStreamRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListSubreddits(ctx context.Context, params *ListSubredditsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSubredditsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSubredditWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSubredditRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateSubreddit(ctx context.Context, body CreateSubredditJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateSubredditRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) AutocompleteSubreddits(ctx context.Context, params *AutocompleteSubredditsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAutocompleteSubredditsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TrendingSubreddits(ctx context.Context, params *TrendingSubredditsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTrendingSubredditsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAutoModRules(ctx context.Context, name Name, params *GetAutoModRulesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAutoModRulesRequest(c.Server, name, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetAutoModRulesWithBody(ctx context.Context, name Name, params *SetAutoModRulesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetAutoModRulesRequestWithBody(c.Server, name, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetAutoModRules(ctx context.Context, name Name, params *SetAutoModRulesParams, body SetAutoModRulesJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetAutoModRulesRequest(c.Server, name, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TestAutoModWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTestAutoModRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TestAutoMod(ctx context.Context, name Name, body TestAutoModJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTestAutoModRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnbanUserWithBody(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnbanUserRequestWithBody(c.Server, name, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnbanUser(ctx context.Context, name Name, id ID, body UnbanUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnbanUserRequest(c.Server, name, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BanUserWithBody(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBanUserRequestWithBody(c.Server, name, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BanUser(ctx context.Context, name Name, id ID, body BanUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBanUserRequest(c.Server, name, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetUserFlairWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetUserFlairRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetUserFlair(ctx context.Context, name Name, body SetUserFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetUserFlairRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListFlairs(ctx context.Context, name Name, params *ListFlairsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListFlairsRequest(c.Server, name, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateFlairWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateFlairRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateFlair(ctx context.Context, name Name, body CreateFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateFlairRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteFlairWithBody(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteFlairRequestWithBody(c.Server, name, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteFlair(ctx context.Context, name Name, id ID, body DeleteFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteFlairRequest(c.Server, name, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) JoinSubredditWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewJoinSubredditRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) JoinSubreddit(ctx context.Context, name Name, body JoinSubredditJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewJoinSubredditRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LeaveSubredditWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLeaveSubredditRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LeaveSubreddit(ctx context.Context, name Name, body LeaveSubredditJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLeaveSubredditRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetModQueue(ctx context.Context, name Name, params *GetModQueueParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetModQueueRequest(c.Server, name, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResolveReportsWithBody(ctx context.Context, name Name, target ResolveReportsParamsTarget, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResolveReportsRequestWithBody(c.Server, name, target, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ResolveReports(ctx context.Context, name Name, target ResolveReportsParamsTarget, id ID, body ResolveReportsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewResolveReportsRequest(c.Server, name, target, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListSubredditPosts(ctx context.Context, name Name, params *ListSubredditPostsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSubredditPostsRequest(c.Server, name, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetReportReasons(ctx context.Context, name Name, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetReportReasonsRequest(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetReportReasonsWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetReportReasonsRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetReportReasons(ctx context.Context, name Name, body SetReportReasonsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetReportReasonsRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListWebhooks(ctx context.Context, name Name, params *ListWebhooksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListWebhooksRequest(c.Server, name, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterWebhookWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterWebhookRequestWithBody(c.Server, name, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterWebhook(ctx context.Context, name Name, body RegisterWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterWebhookRequest(c.Server, name, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteWebhookWithBody(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteWebhookRequestWithBody(c.Server, name, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteWebhook(ctx context.Context, name Name, id ID, body DeleteWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteWebhookRequest(c.Server, name, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterUserWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterUserRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RegisterUser(ctx context.Context, body RegisterUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRegisterUserRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetAllUsers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetAllUsersRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetNotifications(ctx context.Context, id ID, params *GetNotificationsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetNotificationsRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) MarkNotificationsReadWithBody(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMarkNotificationsReadRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) MarkNotificationsRead(ctx context.Context, id ID, body MarkNotificationsReadJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewMarkNotificationsReadRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetSaved(ctx context.Context, id ID, params *GetSavedParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSavedRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) VoteWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewVoteRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Vote(ctx context.Context, body VoteJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewVoteRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetVoteReportRequest generates requests for GetVoteReport
+func NewGetVoteReportRequest(server string, params *GetVoteReportParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/admin/votes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user", runtime.ParamLocationQuery, params.User); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddCommentRequest calls the generic AddComment builder with application/json body
+func NewAddCommentRequest(server string, body AddCommentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddCommentRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddCommentRequestWithBody generates requests for AddComment with any type of body
+func NewAddCommentRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteCommentRequest calls the generic DeleteComment builder with application/json body
+func NewDeleteCommentRequest(server string, id ID, body DeleteCommentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDeleteCommentRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewDeleteCommentRequestWithBody generates requests for DeleteComment with any type of body
+func NewDeleteCommentRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewEditCommentRequest calls the generic EditComment builder with application/json body
+func NewEditCommentRequest(server string, id ID, body EditCommentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewEditCommentRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewEditCommentRequestWithBody generates requests for EditComment with any type of body
+func NewEditCommentRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSaveCommentRequest calls the generic SaveComment builder with application/json body
+func NewSaveCommentRequest(server string, id ID, body SaveCommentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSaveCommentRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewSaveCommentRequestWithBody generates requests for SaveComment with any type of body
+func NewSaveCommentRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments/%s/save", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUnsaveCommentRequest calls the generic UnsaveComment builder with application/json body
+func NewUnsaveCommentRequest(server string, id ID, body UnsaveCommentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUnsaveCommentRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUnsaveCommentRequestWithBody generates requests for UnsaveComment with any type of body
+func NewUnsaveCommentRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/comments/%s/unsave", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListDomainPostsRequest generates requests for ListDomainPosts
+func NewListDomainPostsRequest(server string, domain string, params *ListDomainPostsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "domain", runtime.ParamLocationPath, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/domains/%s/posts", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.User != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user", runtime.ParamLocationQuery, *params.User); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page_size", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUploadMediaRequestWithBody generates requests for UploadMedia with any type of body
+func NewUploadMediaRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/media")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetMediaRequest generates requests for GetMedia
+func NewGetMediaRequest(server string, id MediaID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/media/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetMediaThumbnailRequest generates requests for GetMediaThumbnail
+func NewGetMediaThumbnailRequest(server string, id MediaID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/media/%s/thumbnail", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreatePostRequest calls the generic CreatePost builder with application/json body
+func NewCreatePostRequest(server string, body CreatePostJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreatePostRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreatePostRequestWithBody generates requests for CreatePost with any type of body
+func NewCreatePostRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/posts")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeletePostRequest calls the generic DeletePost builder with application/json body
+func NewDeletePostRequest(server string, id ID, body DeletePostJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDeletePostRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewDeletePostRequestWithBody generates requests for DeletePost with any type of body
+func NewDeletePostRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/posts/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewEditPostRequest calls the generic EditPost builder with application/json body
+func NewEditPostRequest(server string, id ID, body EditPostJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewEditPostRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewEditPostRequestWithBody generates requests for EditPost with any type of body
+func NewEditPostRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/posts/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewCrosspostRequest calls the generic Crosspost builder with application/json body
+func NewCrosspostRequest(server string, id ID, body CrosspostJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCrosspostRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewCrosspostRequestWithBody generates requests for Crosspost with any type of body
+func NewCrosspostRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/posts/%s/crosspost", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSetPostFlairRequest calls the generic SetPostFlair builder with application/json body
+func NewSetPostFlairRequest(server string, id ID, body SetPostFlairJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetPostFlairRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewSetPostFlairRequestWithBody generates requests for SetPostFlair with any type of body
+func NewSetPostFlairRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/posts/%s/flair", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewHidePostRequest calls the generic HidePost builder with application/json body
+func NewHidePostRequest(server string, id ID, body HidePostJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewHidePostRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewHidePostRequestWithBody generates requests for HidePost with any type of body
+func NewHidePostRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/posts/%s/hide", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSavePostRequest calls the generic SavePost builder with application/json body
+func NewSavePostRequest(server string, id ID, body SavePostJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSavePostRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewSavePostRequestWithBody generates requests for SavePost with any type of body
+func NewSavePostRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/posts/%s/save", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUnhidePostRequest calls the generic UnhidePost builder with application/json body
+func NewUnhidePostRequest(server string, id ID, body UnhidePostJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUnhidePostRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUnhidePostRequestWithBody generates requests for UnhidePost with any type of body
+func NewUnhidePostRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/posts/%s/unhide", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUnsavePostRequest calls the generic UnsavePost builder with application/json body
+func NewUnsavePostRequest(server string, id ID, body UnsavePostJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUnsavePostRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewUnsavePostRequestWithBody generates requests for UnsavePost with any type of body
+func NewUnsavePostRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/posts/%s/unsave", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewReportContentRequest calls the generic ReportContent builder with application/json body
+func NewReportContentRequest(server string, body ReportContentJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewReportContentRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewReportContentRequestWithBody generates requests for ReportContent with any type of body
+func NewReportContentRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/reports")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSearchRequest generates requests for Search
+func NewSearchRequest(server string, params *SearchParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/search")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Q != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "q", runtime.ParamLocationQuery, *params.Q); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page_size", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSpamStatsRequest generates requests for GetSpamStats
+func NewGetSpamStatsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/spam-filter")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStreamRequest generates requests for Stream
+func NewStreamRequest(server string, params *StreamParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/stream")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Subreddit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "subreddit", runtime.ParamLocationQuery, *params.Subreddit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Post != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "post", runtime.ParamLocationQuery, *params.Post); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.User != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user", runtime.ParamLocationQuery, *params.User); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListSubredditsRequest generates requests for ListSubreddits
+func NewListSubredditsRequest(server string, params *ListSubredditsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Sort != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "sort", runtime.ParamLocationQuery, *params.Sort); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page_size", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateSubredditRequest calls the generic CreateSubreddit builder with application/json body
+func NewCreateSubredditRequest(server string, body CreateSubredditJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateSubredditRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateSubredditRequestWithBody generates requests for CreateSubreddit with any type of body
+func NewCreateSubredditRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewAutocompleteSubredditsRequest generates requests for AutocompleteSubreddits
+func NewAutocompleteSubredditsRequest(server string, params *AutocompleteSubredditsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/autocomplete")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Prefix != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "prefix", runtime.ParamLocationQuery, *params.Prefix); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTrendingSubredditsRequest generates requests for TrendingSubreddits
+func NewTrendingSubredditsRequest(server string, params *TrendingSubredditsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/trending")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetAutoModRulesRequest generates requests for GetAutoModRules
+func NewGetAutoModRulesRequest(server string, name Name, params *GetAutoModRulesParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/automod", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user", runtime.ParamLocationQuery, params.User); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetAutoModRulesRequest calls the generic SetAutoModRules builder with application/json body
+func NewSetAutoModRulesRequest(server string, name Name, params *SetAutoModRulesParams, body SetAutoModRulesJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetAutoModRulesRequestWithBody(server, name, params, "application/json", bodyReader)
+}
+
+// NewSetAutoModRulesRequestWithBody generates requests for SetAutoModRules with any type of body
+func NewSetAutoModRulesRequestWithBody(server string, name Name, params *SetAutoModRulesParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/automod", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user", runtime.ParamLocationQuery, params.User); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewTestAutoModRequest calls the generic TestAutoMod builder with application/json body
+func NewTestAutoModRequest(server string, name Name, body TestAutoModJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTestAutoModRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewTestAutoModRequestWithBody generates requests for TestAutoMod with any type of body
+func NewTestAutoModRequestWithBody(server string, name Name, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/automod/test", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUnbanUserRequest calls the generic UnbanUser builder with application/json body
+func NewUnbanUserRequest(server string, name Name, id ID, body UnbanUserJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUnbanUserRequestWithBody(server, name, id, "application/json", bodyReader)
+}
+
+// NewUnbanUserRequestWithBody generates requests for UnbanUser with any type of body
+func NewUnbanUserRequestWithBody(server string, name Name, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/bans/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewBanUserRequest calls the generic BanUser builder with application/json body
+func NewBanUserRequest(server string, name Name, id ID, body BanUserJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewBanUserRequestWithBody(server, name, id, "application/json", bodyReader)
+}
+
+// NewBanUserRequestWithBody generates requests for BanUser with any type of body
+func NewBanUserRequestWithBody(server string, name Name, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/bans/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewSetUserFlairRequest calls the generic SetUserFlair builder with application/json body
+func NewSetUserFlairRequest(server string, name Name, body SetUserFlairJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetUserFlairRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewSetUserFlairRequestWithBody generates requests for SetUserFlair with any type of body
+func NewSetUserFlairRequestWithBody(server string, name Name, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/flair", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListFlairsRequest generates requests for ListFlairs
+func NewListFlairsRequest(server string, name Name, params *ListFlairsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/flairs", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Type != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateFlairRequest calls the generic CreateFlair builder with application/json body
+func NewCreateFlairRequest(server string, name Name, body CreateFlairJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateFlairRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewCreateFlairRequestWithBody generates requests for CreateFlair with any type of body
+func NewCreateFlairRequestWithBody(server string, name Name, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/flairs", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteFlairRequest calls the generic DeleteFlair builder with application/json body
+func NewDeleteFlairRequest(server string, name Name, id ID, body DeleteFlairJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDeleteFlairRequestWithBody(server, name, id, "application/json", bodyReader)
+}
+
+// NewDeleteFlairRequestWithBody generates requests for DeleteFlair with any type of body
+func NewDeleteFlairRequestWithBody(server string, name Name, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/flairs/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewJoinSubredditRequest calls the generic JoinSubreddit builder with application/json body
+func NewJoinSubredditRequest(server string, name Name, body JoinSubredditJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewJoinSubredditRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewJoinSubredditRequestWithBody generates requests for JoinSubreddit with any type of body
+func NewJoinSubredditRequestWithBody(server string, name Name, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/join", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewLeaveSubredditRequest calls the generic LeaveSubreddit builder with application/json body
+func NewLeaveSubredditRequest(server string, name Name, body LeaveSubredditJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewLeaveSubredditRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewLeaveSubredditRequestWithBody generates requests for LeaveSubreddit with any type of body
+func NewLeaveSubredditRequestWithBody(server string, name Name, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/leave", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetModQueueRequest generates requests for GetModQueue
+func NewGetModQueueRequest(server string, name Name, params *GetModQueueParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/modqueue", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user", runtime.ParamLocationQuery, params.User); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page_size", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewResolveReportsRequest calls the generic ResolveReports builder with application/json body
+func NewResolveReportsRequest(server string, name Name, target ResolveReportsParamsTarget, id ID, body ResolveReportsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewResolveReportsRequestWithBody(server, name, target, id, "application/json", bodyReader)
+}
+
+// NewResolveReportsRequestWithBody generates requests for ResolveReports with any type of body
+func NewResolveReportsRequestWithBody(server string, name Name, target ResolveReportsParamsTarget, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "target", runtime.ParamLocationPath, target)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam2 string
+
+	pathParam2, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/modqueue/%s/%s", pathParam0, pathParam1, pathParam2)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListSubredditPostsRequest generates requests for ListSubredditPosts
+func NewListSubredditPostsRequest(server string, name Name, params *ListSubredditPostsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/posts", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Flair != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "flair", runtime.ParamLocationQuery, *params.Flair); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.User != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user", runtime.ParamLocationQuery, *params.User); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page_size", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetReportReasonsRequest generates requests for GetReportReasons
+func NewGetReportReasonsRequest(server string, name Name) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/report-reasons", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetReportReasonsRequest calls the generic SetReportReasons builder with application/json body
+func NewSetReportReasonsRequest(server string, name Name, body SetReportReasonsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetReportReasonsRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewSetReportReasonsRequestWithBody generates requests for SetReportReasons with any type of body
+func NewSetReportReasonsRequestWithBody(server string, name Name, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/report-reasons", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListWebhooksRequest generates requests for ListWebhooks
+func NewListWebhooksRequest(server string, name Name, params *ListWebhooksParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/webhooks", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user", runtime.ParamLocationQuery, params.User); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRegisterWebhookRequest calls the generic RegisterWebhook builder with application/json body
+func NewRegisterWebhookRequest(server string, name Name, body RegisterWebhookJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterWebhookRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewRegisterWebhookRequestWithBody generates requests for RegisterWebhook with any type of body
+func NewRegisterWebhookRequestWithBody(server string, name Name, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/webhooks", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteWebhookRequest calls the generic DeleteWebhook builder with application/json body
+func NewDeleteWebhookRequest(server string, name Name, id ID, body DeleteWebhookJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewDeleteWebhookRequestWithBody(server, name, id, "application/json", bodyReader)
+}
+
+// NewDeleteWebhookRequestWithBody generates requests for DeleteWebhook with any type of body
+func NewDeleteWebhookRequestWithBody(server string, name Name, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/subreddits/%s/webhooks/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRegisterUserRequest calls the generic RegisterUser builder with application/json body
+func NewRegisterUserRequest(server string, body RegisterUserJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRegisterUserRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRegisterUserRequestWithBody generates requests for RegisterUser with any type of body
+func NewRegisterUserRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetAllUsersRequest generates requests for GetAllUsers
+func NewGetAllUsersRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/users/karma")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetNotificationsRequest generates requests for GetNotifications
+func NewGetNotificationsRequest(server string, id ID, params *GetNotificationsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/users/%s/notifications", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Unread != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "unread", runtime.ParamLocationQuery, *params.Unread); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page_size", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewMarkNotificationsReadRequest calls the generic MarkNotificationsRead builder with application/json body
+func NewMarkNotificationsReadRequest(server string, id ID, body MarkNotificationsReadJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewMarkNotificationsReadRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewMarkNotificationsReadRequestWithBody generates requests for MarkNotificationsRead with any type of body
+func NewMarkNotificationsReadRequestWithBody(server string, id ID, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/users/%s/notifications/read", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetSavedRequest generates requests for GetSaved
+func NewGetSavedRequest(server string, id ID, params *GetSavedParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/users/%s/saved", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Type != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "type", runtime.ParamLocationQuery, *params.Type); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Page != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page", runtime.ParamLocationQuery, *params.Page); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.PageSize != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "page_size", runtime.ParamLocationQuery, *params.PageSize); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewVoteRequest calls the generic Vote builder with application/json body
+func NewVoteRequest(server string, body VoteJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewVoteRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewVoteRequestWithBody generates requests for Vote with any type of body
+func NewVoteRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/votes")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetVoteReportWithResponse request
+	GetVoteReportWithResponse(ctx context.Context, params *GetVoteReportParams, reqEditors ...RequestEditorFn) (*GetVoteReportResponse, error)
+
+	// AddCommentWithBodyWithResponse request with any body
+	AddCommentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddCommentResponse, error)
+
+	AddCommentWithResponse(ctx context.Context, body AddCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*AddCommentResponse, error)
+
+	// DeleteCommentWithBodyWithResponse request with any body
+	DeleteCommentWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeleteCommentResponse, error)
+
+	DeleteCommentWithResponse(ctx context.Context, id ID, body DeleteCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*DeleteCommentResponse, error)
+
+	// EditCommentWithBodyWithResponse request with any body
+	EditCommentWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EditCommentResponse, error)
+
+	EditCommentWithResponse(ctx context.Context, id ID, body EditCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*EditCommentResponse, error)
+
+	// SaveCommentWithBodyWithResponse request with any body
+	SaveCommentWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SaveCommentResponse, error)
+
+	SaveCommentWithResponse(ctx context.Context, id ID, body SaveCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*SaveCommentResponse, error)
+
+	// UnsaveCommentWithBodyWithResponse request with any body
+	UnsaveCommentWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnsaveCommentResponse, error)
+
+	UnsaveCommentWithResponse(ctx context.Context, id ID, body UnsaveCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*UnsaveCommentResponse, error)
+
+	// ListDomainPostsWithResponse request
+	ListDomainPostsWithResponse(ctx context.Context, domain string, params *ListDomainPostsParams, reqEditors ...RequestEditorFn) (*ListDomainPostsResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// UploadMediaWithBodyWithResponse request with any body
+	UploadMediaWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadMediaResponse, error)
+
+	// GetMediaWithResponse request
+	GetMediaWithResponse(ctx context.Context, id MediaID, reqEditors ...RequestEditorFn) (*GetMediaResponse, error)
+
+	// GetMediaThumbnailWithResponse request
+	GetMediaThumbnailWithResponse(ctx context.Context, id MediaID, reqEditors ...RequestEditorFn) (*GetMediaThumbnailResponse, error)
+
+	// CreatePostWithBodyWithResponse request with any body
+	CreatePostWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePostResponse, error)
+
+	CreatePostWithResponse(ctx context.Context, body CreatePostJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePostResponse, error)
+
+	// DeletePostWithBodyWithResponse request with any body
+	DeletePostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeletePostResponse, error)
+
+	DeletePostWithResponse(ctx context.Context, id ID, body DeletePostJSONRequestBody, reqEditors ...RequestEditorFn) (*DeletePostResponse, error)
+
+	// EditPostWithBodyWithResponse request with any body
+	EditPostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EditPostResponse, error)
+
+	EditPostWithResponse(ctx context.Context, id ID, body EditPostJSONRequestBody, reqEditors ...RequestEditorFn) (*EditPostResponse, error)
+
+	// CrosspostWithBodyWithResponse request with any body
+	CrosspostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CrosspostResponse, error)
+
+	CrosspostWithResponse(ctx context.Context, id ID, body CrosspostJSONRequestBody, reqEditors ...RequestEditorFn) (*CrosspostResponse, error)
+
+	// SetPostFlairWithBodyWithResponse request with any body
+	SetPostFlairWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPostFlairResponse, error)
+
+	SetPostFlairWithResponse(ctx context.Context, id ID, body SetPostFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPostFlairResponse, error)
+
+	// HidePostWithBodyWithResponse request with any body
+	HidePostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*HidePostResponse, error)
+
+	HidePostWithResponse(ctx context.Context, id ID, body HidePostJSONRequestBody, reqEditors ...RequestEditorFn) (*HidePostResponse, error)
+
+	// SavePostWithBodyWithResponse request with any body
+	SavePostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SavePostResponse, error)
+
+	SavePostWithResponse(ctx context.Context, id ID, body SavePostJSONRequestBody, reqEditors ...RequestEditorFn) (*SavePostResponse, error)
+
+	// UnhidePostWithBodyWithResponse request with any body
+	UnhidePostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnhidePostResponse, error)
+
+	UnhidePostWithResponse(ctx context.Context, id ID, body UnhidePostJSONRequestBody, reqEditors ...RequestEditorFn) (*UnhidePostResponse, error)
+
+	// UnsavePostWithBodyWithResponse request with any body
+	UnsavePostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnsavePostResponse, error)
+
+	UnsavePostWithResponse(ctx context.Context, id ID, body UnsavePostJSONRequestBody, reqEditors ...RequestEditorFn) (*UnsavePostResponse, error)
+
+	// ReportContentWithBodyWithResponse request with any body
+	ReportContentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReportContentResponse, error)
+
+	ReportContentWithResponse(ctx context.Context, body ReportContentJSONRequestBody, reqEditors ...RequestEditorFn) (*ReportContentResponse, error)
+
+	// SearchWithResponse request
+	SearchWithResponse(ctx context.Context, params *SearchParams, reqEditors ...RequestEditorFn) (*SearchResponse, error)
+
+	// GetSpamStatsWithResponse request
+	GetSpamStatsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSpamStatsResponse, error)
+
+	// StreamWithResponse request
+	StreamWithResponse(ctx context.Context, params *StreamParams, reqEditors ...RequestEditorFn) (*StreamResponse, error)
+
+	// ListSubredditsWithResponse request
+	ListSubredditsWithResponse(ctx context.Context, params *ListSubredditsParams, reqEditors ...RequestEditorFn) (*ListSubredditsResponse, error)
+
+	// CreateSubredditWithBodyWithResponse request with any body
+	CreateSubredditWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSubredditResponse, error)
+
+	CreateSubredditWithResponse(ctx context.Context, body CreateSubredditJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSubredditResponse, error)
+
+	// AutocompleteSubredditsWithResponse request
+	AutocompleteSubredditsWithResponse(ctx context.Context, params *AutocompleteSubredditsParams, reqEditors ...RequestEditorFn) (*AutocompleteSubredditsResponse, error)
+
+	// TrendingSubredditsWithResponse request
+	TrendingSubredditsWithResponse(ctx context.Context, params *TrendingSubredditsParams, reqEditors ...RequestEditorFn) (*TrendingSubredditsResponse, error)
+
+	// GetAutoModRulesWithResponse request
+	GetAutoModRulesWithResponse(ctx context.Context, name Name, params *GetAutoModRulesParams, reqEditors ...RequestEditorFn) (*GetAutoModRulesResponse, error)
+
+	// SetAutoModRulesWithBodyWithResponse request with any body
+	SetAutoModRulesWithBodyWithResponse(ctx context.Context, name Name, params *SetAutoModRulesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetAutoModRulesResponse, error)
+
+	SetAutoModRulesWithResponse(ctx context.Context, name Name, params *SetAutoModRulesParams, body SetAutoModRulesJSONRequestBody, reqEditors ...RequestEditorFn) (*SetAutoModRulesResponse, error)
+
+	// TestAutoModWithBodyWithResponse request with any body
+	TestAutoModWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TestAutoModResponse, error)
+
+	TestAutoModWithResponse(ctx context.Context, name Name, body TestAutoModJSONRequestBody, reqEditors ...RequestEditorFn) (*TestAutoModResponse, error)
+
+	// UnbanUserWithBodyWithResponse request with any body
+	UnbanUserWithBodyWithResponse(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnbanUserResponse, error)
+
+	UnbanUserWithResponse(ctx context.Context, name Name, id ID, body UnbanUserJSONRequestBody, reqEditors ...RequestEditorFn) (*UnbanUserResponse, error)
+
+	// BanUserWithBodyWithResponse request with any body
+	BanUserWithBodyWithResponse(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BanUserResponse, error)
+
+	BanUserWithResponse(ctx context.Context, name Name, id ID, body BanUserJSONRequestBody, reqEditors ...RequestEditorFn) (*BanUserResponse, error)
+
+	// SetUserFlairWithBodyWithResponse request with any body
+	SetUserFlairWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetUserFlairResponse, error)
+
+	SetUserFlairWithResponse(ctx context.Context, name Name, body SetUserFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*SetUserFlairResponse, error)
+
+	// ListFlairsWithResponse request
+	ListFlairsWithResponse(ctx context.Context, name Name, params *ListFlairsParams, reqEditors ...RequestEditorFn) (*ListFlairsResponse, error)
+
+	// CreateFlairWithBodyWithResponse request with any body
+	CreateFlairWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateFlairResponse, error)
+
+	CreateFlairWithResponse(ctx context.Context, name Name, body CreateFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateFlairResponse, error)
+
+	// DeleteFlairWithBodyWithResponse request with any body
+	DeleteFlairWithBodyWithResponse(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeleteFlairResponse, error)
+
+	DeleteFlairWithResponse(ctx context.Context, name Name, id ID, body DeleteFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*DeleteFlairResponse, error)
+
+	// JoinSubredditWithBodyWithResponse request with any body
+	JoinSubredditWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*JoinSubredditResponse, error)
+
+	JoinSubredditWithResponse(ctx context.Context, name Name, body JoinSubredditJSONRequestBody, reqEditors ...RequestEditorFn) (*JoinSubredditResponse, error)
+
+	// LeaveSubredditWithBodyWithResponse request with any body
+	LeaveSubredditWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LeaveSubredditResponse, error)
+
+	LeaveSubredditWithResponse(ctx context.Context, name Name, body LeaveSubredditJSONRequestBody, reqEditors ...RequestEditorFn) (*LeaveSubredditResponse, error)
+
+	// GetModQueueWithResponse request
+	GetModQueueWithResponse(ctx context.Context, name Name, params *GetModQueueParams, reqEditors ...RequestEditorFn) (*GetModQueueResponse, error)
+
+	// ResolveReportsWithBodyWithResponse request with any body
+	ResolveReportsWithBodyWithResponse(ctx context.Context, name Name, target ResolveReportsParamsTarget, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ResolveReportsResponse, error)
+
+	ResolveReportsWithResponse(ctx context.Context, name Name, target ResolveReportsParamsTarget, id ID, body ResolveReportsJSONRequestBody, reqEditors ...RequestEditorFn) (*ResolveReportsResponse, error)
+
+	// ListSubredditPostsWithResponse request
+	ListSubredditPostsWithResponse(ctx context.Context, name Name, params *ListSubredditPostsParams, reqEditors ...RequestEditorFn) (*ListSubredditPostsResponse, error)
+
+	// GetReportReasonsWithResponse request
+	GetReportReasonsWithResponse(ctx context.Context, name Name, reqEditors ...RequestEditorFn) (*GetReportReasonsResponse, error)
+
+	// SetReportReasonsWithBodyWithResponse request with any body
+	SetReportReasonsWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetReportReasonsResponse, error)
+
+	SetReportReasonsWithResponse(ctx context.Context, name Name, body SetReportReasonsJSONRequestBody, reqEditors ...RequestEditorFn) (*SetReportReasonsResponse, error)
+
+	// ListWebhooksWithResponse request
+	ListWebhooksWithResponse(ctx context.Context, name Name, params *ListWebhooksParams, reqEditors ...RequestEditorFn) (*ListWebhooksResponse, error)
+
+	// RegisterWebhookWithBodyWithResponse request with any body
+	RegisterWebhookWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterWebhookResponse, error)
+
+	RegisterWebhookWithResponse(ctx context.Context, name Name, body RegisterWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterWebhookResponse, error)
+
+	// DeleteWebhookWithBodyWithResponse request with any body
+	DeleteWebhookWithBodyWithResponse(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeleteWebhookResponse, error)
+
+	DeleteWebhookWithResponse(ctx context.Context, name Name, id ID, body DeleteWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*DeleteWebhookResponse, error)
+
+	// RegisterUserWithBodyWithResponse request with any body
+	RegisterUserWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterUserResponse, error)
+
+	RegisterUserWithResponse(ctx context.Context, body RegisterUserJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterUserResponse, error)
+
+	// GetAllUsersWithResponse request
+	GetAllUsersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAllUsersResponse, error)
+
+	// GetNotificationsWithResponse request
+	GetNotificationsWithResponse(ctx context.Context, id ID, params *GetNotificationsParams, reqEditors ...RequestEditorFn) (*GetNotificationsResponse, error)
+
+	// MarkNotificationsReadWithBodyWithResponse request with any body
+	MarkNotificationsReadWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MarkNotificationsReadResponse, error)
+
+	MarkNotificationsReadWithResponse(ctx context.Context, id ID, body MarkNotificationsReadJSONRequestBody, reqEditors ...RequestEditorFn) (*MarkNotificationsReadResponse, error)
+
+	// GetSavedWithResponse request
+	GetSavedWithResponse(ctx context.Context, id ID, params *GetSavedParams, reqEditors ...RequestEditorFn) (*GetSavedResponse, error)
+
+	// VoteWithBodyWithResponse request with any body
+	VoteWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*VoteResponse, error)
+
+	VoteWithResponse(ctx context.Context, body VoteJSONRequestBody, reqEditors ...RequestEditorFn) (*VoteResponse, error)
+}
+
+type GetVoteReportResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *VoteReport
+}
+
+// Status returns HTTPResponse.Status
+func (r GetVoteReportResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetVoteReportResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddCommentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r AddCommentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddCommentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteCommentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteCommentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteCommentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EditCommentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r EditCommentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EditCommentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SaveCommentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r SaveCommentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SaveCommentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UnsaveCommentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r UnsaveCommentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnsaveCommentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListDomainPostsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]PostSummary
+}
+
+// Status returns HTTPResponse.Status
+func (r ListDomainPostsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListDomainPostsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Health
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UploadMediaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Media
+}
+
+// Status returns HTTPResponse.Status
+func (r UploadMediaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UploadMediaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMediaResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMediaResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMediaResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetMediaThumbnailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetMediaThumbnailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetMediaThumbnailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreatePostResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r CreatePostResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreatePostResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeletePostResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeletePostResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeletePostResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EditPostResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r EditPostResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EditPostResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CrosspostResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *PostSummary
+}
+
+// Status returns HTTPResponse.Status
+func (r CrosspostResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CrosspostResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetPostFlairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r SetPostFlairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetPostFlairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type HidePostResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r HidePostResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r HidePostResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SavePostResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r SavePostResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SavePostResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UnhidePostResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r UnhidePostResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnhidePostResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UnsavePostResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r UnsavePostResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnsavePostResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReportContentResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ReportContentResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReportContentResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SearchResults
+}
+
+// Status returns HTTPResponse.Status
+func (r SearchResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SearchResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSpamStatsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SpamStats
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSpamStatsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSpamStatsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StreamResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r StreamResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StreamResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSubredditsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SubredditListing
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSubredditsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSubredditsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateSubredditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateSubredditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateSubredditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AutocompleteSubredditsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SubredditListing
+}
+
+// Status returns HTTPResponse.Status
+func (r AutocompleteSubredditsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AutocompleteSubredditsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TrendingSubredditsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SubredditListing
+}
+
+// Status returns HTTPResponse.Status
+func (r TrendingSubredditsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TrendingSubredditsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAutoModRulesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]AutoModRule
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAutoModRulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAutoModRulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetAutoModRulesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r SetAutoModRulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetAutoModRulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TestAutoModResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *AutoModVerdict
+}
+
+// Status returns HTTPResponse.Status
+func (r TestAutoModResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TestAutoModResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UnbanUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r UnbanUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnbanUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BanUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r BanUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BanUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetUserFlairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r SetUserFlairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetUserFlairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListFlairsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Flair
+}
+
+// Status returns HTTPResponse.Status
+func (r ListFlairsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListFlairsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateFlairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Flair
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateFlairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateFlairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteFlairResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteFlairResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteFlairResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type JoinSubredditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r JoinSubredditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r JoinSubredditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LeaveSubredditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r LeaveSubredditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LeaveSubredditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetModQueueResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Report
+}
+
+// Status returns HTTPResponse.Status
+func (r GetModQueueResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetModQueueResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ResolveReportsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r ResolveReportsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ResolveReportsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSubredditPostsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]PostSummary
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSubredditPostsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSubredditPostsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetReportReasonsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]string
+}
+
+// Status returns HTTPResponse.Status
+func (r GetReportReasonsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetReportReasonsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetReportReasonsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r SetReportReasonsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetReportReasonsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListWebhooksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Webhook
+}
+
+// Status returns HTTPResponse.Status
+func (r ListWebhooksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListWebhooksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Webhook
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteWebhookResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteWebhookResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteWebhookResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RegisterUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r RegisterUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RegisterUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetAllUsersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]User
+}
+
+// Status returns HTTPResponse.Status
+func (r GetAllUsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetAllUsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetNotificationsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *NotificationPage
+}
+
+// Status returns HTTPResponse.Status
+func (r GetNotificationsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetNotificationsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type MarkNotificationsReadResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r MarkNotificationsReadResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r MarkNotificationsReadResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSavedResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]SavedItem
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSavedResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSavedResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type VoteResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r VoteResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r VoteResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetVoteReportWithResponse request returning *GetVoteReportResponse
+func (c *ClientWithResponses) GetVoteReportWithResponse(ctx context.Context, params *GetVoteReportParams, reqEditors ...RequestEditorFn) (*GetVoteReportResponse, error) {
+	rsp, err := c.GetVoteReport(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetVoteReportResponse(rsp)
+}
+
+// AddCommentWithBodyWithResponse request with arbitrary body returning *AddCommentResponse
+func (c *ClientWithResponses) AddCommentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddCommentResponse, error) {
+	rsp, err := c.AddCommentWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddCommentResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddCommentWithResponse(ctx context.Context, body AddCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*AddCommentResponse, error) {
+	rsp, err := c.AddComment(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddCommentResponse(rsp)
+}
+
+// DeleteCommentWithBodyWithResponse request with arbitrary body returning *DeleteCommentResponse
+func (c *ClientWithResponses) DeleteCommentWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeleteCommentResponse, error) {
+	rsp, err := c.DeleteCommentWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteCommentResponse(rsp)
+}
+
+func (c *ClientWithResponses) DeleteCommentWithResponse(ctx context.Context, id ID, body DeleteCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*DeleteCommentResponse, error) {
+	rsp, err := c.DeleteComment(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteCommentResponse(rsp)
+}
+
+// EditCommentWithBodyWithResponse request with arbitrary body returning *EditCommentResponse
+func (c *ClientWithResponses) EditCommentWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EditCommentResponse, error) {
+	rsp, err := c.EditCommentWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEditCommentResponse(rsp)
+}
+
+func (c *ClientWithResponses) EditCommentWithResponse(ctx context.Context, id ID, body EditCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*EditCommentResponse, error) {
+	rsp, err := c.EditComment(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEditCommentResponse(rsp)
+}
+
+// SaveCommentWithBodyWithResponse request with arbitrary body returning *SaveCommentResponse
+func (c *ClientWithResponses) SaveCommentWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SaveCommentResponse, error) {
+	rsp, err := c.SaveCommentWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSaveCommentResponse(rsp)
+}
+
+func (c *ClientWithResponses) SaveCommentWithResponse(ctx context.Context, id ID, body SaveCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*SaveCommentResponse, error) {
+	rsp, err := c.SaveComment(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSaveCommentResponse(rsp)
+}
+
+// UnsaveCommentWithBodyWithResponse request with arbitrary body returning *UnsaveCommentResponse
+func (c *ClientWithResponses) UnsaveCommentWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnsaveCommentResponse, error) {
+	rsp, err := c.UnsaveCommentWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnsaveCommentResponse(rsp)
+}
+
+func (c *ClientWithResponses) UnsaveCommentWithResponse(ctx context.Context, id ID, body UnsaveCommentJSONRequestBody, reqEditors ...RequestEditorFn) (*UnsaveCommentResponse, error) {
+	rsp, err := c.UnsaveComment(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnsaveCommentResponse(rsp)
+}
+
+// ListDomainPostsWithResponse request returning *ListDomainPostsResponse
+func (c *ClientWithResponses) ListDomainPostsWithResponse(ctx context.Context, domain string, params *ListDomainPostsParams, reqEditors ...RequestEditorFn) (*ListDomainPostsResponse, error) {
+	rsp, err := c.ListDomainPosts(ctx, domain, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListDomainPostsResponse(rsp)
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// UploadMediaWithBodyWithResponse request with arbitrary body returning *UploadMediaResponse
+func (c *ClientWithResponses) UploadMediaWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UploadMediaResponse, error) {
+	rsp, err := c.UploadMediaWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUploadMediaResponse(rsp)
+}
+
+// GetMediaWithResponse request returning *GetMediaResponse
+func (c *ClientWithResponses) GetMediaWithResponse(ctx context.Context, id MediaID, reqEditors ...RequestEditorFn) (*GetMediaResponse, error) {
+	rsp, err := c.GetMedia(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMediaResponse(rsp)
+}
+
+// GetMediaThumbnailWithResponse request returning *GetMediaThumbnailResponse
+func (c *ClientWithResponses) GetMediaThumbnailWithResponse(ctx context.Context, id MediaID, reqEditors ...RequestEditorFn) (*GetMediaThumbnailResponse, error) {
+	rsp, err := c.GetMediaThumbnail(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetMediaThumbnailResponse(rsp)
+}
+
+// CreatePostWithBodyWithResponse request with arbitrary body returning *CreatePostResponse
+func (c *ClientWithResponses) CreatePostWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePostResponse, error) {
+	rsp, err := c.CreatePostWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePostResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreatePostWithResponse(ctx context.Context, body CreatePostJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePostResponse, error) {
+	rsp, err := c.CreatePost(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePostResponse(rsp)
+}
+
+// DeletePostWithBodyWithResponse request with arbitrary body returning *DeletePostResponse
+func (c *ClientWithResponses) DeletePostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeletePostResponse, error) {
+	rsp, err := c.DeletePostWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeletePostResponse(rsp)
+}
+
+func (c *ClientWithResponses) DeletePostWithResponse(ctx context.Context, id ID, body DeletePostJSONRequestBody, reqEditors ...RequestEditorFn) (*DeletePostResponse, error) {
+	rsp, err := c.DeletePost(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeletePostResponse(rsp)
+}
+
+// EditPostWithBodyWithResponse request with arbitrary body returning *EditPostResponse
+func (c *ClientWithResponses) EditPostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EditPostResponse, error) {
+	rsp, err := c.EditPostWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEditPostResponse(rsp)
+}
+
+func (c *ClientWithResponses) EditPostWithResponse(ctx context.Context, id ID, body EditPostJSONRequestBody, reqEditors ...RequestEditorFn) (*EditPostResponse, error) {
+	rsp, err := c.EditPost(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEditPostResponse(rsp)
+}
+
+// CrosspostWithBodyWithResponse request with arbitrary body returning *CrosspostResponse
+func (c *ClientWithResponses) CrosspostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CrosspostResponse, error) {
+	rsp, err := c.CrosspostWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCrosspostResponse(rsp)
+}
+
+func (c *ClientWithResponses) CrosspostWithResponse(ctx context.Context, id ID, body CrosspostJSONRequestBody, reqEditors ...RequestEditorFn) (*CrosspostResponse, error) {
+	rsp, err := c.Crosspost(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCrosspostResponse(rsp)
+}
+
+// SetPostFlairWithBodyWithResponse request with arbitrary body returning *SetPostFlairResponse
+func (c *ClientWithResponses) SetPostFlairWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetPostFlairResponse, error) {
+	rsp, err := c.SetPostFlairWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPostFlairResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetPostFlairWithResponse(ctx context.Context, id ID, body SetPostFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*SetPostFlairResponse, error) {
+	rsp, err := c.SetPostFlair(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetPostFlairResponse(rsp)
+}
+
+// HidePostWithBodyWithResponse request with arbitrary body returning *HidePostResponse
+func (c *ClientWithResponses) HidePostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*HidePostResponse, error) {
+	rsp, err := c.HidePostWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHidePostResponse(rsp)
+}
+
+func (c *ClientWithResponses) HidePostWithResponse(ctx context.Context, id ID, body HidePostJSONRequestBody, reqEditors ...RequestEditorFn) (*HidePostResponse, error) {
+	rsp, err := c.HidePost(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHidePostResponse(rsp)
+}
+
+// SavePostWithBodyWithResponse request with arbitrary body returning *SavePostResponse
+func (c *ClientWithResponses) SavePostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SavePostResponse, error) {
+	rsp, err := c.SavePostWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSavePostResponse(rsp)
+}
+
+func (c *ClientWithResponses) SavePostWithResponse(ctx context.Context, id ID, body SavePostJSONRequestBody, reqEditors ...RequestEditorFn) (*SavePostResponse, error) {
+	rsp, err := c.SavePost(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSavePostResponse(rsp)
+}
+
+// UnhidePostWithBodyWithResponse request with arbitrary body returning *UnhidePostResponse
+func (c *ClientWithResponses) UnhidePostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnhidePostResponse, error) {
+	rsp, err := c.UnhidePostWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnhidePostResponse(rsp)
+}
+
+func (c *ClientWithResponses) UnhidePostWithResponse(ctx context.Context, id ID, body UnhidePostJSONRequestBody, reqEditors ...RequestEditorFn) (*UnhidePostResponse, error) {
+	rsp, err := c.UnhidePost(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnhidePostResponse(rsp)
+}
+
+// UnsavePostWithBodyWithResponse request with arbitrary body returning *UnsavePostResponse
+func (c *ClientWithResponses) UnsavePostWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnsavePostResponse, error) {
+	rsp, err := c.UnsavePostWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnsavePostResponse(rsp)
+}
+
+func (c *ClientWithResponses) UnsavePostWithResponse(ctx context.Context, id ID, body UnsavePostJSONRequestBody, reqEditors ...RequestEditorFn) (*UnsavePostResponse, error) {
+	rsp, err := c.UnsavePost(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnsavePostResponse(rsp)
+}
+
+// ReportContentWithBodyWithResponse request with arbitrary body returning *ReportContentResponse
+func (c *ClientWithResponses) ReportContentWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ReportContentResponse, error) {
+	rsp, err := c.ReportContentWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReportContentResponse(rsp)
+}
+
+func (c *ClientWithResponses) ReportContentWithResponse(ctx context.Context, body ReportContentJSONRequestBody, reqEditors ...RequestEditorFn) (*ReportContentResponse, error) {
+	rsp, err := c.ReportContent(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReportContentResponse(rsp)
+}
+
+// SearchWithResponse request returning *SearchResponse
+func (c *ClientWithResponses) SearchWithResponse(ctx context.Context, params *SearchParams, reqEditors ...RequestEditorFn) (*SearchResponse, error) {
+	rsp, err := c.Search(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchResponse(rsp)
+}
+
+// GetSpamStatsWithResponse request returning *GetSpamStatsResponse
+func (c *ClientWithResponses) GetSpamStatsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSpamStatsResponse, error) {
+	rsp, err := c.GetSpamStats(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSpamStatsResponse(rsp)
+}
+
+// StreamWithResponse request returning *StreamResponse
+func (c *ClientWithResponses) StreamWithResponse(ctx context.Context, params *StreamParams, reqEditors ...RequestEditorFn) (*StreamResponse, error) {
+	rsp, err := c.Stream(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStreamResponse(rsp)
+}
+
+// ListSubredditsWithResponse request returning *ListSubredditsResponse
+func (c *ClientWithResponses) ListSubredditsWithResponse(ctx context.Context, params *ListSubredditsParams, reqEditors ...RequestEditorFn) (*ListSubredditsResponse, error) {
+	rsp, err := c.ListSubreddits(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSubredditsResponse(rsp)
+}
+
+// CreateSubredditWithBodyWithResponse request with arbitrary body returning *CreateSubredditResponse
+func (c *ClientWithResponses) CreateSubredditWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateSubredditResponse, error) {
+	rsp, err := c.CreateSubredditWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSubredditResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateSubredditWithResponse(ctx context.Context, body CreateSubredditJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateSubredditResponse, error) {
+	rsp, err := c.CreateSubreddit(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateSubredditResponse(rsp)
+}
+
+// AutocompleteSubredditsWithResponse request returning *AutocompleteSubredditsResponse
+func (c *ClientWithResponses) AutocompleteSubredditsWithResponse(ctx context.Context, params *AutocompleteSubredditsParams, reqEditors ...RequestEditorFn) (*AutocompleteSubredditsResponse, error) {
+	rsp, err := c.AutocompleteSubreddits(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAutocompleteSubredditsResponse(rsp)
+}
+
+// TrendingSubredditsWithResponse request returning *TrendingSubredditsResponse
+func (c *ClientWithResponses) TrendingSubredditsWithResponse(ctx context.Context, params *TrendingSubredditsParams, reqEditors ...RequestEditorFn) (*TrendingSubredditsResponse, error) {
+	rsp, err := c.TrendingSubreddits(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTrendingSubredditsResponse(rsp)
+}
+
+// GetAutoModRulesWithResponse request returning *GetAutoModRulesResponse
+func (c *ClientWithResponses) GetAutoModRulesWithResponse(ctx context.Context, name Name, params *GetAutoModRulesParams, reqEditors ...RequestEditorFn) (*GetAutoModRulesResponse, error) {
+	rsp, err := c.GetAutoModRules(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAutoModRulesResponse(rsp)
+}
+
+// SetAutoModRulesWithBodyWithResponse request with arbitrary body returning *SetAutoModRulesResponse
+func (c *ClientWithResponses) SetAutoModRulesWithBodyWithResponse(ctx context.Context, name Name, params *SetAutoModRulesParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetAutoModRulesResponse, error) {
+	rsp, err := c.SetAutoModRulesWithBody(ctx, name, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetAutoModRulesResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetAutoModRulesWithResponse(ctx context.Context, name Name, params *SetAutoModRulesParams, body SetAutoModRulesJSONRequestBody, reqEditors ...RequestEditorFn) (*SetAutoModRulesResponse, error) {
+	rsp, err := c.SetAutoModRules(ctx, name, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetAutoModRulesResponse(rsp)
+}
+
+// TestAutoModWithBodyWithResponse request with arbitrary body returning *TestAutoModResponse
+func (c *ClientWithResponses) TestAutoModWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TestAutoModResponse, error) {
+	rsp, err := c.TestAutoModWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTestAutoModResponse(rsp)
+}
+
+func (c *ClientWithResponses) TestAutoModWithResponse(ctx context.Context, name Name, body TestAutoModJSONRequestBody, reqEditors ...RequestEditorFn) (*TestAutoModResponse, error) {
+	rsp, err := c.TestAutoMod(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTestAutoModResponse(rsp)
+}
+
+// UnbanUserWithBodyWithResponse request with arbitrary body returning *UnbanUserResponse
+func (c *ClientWithResponses) UnbanUserWithBodyWithResponse(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UnbanUserResponse, error) {
+	rsp, err := c.UnbanUserWithBody(ctx, name, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnbanUserResponse(rsp)
+}
+
+func (c *ClientWithResponses) UnbanUserWithResponse(ctx context.Context, name Name, id ID, body UnbanUserJSONRequestBody, reqEditors ...RequestEditorFn) (*UnbanUserResponse, error) {
+	rsp, err := c.UnbanUser(ctx, name, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnbanUserResponse(rsp)
+}
+
+// BanUserWithBodyWithResponse request with arbitrary body returning *BanUserResponse
+func (c *ClientWithResponses) BanUserWithBodyWithResponse(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BanUserResponse, error) {
+	rsp, err := c.BanUserWithBody(ctx, name, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBanUserResponse(rsp)
+}
+
+func (c *ClientWithResponses) BanUserWithResponse(ctx context.Context, name Name, id ID, body BanUserJSONRequestBody, reqEditors ...RequestEditorFn) (*BanUserResponse, error) {
+	rsp, err := c.BanUser(ctx, name, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBanUserResponse(rsp)
+}
+
+// SetUserFlairWithBodyWithResponse request with arbitrary body returning *SetUserFlairResponse
+func (c *ClientWithResponses) SetUserFlairWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetUserFlairResponse, error) {
+	rsp, err := c.SetUserFlairWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetUserFlairResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetUserFlairWithResponse(ctx context.Context, name Name, body SetUserFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*SetUserFlairResponse, error) {
+	rsp, err := c.SetUserFlair(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetUserFlairResponse(rsp)
+}
+
+// ListFlairsWithResponse request returning *ListFlairsResponse
+func (c *ClientWithResponses) ListFlairsWithResponse(ctx context.Context, name Name, params *ListFlairsParams, reqEditors ...RequestEditorFn) (*ListFlairsResponse, error) {
+	rsp, err := c.ListFlairs(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListFlairsResponse(rsp)
+}
+
+// CreateFlairWithBodyWithResponse request with arbitrary body returning *CreateFlairResponse
+func (c *ClientWithResponses) CreateFlairWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateFlairResponse, error) {
+	rsp, err := c.CreateFlairWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateFlairResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateFlairWithResponse(ctx context.Context, name Name, body CreateFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateFlairResponse, error) {
+	rsp, err := c.CreateFlair(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateFlairResponse(rsp)
+}
+
+// DeleteFlairWithBodyWithResponse request with arbitrary body returning *DeleteFlairResponse
+func (c *ClientWithResponses) DeleteFlairWithBodyWithResponse(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeleteFlairResponse, error) {
+	rsp, err := c.DeleteFlairWithBody(ctx, name, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteFlairResponse(rsp)
+}
+
+func (c *ClientWithResponses) DeleteFlairWithResponse(ctx context.Context, name Name, id ID, body DeleteFlairJSONRequestBody, reqEditors ...RequestEditorFn) (*DeleteFlairResponse, error) {
+	rsp, err := c.DeleteFlair(ctx, name, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteFlairResponse(rsp)
+}
+
+// JoinSubredditWithBodyWithResponse request with arbitrary body returning *JoinSubredditResponse
+func (c *ClientWithResponses) JoinSubredditWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*JoinSubredditResponse, error) {
+	rsp, err := c.JoinSubredditWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJoinSubredditResponse(rsp)
+}
+
+func (c *ClientWithResponses) JoinSubredditWithResponse(ctx context.Context, name Name, body JoinSubredditJSONRequestBody, reqEditors ...RequestEditorFn) (*JoinSubredditResponse, error) {
+	rsp, err := c.JoinSubreddit(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJoinSubredditResponse(rsp)
+}
+
+// LeaveSubredditWithBodyWithResponse request with arbitrary body returning *LeaveSubredditResponse
+func (c *ClientWithResponses) LeaveSubredditWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LeaveSubredditResponse, error) {
+	rsp, err := c.LeaveSubredditWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLeaveSubredditResponse(rsp)
+}
+
+func (c *ClientWithResponses) LeaveSubredditWithResponse(ctx context.Context, name Name, body LeaveSubredditJSONRequestBody, reqEditors ...RequestEditorFn) (*LeaveSubredditResponse, error) {
+	rsp, err := c.LeaveSubreddit(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLeaveSubredditResponse(rsp)
+}
+
+// GetModQueueWithResponse request returning *GetModQueueResponse
+func (c *ClientWithResponses) GetModQueueWithResponse(ctx context.Context, name Name, params *GetModQueueParams, reqEditors ...RequestEditorFn) (*GetModQueueResponse, error) {
+	rsp, err := c.GetModQueue(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetModQueueResponse(rsp)
+}
+
+// ResolveReportsWithBodyWithResponse request with arbitrary body returning *ResolveReportsResponse
+func (c *ClientWithResponses) ResolveReportsWithBodyWithResponse(ctx context.Context, name Name, target ResolveReportsParamsTarget, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ResolveReportsResponse, error) {
+	rsp, err := c.ResolveReportsWithBody(ctx, name, target, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResolveReportsResponse(rsp)
+}
+
+func (c *ClientWithResponses) ResolveReportsWithResponse(ctx context.Context, name Name, target ResolveReportsParamsTarget, id ID, body ResolveReportsJSONRequestBody, reqEditors ...RequestEditorFn) (*ResolveReportsResponse, error) {
+	rsp, err := c.ResolveReports(ctx, name, target, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseResolveReportsResponse(rsp)
+}
+
+// ListSubredditPostsWithResponse request returning *ListSubredditPostsResponse
+func (c *ClientWithResponses) ListSubredditPostsWithResponse(ctx context.Context, name Name, params *ListSubredditPostsParams, reqEditors ...RequestEditorFn) (*ListSubredditPostsResponse, error) {
+	rsp, err := c.ListSubredditPosts(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSubredditPostsResponse(rsp)
+}
+
+// GetReportReasonsWithResponse request returning *GetReportReasonsResponse
+func (c *ClientWithResponses) GetReportReasonsWithResponse(ctx context.Context, name Name, reqEditors ...RequestEditorFn) (*GetReportReasonsResponse, error) {
+	rsp, err := c.GetReportReasons(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetReportReasonsResponse(rsp)
+}
+
+// SetReportReasonsWithBodyWithResponse request with arbitrary body returning *SetReportReasonsResponse
+func (c *ClientWithResponses) SetReportReasonsWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetReportReasonsResponse, error) {
+	rsp, err := c.SetReportReasonsWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetReportReasonsResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetReportReasonsWithResponse(ctx context.Context, name Name, body SetReportReasonsJSONRequestBody, reqEditors ...RequestEditorFn) (*SetReportReasonsResponse, error) {
+	rsp, err := c.SetReportReasons(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetReportReasonsResponse(rsp)
+}
+
+// ListWebhooksWithResponse request returning *ListWebhooksResponse
+func (c *ClientWithResponses) ListWebhooksWithResponse(ctx context.Context, name Name, params *ListWebhooksParams, reqEditors ...RequestEditorFn) (*ListWebhooksResponse, error) {
+	rsp, err := c.ListWebhooks(ctx, name, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListWebhooksResponse(rsp)
+}
+
+// RegisterWebhookWithBodyWithResponse request with arbitrary body returning *RegisterWebhookResponse
+func (c *ClientWithResponses) RegisterWebhookWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterWebhookResponse, error) {
+	rsp, err := c.RegisterWebhookWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterWebhookResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterWebhookWithResponse(ctx context.Context, name Name, body RegisterWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterWebhookResponse, error) {
+	rsp, err := c.RegisterWebhook(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterWebhookResponse(rsp)
+}
+
+// DeleteWebhookWithBodyWithResponse request with arbitrary body returning *DeleteWebhookResponse
+func (c *ClientWithResponses) DeleteWebhookWithBodyWithResponse(ctx context.Context, name Name, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DeleteWebhookResponse, error) {
+	rsp, err := c.DeleteWebhookWithBody(ctx, name, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteWebhookResponse(rsp)
+}
+
+func (c *ClientWithResponses) DeleteWebhookWithResponse(ctx context.Context, name Name, id ID, body DeleteWebhookJSONRequestBody, reqEditors ...RequestEditorFn) (*DeleteWebhookResponse, error) {
+	rsp, err := c.DeleteWebhook(ctx, name, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteWebhookResponse(rsp)
+}
+
+// RegisterUserWithBodyWithResponse request with arbitrary body returning *RegisterUserResponse
+func (c *ClientWithResponses) RegisterUserWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RegisterUserResponse, error) {
+	rsp, err := c.RegisterUserWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterUserResponse(rsp)
+}
+
+func (c *ClientWithResponses) RegisterUserWithResponse(ctx context.Context, body RegisterUserJSONRequestBody, reqEditors ...RequestEditorFn) (*RegisterUserResponse, error) {
+	rsp, err := c.RegisterUser(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRegisterUserResponse(rsp)
+}
+
+// GetAllUsersWithResponse request returning *GetAllUsersResponse
+func (c *ClientWithResponses) GetAllUsersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetAllUsersResponse, error) {
+	rsp, err := c.GetAllUsers(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetAllUsersResponse(rsp)
+}
+
+// GetNotificationsWithResponse request returning *GetNotificationsResponse
+func (c *ClientWithResponses) GetNotificationsWithResponse(ctx context.Context, id ID, params *GetNotificationsParams, reqEditors ...RequestEditorFn) (*GetNotificationsResponse, error) {
+	rsp, err := c.GetNotifications(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetNotificationsResponse(rsp)
+}
+
+// MarkNotificationsReadWithBodyWithResponse request with arbitrary body returning *MarkNotificationsReadResponse
+func (c *ClientWithResponses) MarkNotificationsReadWithBodyWithResponse(ctx context.Context, id ID, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*MarkNotificationsReadResponse, error) {
+	rsp, err := c.MarkNotificationsReadWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMarkNotificationsReadResponse(rsp)
+}
+
+func (c *ClientWithResponses) MarkNotificationsReadWithResponse(ctx context.Context, id ID, body MarkNotificationsReadJSONRequestBody, reqEditors ...RequestEditorFn) (*MarkNotificationsReadResponse, error) {
+	rsp, err := c.MarkNotificationsRead(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseMarkNotificationsReadResponse(rsp)
+}
+
+// GetSavedWithResponse request returning *GetSavedResponse
+func (c *ClientWithResponses) GetSavedWithResponse(ctx context.Context, id ID, params *GetSavedParams, reqEditors ...RequestEditorFn) (*GetSavedResponse, error) {
+	rsp, err := c.GetSaved(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSavedResponse(rsp)
+}
+
+// VoteWithBodyWithResponse request with arbitrary body returning *VoteResponse
+func (c *ClientWithResponses) VoteWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*VoteResponse, error) {
+	rsp, err := c.VoteWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVoteResponse(rsp)
+}
+
+func (c *ClientWithResponses) VoteWithResponse(ctx context.Context, body VoteJSONRequestBody, reqEditors ...RequestEditorFn) (*VoteResponse, error) {
+	rsp, err := c.Vote(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseVoteResponse(rsp)
+}
+
+// ParseGetVoteReportResponse parses an HTTP response from a GetVoteReportWithResponse call
+func ParseGetVoteReportResponse(rsp *http.Response) (*GetVoteReportResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetVoteReportResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VoteReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddCommentResponse parses an HTTP response from a AddCommentWithResponse call
+func ParseAddCommentResponse(rsp *http.Response) (*AddCommentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddCommentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseDeleteCommentResponse parses an HTTP response from a DeleteCommentWithResponse call
+func ParseDeleteCommentResponse(rsp *http.Response) (*DeleteCommentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteCommentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseEditCommentResponse parses an HTTP response from a EditCommentWithResponse call
+func ParseEditCommentResponse(rsp *http.Response) (*EditCommentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EditCommentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseSaveCommentResponse parses an HTTP response from a SaveCommentWithResponse call
+func ParseSaveCommentResponse(rsp *http.Response) (*SaveCommentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SaveCommentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseUnsaveCommentResponse parses an HTTP response from a UnsaveCommentWithResponse call
+func ParseUnsaveCommentResponse(rsp *http.Response) (*UnsaveCommentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnsaveCommentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseListDomainPostsResponse parses an HTTP response from a ListDomainPostsWithResponse call
+func ParseListDomainPostsResponse(rsp *http.Response) (*ListDomainPostsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListDomainPostsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []PostSummary
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Health
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUploadMediaResponse parses an HTTP response from a UploadMediaWithResponse call
+func ParseUploadMediaResponse(rsp *http.Response) (*UploadMediaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UploadMediaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Media
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetMediaResponse parses an HTTP response from a GetMediaWithResponse call
+func ParseGetMediaResponse(rsp *http.Response) (*GetMediaResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetMediaResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetMediaThumbnailResponse parses an HTTP response from a GetMediaThumbnailWithResponse call
+func ParseGetMediaThumbnailResponse(rsp *http.Response) (*GetMediaThumbnailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetMediaThumbnailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseCreatePostResponse parses an HTTP response from a CreatePostWithResponse call
+func ParseCreatePostResponse(rsp *http.Response) (*CreatePostResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreatePostResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseDeletePostResponse parses an HTTP response from a DeletePostWithResponse call
+func ParseDeletePostResponse(rsp *http.Response) (*DeletePostResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeletePostResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseEditPostResponse parses an HTTP response from a EditPostWithResponse call
+func ParseEditPostResponse(rsp *http.Response) (*EditPostResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EditPostResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseCrosspostResponse parses an HTTP response from a CrosspostWithResponse call
+func ParseCrosspostResponse(rsp *http.Response) (*CrosspostResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CrosspostResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest PostSummary
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetPostFlairResponse parses an HTTP response from a SetPostFlairWithResponse call
+func ParseSetPostFlairResponse(rsp *http.Response) (*SetPostFlairResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetPostFlairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseHidePostResponse parses an HTTP response from a HidePostWithResponse call
+func ParseHidePostResponse(rsp *http.Response) (*HidePostResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HidePostResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseSavePostResponse parses an HTTP response from a SavePostWithResponse call
+func ParseSavePostResponse(rsp *http.Response) (*SavePostResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SavePostResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseUnhidePostResponse parses an HTTP response from a UnhidePostWithResponse call
+func ParseUnhidePostResponse(rsp *http.Response) (*UnhidePostResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnhidePostResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseUnsavePostResponse parses an HTTP response from a UnsavePostWithResponse call
+func ParseUnsavePostResponse(rsp *http.Response) (*UnsavePostResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnsavePostResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseReportContentResponse parses an HTTP response from a ReportContentWithResponse call
+func ParseReportContentResponse(rsp *http.Response) (*ReportContentResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReportContentResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseSearchResponse parses an HTTP response from a SearchWithResponse call
+func ParseSearchResponse(rsp *http.Response) (*SearchResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SearchResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SearchResults
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSpamStatsResponse parses an HTTP response from a GetSpamStatsWithResponse call
+func ParseGetSpamStatsResponse(rsp *http.Response) (*GetSpamStatsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSpamStatsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SpamStats
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStreamResponse parses an HTTP response from a StreamWithResponse call
+func ParseStreamResponse(rsp *http.Response) (*StreamResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StreamResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseListSubredditsResponse parses an HTTP response from a ListSubredditsWithResponse call
+func ParseListSubredditsResponse(rsp *http.Response) (*ListSubredditsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListSubredditsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SubredditListing
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateSubredditResponse parses an HTTP response from a CreateSubredditWithResponse call
+func ParseCreateSubredditResponse(rsp *http.Response) (*CreateSubredditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateSubredditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseAutocompleteSubredditsResponse parses an HTTP response from a AutocompleteSubredditsWithResponse call
+func ParseAutocompleteSubredditsResponse(rsp *http.Response) (*AutocompleteSubredditsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AutocompleteSubredditsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SubredditListing
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTrendingSubredditsResponse parses an HTTP response from a TrendingSubredditsWithResponse call
+func ParseTrendingSubredditsResponse(rsp *http.Response) (*TrendingSubredditsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TrendingSubredditsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SubredditListing
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetAutoModRulesResponse parses an HTTP response from a GetAutoModRulesWithResponse call
+func ParseGetAutoModRulesResponse(rsp *http.Response) (*GetAutoModRulesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAutoModRulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []AutoModRule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetAutoModRulesResponse parses an HTTP response from a SetAutoModRulesWithResponse call
+func ParseSetAutoModRulesResponse(rsp *http.Response) (*SetAutoModRulesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetAutoModRulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseTestAutoModResponse parses an HTTP response from a TestAutoModWithResponse call
+func ParseTestAutoModResponse(rsp *http.Response) (*TestAutoModResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TestAutoModResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest AutoModVerdict
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUnbanUserResponse parses an HTTP response from a UnbanUserWithResponse call
+func ParseUnbanUserResponse(rsp *http.Response) (*UnbanUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnbanUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseBanUserResponse parses an HTTP response from a BanUserWithResponse call
+func ParseBanUserResponse(rsp *http.Response) (*BanUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BanUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseSetUserFlairResponse parses an HTTP response from a SetUserFlairWithResponse call
+func ParseSetUserFlairResponse(rsp *http.Response) (*SetUserFlairResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetUserFlairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseListFlairsResponse parses an HTTP response from a ListFlairsWithResponse call
+func ParseListFlairsResponse(rsp *http.Response) (*ListFlairsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListFlairsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Flair
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateFlairResponse parses an HTTP response from a CreateFlairWithResponse call
+func ParseCreateFlairResponse(rsp *http.Response) (*CreateFlairResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateFlairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Flair
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteFlairResponse parses an HTTP response from a DeleteFlairWithResponse call
+func ParseDeleteFlairResponse(rsp *http.Response) (*DeleteFlairResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteFlairResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseJoinSubredditResponse parses an HTTP response from a JoinSubredditWithResponse call
+func ParseJoinSubredditResponse(rsp *http.Response) (*JoinSubredditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &JoinSubredditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseLeaveSubredditResponse parses an HTTP response from a LeaveSubredditWithResponse call
+func ParseLeaveSubredditResponse(rsp *http.Response) (*LeaveSubredditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LeaveSubredditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetModQueueResponse parses an HTTP response from a GetModQueueWithResponse call
+func ParseGetModQueueResponse(rsp *http.Response) (*GetModQueueResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetModQueueResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Report
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseResolveReportsResponse parses an HTTP response from a ResolveReportsWithResponse call
+func ParseResolveReportsResponse(rsp *http.Response) (*ResolveReportsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ResolveReportsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseListSubredditPostsResponse parses an HTTP response from a ListSubredditPostsWithResponse call
+func ParseListSubredditPostsResponse(rsp *http.Response) (*ListSubredditPostsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListSubredditPostsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []PostSummary
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetReportReasonsResponse parses an HTTP response from a GetReportReasonsWithResponse call
+func ParseGetReportReasonsResponse(rsp *http.Response) (*GetReportReasonsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetReportReasonsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []string
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetReportReasonsResponse parses an HTTP response from a SetReportReasonsWithResponse call
+func ParseSetReportReasonsResponse(rsp *http.Response) (*SetReportReasonsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetReportReasonsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseListWebhooksResponse parses an HTTP response from a ListWebhooksWithResponse call
+func ParseListWebhooksResponse(rsp *http.Response) (*ListWebhooksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListWebhooksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Webhook
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRegisterWebhookResponse parses an HTTP response from a RegisterWebhookWithResponse call
+func ParseRegisterWebhookResponse(rsp *http.Response) (*RegisterWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Webhook
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteWebhookResponse parses an HTTP response from a DeleteWebhookWithResponse call
+func ParseDeleteWebhookResponse(rsp *http.Response) (*DeleteWebhookResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteWebhookResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseRegisterUserResponse parses an HTTP response from a RegisterUserWithResponse call
+func ParseRegisterUserResponse(rsp *http.Response) (*RegisterUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RegisterUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetAllUsersResponse parses an HTTP response from a GetAllUsersWithResponse call
+func ParseGetAllUsersResponse(rsp *http.Response) (*GetAllUsersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetAllUsersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetNotificationsResponse parses an HTTP response from a GetNotificationsWithResponse call
+func ParseGetNotificationsResponse(rsp *http.Response) (*GetNotificationsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetNotificationsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest NotificationPage
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseMarkNotificationsReadResponse parses an HTTP response from a MarkNotificationsReadWithResponse call
+func ParseMarkNotificationsReadResponse(rsp *http.Response) (*MarkNotificationsReadResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &MarkNotificationsReadResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetSavedResponse parses an HTTP response from a GetSavedWithResponse call
+func ParseGetSavedResponse(rsp *http.Response) (*GetSavedResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSavedResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []SavedItem
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseVoteResponse parses an HTTP response from a VoteWithResponse call
+func ParseVoteResponse(rsp *http.Response) (*VoteResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &VoteResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
@@ -0,0 +1,417 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"gopkg.in/yaml.v3"
+)
+
+// AutoModeratorID is the user ID AutoModerator acts and replies as; no registered user has it
+const AutoModeratorID = 0
+
+// AutoModerator rule types
+const (
+	AutoModPost    = "post"
+	AutoModComment = "comment"
+)
+
+// AutoModerator actions
+const (
+	AutoModRemove = "remove" // remove the item, as a moderator would
+	AutoModFilter = "filter" // hold the item in the moderator queue until a moderator approves it
+	AutoModFlair  = "flair"  // flair the post with SetFlair and SetFlairColor
+	AutoModLock   = "lock"   // stop new comments on the post
+	AutoModReply  = "reply"  // only reply; any rule may set Reply
+)
+
+const (
+	MaxAutoModRules       = 100
+	MaxAutoModNameLength  = 100
+	MaxAutoModReplyLength = 10000
+)
+
+var ErrInvalidAutoMod = errors.New("invalid AutoModerator rule")
+
+// AutoModRule is one of a subreddit's AutoModerator rules. A rule matches a new post or comment
+// when every condition it sets holds; conditions on the author only hold for registered users.
+type AutoModRule struct {
+	Name string `json:"name" yaml:"name"`
+	Type string `json:"type,omitempty" yaml:"type,omitempty"` // AutoModPost, AutoModComment, or both when empty
+
+	// Conditions
+	AuthorKarmaBelow      *int     `json:"author_karma_below,omitempty" yaml:"author_karma_below,omitempty"`
+	AuthorAccountAgeBelow string   `json:"author_account_age_below,omitempty" yaml:"author_account_age_below,omitempty"` // a Go duration, like "72h"
+	TitleRegex            string   `json:"title_regex,omitempty" yaml:"title_regex,omitempty"`                           // posts only
+	BodyRegex             string   `json:"body_regex,omitempty" yaml:"body_regex,omitempty"`
+	Domains               []string `json:"domains,omitempty" yaml:"domains,omitempty"` // link and image posts to these domains or their subdomains
+	Flairs                []string `json:"flairs,omitempty" yaml:"flairs,omitempty"`   // posts with one of these post flairs, by text
+
+	// Actions
+	Action        string `json:"action,omitempty" yaml:"action,omitempty"` // one of the AutoMod* actions
+	SetFlair      string `json:"set_flair,omitempty" yaml:"set_flair,omitempty"`
+	SetFlairColor string `json:"set_flair_color,omitempty" yaml:"set_flair_color,omitempty"`
+	Reply         string `json:"reply,omitempty" yaml:"reply,omitempty"` // markdown AutoModerator replies with
+
+	accountAge time.Duration
+	title      *regexp.Regexp
+	body       *regexp.Regexp
+}
+
+// AutoModItem is a post or comment as AutoModerator rules see it
+type AutoModItem struct {
+	Type    string `json:"type" yaml:"type"` // AutoModPost or AutoModComment
+	Title   string `json:"title,omitempty" yaml:"title,omitempty"`
+	Content string `json:"content,omitempty" yaml:"content,omitempty"`
+	URL     string `json:"url,omitempty" yaml:"url,omitempty"`
+	Flair   string `json:"flair,omitempty" yaml:"flair,omitempty"` // text of the post flair
+}
+
+// AutoModVerdict is what a subreddit's rules do to an item
+type AutoModVerdict struct {
+	Rules   []string       `json:"rules"`             // names of the matching rules, in order
+	Removal string         `json:"removal,omitempty"` // AutoModRemove or AutoModFilter; remove wins
+	Reason  string         `json:"reason,omitempty"`  // name of the rule that decided Removal
+	Lock    bool           `json:"lock,omitempty"`
+	Flair   *FlairTemplate `json:"flair,omitempty"` // from the first matching flair rule
+	Replies []string       `json:"replies,omitempty"`
+}
+
+// compile checks a rule and prepares its regexes and durations; rules loaded from the Store
+// are compiled again before use.
+func (r *AutoModRule) compile() error {
+	r.Name = strings.TrimSpace(r.Name)
+	switch {
+	case r.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidAutoMod)
+	case utf8.RuneCountInString(r.Name) > MaxAutoModNameLength:
+		return fmt.Errorf("%w: name %q is longer than %d characters", ErrInvalidAutoMod, r.Name, MaxAutoModNameLength)
+	case r.Type != "" && r.Type != AutoModPost && r.Type != AutoModComment:
+		return fmt.Errorf("%w: rule %q: type must be %q or %q", ErrInvalidAutoMod, r.Name, AutoModPost, AutoModComment)
+	}
+	postsOnly := r.TitleRegex != "" || len(r.Domains) > 0 || len(r.Flairs) > 0 || r.Action == AutoModFlair || r.Action == AutoModLock
+	if postsOnly && r.Type != AutoModPost {
+		return fmt.Errorf("%w: rule %q: title, domain and flair conditions and the flair and lock actions need type %q", ErrInvalidAutoMod, r.Name, AutoModPost)
+	}
+
+	var err error
+	r.accountAge = 0
+	if r.AuthorAccountAgeBelow != "" {
+		if r.accountAge, err = time.ParseDuration(r.AuthorAccountAgeBelow); err != nil || r.accountAge <= 0 {
+			return fmt.Errorf("%w: rule %q: author_account_age_below must be a positive duration like \"72h\"", ErrInvalidAutoMod, r.Name)
+		}
+	}
+	if r.title, err = compileRuleRegex(r.TitleRegex); err != nil {
+		return fmt.Errorf("%w: rule %q: title_regex: %v", ErrInvalidAutoMod, r.Name, err)
+	}
+	if r.body, err = compileRuleRegex(r.BodyRegex); err != nil {
+		return fmt.Errorf("%w: rule %q: body_regex: %v", ErrInvalidAutoMod, r.Name, err)
+	}
+	domains := make([]string, 0, len(r.Domains))
+	for _, domain := range r.Domains {
+		domains = append(domains, LinkDomain(strings.TrimSpace(domain)))
+	}
+	if len(domains) > 0 {
+		r.Domains = domains
+	}
+
+	r.SetFlair = strings.TrimSpace(r.SetFlair)
+	r.SetFlairColor = strings.ToLower(strings.TrimSpace(r.SetFlairColor))
+	switch r.Action {
+	case AutoModRemove, AutoModFilter, AutoModLock:
+	case AutoModFlair:
+		switch {
+		case r.SetFlair == "" || utf8.RuneCountInString(r.SetFlair) > MaxFlairTextLength:
+			return fmt.Errorf("%w: rule %q: set_flair needs 1 to %d characters", ErrInvalidAutoMod, r.Name, MaxFlairTextLength)
+		case r.SetFlairColor != "" && !flairColor.MatchString(r.SetFlairColor):
+			return fmt.Errorf("%w: rule %q: set_flair_color must look like #1a2b3c", ErrInvalidAutoMod, r.Name)
+		}
+	case AutoModReply:
+		if strings.TrimSpace(r.Reply) == "" {
+			return fmt.Errorf("%w: rule %q: the reply action needs a reply", ErrInvalidAutoMod, r.Name)
+		}
+	default:
+		return fmt.Errorf("%w: rule %q: action must be %s, %s, %s, %s or %s", ErrInvalidAutoMod, r.Name, AutoModRemove, AutoModFilter, AutoModFlair, AutoModLock, AutoModReply)
+	}
+	if utf8.RuneCountInString(r.Reply) > MaxAutoModReplyLength {
+		return fmt.Errorf("%w: rule %q: reply is longer than %d characters", ErrInvalidAutoMod, r.Name, MaxAutoModReplyLength)
+	}
+	return nil
+}
+
+func compileRuleRegex(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile(pattern)
+}
+
+// compileAutoModRules compiles a subreddit's rules, which must have distinct names
+func compileAutoModRules(rules []AutoModRule) error {
+	if len(rules) > MaxAutoModRules {
+		return fmt.Errorf("%w: at most %d rules", ErrInvalidAutoMod, MaxAutoModRules)
+	}
+	seen := map[string]bool{}
+	for i := range rules {
+		if err := rules[i].compile(); err != nil {
+			return err
+		}
+		key := strings.ToLower(rules[i].Name)
+		if seen[key] {
+			return fmt.Errorf("%w: two rules are named %q", ErrInvalidAutoMod, rules[i].Name)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// needsAuthor reports whether any of the rules looks at the author
+func needsAuthor(rules []AutoModRule) bool {
+	return slices.ContainsFunc(rules, func(rule AutoModRule) bool {
+		return rule.AuthorKarmaBelow != nil || rule.accountAge > 0
+	})
+}
+
+func (r *AutoModRule) matches(item AutoModItem, author *User, now time.Time) bool {
+	switch {
+	case r.Type != "" && r.Type != item.Type:
+		return false
+	case r.AuthorKarmaBelow != nil && (author == nil || author.Karma >= *r.AuthorKarmaBelow):
+		return false
+	case r.accountAge > 0 && (author == nil || now.Sub(author.CreatedAt) >= r.accountAge):
+		return false
+	case r.title != nil && !r.title.MatchString(item.Title):
+		return false
+	case r.body != nil && !r.body.MatchString(item.Content):
+		return false
+	case len(r.Flairs) > 0 && !slices.ContainsFunc(r.Flairs, func(flair string) bool { return strings.EqualFold(flair, item.Flair) }):
+		return false
+	}
+	if len(r.Domains) > 0 {
+		domain := ""
+		if item.URL != "" {
+			domain = domainOf(item.URL)
+		}
+		return domain != "" && slices.ContainsFunc(r.Domains, func(wanted string) bool {
+			return domain == wanted || strings.HasSuffix(domain, "."+wanted)
+		})
+	}
+	return true
+}
+
+// evaluateAutoMod runs compiled rules against an item, author being nil when unknown.
+func evaluateAutoMod(rules []AutoModRule, item AutoModItem, author *User) AutoModVerdict {
+	verdict := AutoModVerdict{Rules: []string{}}
+	now := time.Now()
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.matches(item, author, now) {
+			continue
+		}
+		verdict.Rules = append(verdict.Rules, rule.Name)
+		switch rule.Action {
+		case AutoModRemove:
+			if verdict.Removal != AutoModRemove {
+				verdict.Removal, verdict.Reason = AutoModRemove, rule.Name
+			}
+		case AutoModFilter:
+			if verdict.Removal == "" {
+				verdict.Removal, verdict.Reason = AutoModFilter, rule.Name
+			}
+		case AutoModFlair:
+			if verdict.Flair == nil {
+				verdict.Flair = &FlairTemplate{Type: FlairPost, Text: rule.SetFlair, Color: rule.SetFlairColor}
+			}
+		case AutoModLock:
+			verdict.Lock = true
+		}
+		if strings.TrimSpace(rule.Reply) != "" {
+			verdict.Replies = append(verdict.Replies, rule.Reply)
+		}
+	}
+	return verdict
+}
+
+// withAuthor calls create with the author when the subreddit's rules look at authors, fetching
+// them from UserActor without blocking the mailbox, and with nil otherwise.
+func (p *PostActor) withAuthor(ctx actor.Context, subreddit string, userID int, requestID string, create func(author *User)) {
+	p.mu.Lock()
+	fetch := p.userActor != nil && needsAuthor(p.automod[subreddit])
+	p.mu.Unlock()
+	if !fetch {
+		create(nil)
+		return
+	}
+	future := ctx.RequestFuture(p.userActor, &GetUser{UserID: userID, RequestID: requestID}, p.timeout)
+	ctx.ReenterAfter(future, func(res interface{}, err error) {
+		if err != nil {
+			p.logger.Warn("author lookup failed, AutoModerator sees an unknown author", "request_id", requestID, "user_id", userID, "error", err)
+		}
+		author, _ := res.(*User)
+		create(author)
+	})
+}
+
+// enforceAutoMod carries out the parts of verdict that reach past the new post or comment:
+// the author hears about removals, filtered items go to the moderator queue and AutoModerator
+// replies. p.mu must be held.
+func (p *PostActor) enforceAutoMod(ctx actor.Context, post *Post, comment *Comment, verdict AutoModVerdict, requestID string) {
+	if len(verdict.Rules) == 0 {
+		return
+	}
+	target, id, authorID := ReportPost, post.ID, post.UserID
+	if comment != nil {
+		target, id, authorID = ReportComment, comment.ID, comment.UserID
+	}
+	p.logger.Info("AutoModerator rules matched", "request_id", requestID, "subreddit", post.Subreddit, "target", target, "id", id, "rules", verdict.Rules, "removal", verdict.Removal)
+
+	switch verdict.Removal {
+	case AutoModRemove:
+		if p.notificationActor != nil {
+			notification := Notification{Type: NotificationModAction, FromUserID: AutoModeratorID, PostID: post.ID, Subreddit: post.Subreddit, Excerpt: excerpt("Removed by AutoModerator: " + verdict.Reason)}
+			if comment != nil {
+				notification.CommentID = comment.ID
+			}
+			ctx.Send(p.notificationActor, &Notify{RecipientID: authorID, Notification: notification})
+		}
+	case AutoModFilter:
+		if p.reportActor != nil {
+			ctx.Send(p.reportActor, &ReportContent{UserID: AutoModeratorID, Target: target, ID: id, Reason: "AutoModerator: " + verdict.Reason, Subreddit: post.Subreddit, RequestID: requestID})
+		} else {
+			p.logger.Error("no ReportActor assigned, filtered item not queued", "request_id", requestID, "target", target, "id", id)
+		}
+	}
+
+	parentID := 0
+	if comment != nil {
+		parentID = comment.ID
+	}
+	for _, reply := range verdict.Replies {
+		p.addComment(ctx, post, &Comment{
+			ID:          len(p.comments) + 1,
+			PostID:      post.ID,
+			ParentID:    parentID,
+			UserID:      AutoModeratorID,
+			Content:     reply,
+			ContentHTML: RenderMarkdown(reply),
+		})
+	}
+}
+
+// approve lets a post or comment AutoModerator or the spam filter held go live, and teaches the
+// spam filter that an approved item is not spam; p.mu must be held.
+func (p *PostActor) approve(ctx actor.Context, msg *ApproveContent) {
+	switch msg.Target {
+	case ReportPost:
+		post, exists := p.posts[msg.ID]
+		if !exists || post.Deleted {
+			return
+		}
+		if !msg.Ignored {
+			p.learnSpam(SpamExample{Text: postSpamText(post), Target: ReportPost, ID: post.ID, Subreddit: post.Subreddit, At: time.Now()}, msg.RequestID)
+		}
+		if !post.Filtered {
+			return
+		}
+		post.Filtered = false
+		p.store.SavePost(post)
+		p.announcePost(ctx, post)
+		for _, comment := range post.Comments {
+			if comment.live() {
+				p.index.indexComment(post, comment)
+			}
+		}
+		p.notifyMentions(ctx, post.Content, Notification{FromUserID: post.UserID, PostID: post.ID, Subreddit: post.Subreddit, Excerpt: excerpt(post.Content)})
+	case ReportComment:
+		comment, exists := p.comments[msg.ID]
+		if !exists || comment.Deleted {
+			return
+		}
+		post := p.posts[comment.PostID]
+		if !msg.Ignored {
+			p.learnSpam(SpamExample{Text: comment.Content, Target: ReportComment, ID: comment.ID, Subreddit: post.Subreddit, At: time.Now()}, msg.RequestID)
+		}
+		if !comment.Filtered {
+			return
+		}
+		comment.Filtered = false
+		p.store.SavePost(post)
+		if post.live() {
+			p.announceComment(ctx, post, comment)
+		}
+	default:
+		return
+	}
+	p.logger.Info("filtered content approved", "request_id", msg.RequestID, "target", msg.Target, "id", msg.ID, "moderator_id", msg.ModeratorID)
+}
+
+// ParseAutoModRules reads a rules document, a YAML (or JSON) list of AutoModRule.
+func ParseAutoModRules(data []byte) ([]AutoModRule, error) {
+	var rules []AutoModRule
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAutoMod, err)
+	}
+	return rules, nil
+}
+
+// SetAutoModRules replaces a subreddit's AutoModerator rules on behalf of one of its
+// moderators. New posts and comments are checked against them from then on.
+func (as *ActorSystem) SetAutoModRules(ctx context.Context, msg SetAutoModRules) error {
+	if msg.Rules == nil {
+		msg.Rules = []AutoModRule{}
+	}
+	if err := compileAutoModRules(msg.Rules); err != nil {
+		return err
+	}
+	if _, err := as.moderatedSubreddit(ctx, msg.Subreddit, msg.UserID, msg.RequestID); err != nil {
+		return err
+	}
+	as.send(ctx, as.SubredditActor, &msg)
+	forward := msg
+	forward.Rules = slices.Clone(msg.Rules)
+	as.send(ctx, as.PostActor, &forward)
+	return nil
+}
+
+// AutoModRules returns a subreddit's AutoModerator rules to one of its moderators.
+func (as *ActorSystem) AutoModRules(ctx context.Context, subreddit string, userID int, requestID string) ([]AutoModRule, error) {
+	found, err := as.moderatedSubreddit(ctx, subreddit, userID, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if found.AutoModRules == nil {
+		return []AutoModRule{}, nil
+	}
+	return found.AutoModRules, nil
+}
+
+// TestAutoMod evaluates msg.Rules, or the subreddit's rules when msg.Rules is nil, against a
+// hypothetical item by msg.AuthorID without posting anything.
+func (as *ActorSystem) TestAutoMod(ctx context.Context, msg TestAutoMod) (AutoModVerdict, error) {
+	subreddit, err := as.moderatedSubreddit(ctx, msg.Subreddit, msg.UserID, msg.RequestID)
+	if err != nil {
+		return AutoModVerdict{}, err
+	}
+	if msg.Item.Type != AutoModPost && msg.Item.Type != AutoModComment {
+		return AutoModVerdict{}, fmt.Errorf("%w: item type must be %q or %q", ErrInvalidAutoMod, AutoModPost, AutoModComment)
+	}
+	rules := msg.Rules
+	if rules == nil {
+		rules = subreddit.AutoModRules
+	}
+	if err := compileAutoModRules(rules); err != nil {
+		return AutoModVerdict{}, err
+	}
+	var author *User
+	if needsAuthor(rules) {
+		author, _ = as.GetUser(ctx, GetUser{UserID: msg.AuthorID, RequestID: msg.RequestID})
+	}
+	return evaluateAutoMod(rules, msg.Item, author), nil
+}
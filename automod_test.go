@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestEditsFollowAutoModRules(t *testing.T) {
+	ctx := context.Background()
+	as := newTestActorSystem(t, NewMemoryStore())
+	as.CreateSubreddit(ctx, CreateSubreddit{Name: "golang", CreatorID: 1})
+	eventually(t, "r/golang to set its rules", func() bool {
+		return as.SetAutoModRules(ctx, SetAutoModRules{Subreddit: "golang", UserID: 1, Rules: []AutoModRule{
+			{Name: "no casinos", Type: AutoModPost, BodyRegex: "(?i)casino", Action: AutoModRemove},
+			{Name: "hold rumours", Type: AutoModComment, BodyRegex: "(?i)rumou?r", Action: AutoModFilter},
+		}}) == nil
+	})
+	as.CreatePost(ctx, PostMessage{UserID: 2, Subreddit: "golang", Title: "Go 1.24", Content: "released"})
+	eventually(t, "the post", func() bool {
+		_, err := as.livePost(ctx, 1, "")
+		return err == nil
+	})
+	as.AddComment(ctx, CommentMessage{UserID: 3, PostID: 1, Content: "nice"})
+	comment := func() *Comment {
+		result, err := as.requestFuture(ctx, as.PostActor, &GetComment{CommentID: 1}, as.RequestTimeout).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		found, _ := result.(*Comment)
+		return found
+	}
+	eventually(t, "the comment", func() bool { return comment() != nil })
+
+	// A clean edit goes through
+	as.EditComment(ctx, EditComment{UserID: 3, CommentID: 1, Content: "very nice"})
+	eventually(t, "the clean edit", func() bool { return comment().Content == "very nice" })
+	if found := comment(); found.Filtered || found.Deleted {
+		t.Fatalf("clean edit was held: %+v", found)
+	}
+
+	as.EditComment(ctx, EditComment{UserID: 3, CommentID: 1, Content: "heard a rumour"})
+	eventually(t, "the comment edit to be held", func() bool { return comment().Filtered })
+
+	as.EditPost(ctx, EditPost{UserID: 2, PostID: 1, Content: "now with a casino link"})
+	eventually(t, "the post edit to be removed", func() bool {
+		_, err := as.livePost(ctx, 1, "")
+		return errors.Is(err, ErrNoSuchPost)
+	})
+	if results, err := as.Search(ctx, Search{Query: ParseSearchQuery("casino")}); err != nil || results.Total != 0 {
+		t.Fatalf("search finds the removed edit: %+v, %v", results, err)
+	}
+}
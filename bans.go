@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrBanned     = errors.New("user is banned from the subreddit")
+	ErrInvalidBan = errors.New("invalid ban")
+)
+
+// BanUser bars a user from posting, crossposting and joining a subreddit on behalf of one
+// of its moderators. Moderators can't be banned.
+func (as *ActorSystem) BanUser(ctx context.Context, msg BanUser) error {
+	subreddit, err := as.moderatedSubreddit(ctx, msg.Subreddit, msg.UserID, msg.RequestID)
+	if err != nil {
+		return err
+	}
+	if subreddit.Moderators[msg.BannedID] {
+		return fmt.Errorf("%w: user %d moderates r/%s", ErrInvalidBan, msg.BannedID, msg.Subreddit)
+	}
+	as.send(ctx, as.SubredditActor, &msg)
+	return nil
+}
+
+// UnbanUser lifts a ban on behalf of one of the subreddit's moderators.
+func (as *ActorSystem) UnbanUser(ctx context.Context, msg UnbanUser) error {
+	if _, err := as.moderatedSubreddit(ctx, msg.Subreddit, msg.UserID, msg.RequestID); err != nil {
+		return err
+	}
+	as.send(ctx, as.SubredditActor, &msg)
+	return nil
+}
+
+// CheckPoster fails with ErrBanned when userID is banned from the subreddit a post is for.
+// Posts may still go to a subreddit nobody created.
+func (as *ActorSystem) CheckPoster(ctx context.Context, subreddit string, userID int, requestID string) error {
+	found, err := as.subreddit(ctx, subreddit, requestID)
+	switch {
+	case errors.Is(err, ErrNoSuchSubreddit):
+		return nil
+	case err != nil:
+		return err
+	case found.Banned[userID]:
+		return fmt.Errorf("%w: r/%s", ErrBanned, subreddit)
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+var ErrNoSuchBlob = errors.New("blob does not exist")
+
+// BlobStore keeps the bytes of uploaded media under keys the engine picks. Other backends,
+// such as an object store, only need to implement these four methods.
+type BlobStore interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error // deleting a missing blob is not an error
+	List(ctx context.Context) ([]BlobInfo, error)
+}
+
+type BlobInfo struct {
+	Key     string
+	Size    int64
+	ModTime time.Time
+}
+
+// blobKeys matches the keys the engine generates, so a key can never name a path outside the store
+var blobKeys = regexp.MustCompile(`^[0-9a-f]{1,64}(_[a-z]+)?$`)
+
+// FileBlobStore keeps each blob in a file named after its key
+type FileBlobStore struct {
+	dir string
+}
+
+func NewFileBlobStore(dir string) (*FileBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileBlobStore{dir: dir}, nil
+}
+
+// Put writes the blob to a temporary file first, so a failed upload never leaves half a blob.
+func (f *FileBlobStore) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(f.dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (f *FileBlobStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := f.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNoSuchBlob
+	}
+	return file, err
+}
+
+func (f *FileBlobStore) Delete(ctx context.Context, key string) error {
+	path, err := f.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List skips files that aren't blobs, such as uploads still being written
+func (f *FileBlobStore) List(ctx context.Context) ([]BlobInfo, error) {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, err
+	}
+	var blobs []BlobInfo
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !blobKeys.MatchString(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue // removed since ReadDir
+		}
+		blobs = append(blobs, BlobInfo{Key: entry.Name(), Size: info.Size(), ModTime: info.ModTime()})
+	}
+	return blobs, nil
+}
+
+func (f *FileBlobStore) path(key string) (string, error) {
+	if !blobKeys.MatchString(key) {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(f.dir, key), nil
+}
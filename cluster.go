@@ -0,0 +1,511 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reddit_clone2/enginepb"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/cluster/identitylookup/disthash"
+	"github.com/asynkron/protoactor-go/remote"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Cluster grain kinds. A subreddit grain is identified by the subreddit name, a post grain by
+// "<subreddit>/<post id>", so grains spread over the members by hashing those identities. The
+// one sequence grain hands out post IDs, which are unique across the cluster.
+const (
+	GrainSubreddit = "subreddit"
+	GrainPost      = "post"
+	GrainSequence  = "sequence"
+)
+
+// postSequence identifies the sequence grain
+const postSequence = "posts"
+
+var (
+	ErrSubredditExists = errors.New("subreddit already exists")
+	ErrNoSuchPost      = errors.New("post does not exist")
+	ErrPostExists      = errors.New("post already exists")
+)
+
+// ClusterOptions configures one cluster member
+type ClusterOptions struct {
+	Name           string // members only see others started with the same name
+	Addr           string // "host:port" the member listens on; port 0 picks a free one
+	Provider       cluster.ClusterProvider
+	Store          Store // where grains keep their state; nil keeps it in a MemoryStore of this member's own
+	RequestTimeout time.Duration
+	Logger         *slog.Logger
+}
+
+// ClusterNode is one member of an engine cluster. Requests may go through any member; the
+// identity lookup forwards them to the member that owns the grain, activating it if needed.
+//
+// The cluster runs a small part of the engine: creating, joining, leaving and reading
+// subreddits, creating and reading posts, commenting on posts and counting votes on them.
+// Users, moderation, edits and deletes, feeds, search, notifications, media, webhooks and the
+// REST and gRPC APIs are features of the single-process ActorSystem only.
+type ClusterNode struct {
+	cluster        *cluster.Cluster
+	logger         *slog.Logger
+	stopOnce       sync.Once
+	RequestTimeout time.Duration
+}
+
+// StartClusterNode starts a member hosting subreddit and post grains and joins it to the
+// cluster through opts.Provider.
+func StartClusterNode(opts ClusterOptions) (node *ClusterNode, err error) {
+	host, port, err := splitHostPort(opts.Addr)
+	if err != nil {
+		return nil, err
+	}
+	logger := opts.Logger.With("component", "cluster")
+	store := opts.Store
+	if store == nil {
+		store = NewMemoryStore()
+	}
+	subredditProps := actor.PropsFromProducer(func() actor.Actor {
+		return &subredditGrain{store: store, logger: logger.With("grain", GrainSubreddit)}
+	}, tracingMiddleware("SubredditGrain")...)
+	postProps := actor.PropsFromProducer(func() actor.Actor {
+		return &postGrain{store: store, logger: logger.With("grain", GrainPost)}
+	}, tracingMiddleware("PostGrain")...)
+	sequenceProps := actor.PropsFromProducer(func() actor.Actor {
+		return &sequenceGrain{store: store, logger: logger.With("grain", GrainSequence)}
+	}, tracingMiddleware("SequenceGrain")...)
+
+	config := cluster.Configure(opts.Name, opts.Provider, disthash.New(), remote.Configure(host, port),
+		cluster.WithKinds(cluster.NewKind(GrainSubreddit, subredditProps), cluster.NewKind(GrainPost, postProps), cluster.NewKind(GrainSequence, sequenceProps)),
+		cluster.WithRequestTimeout(opts.RequestTimeout))
+	c := cluster.New(newProtoActorSystem(opts.Logger), config)
+
+	// StartMember panics when it can't listen or join
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("starting cluster member on %s: %v", opts.Addr, p)
+		}
+	}()
+	c.StartMember()
+	logger.Info("cluster member started", "cluster", opts.Name, "addr", c.ActorSystem.Address(), "id", c.ActorSystem.ID)
+	return &ClusterNode{cluster: c, logger: logger, RequestTimeout: opts.RequestTimeout}, nil
+}
+
+// Address is where this member listens for other members
+func (n *ClusterNode) Address() string {
+	return n.cluster.ActorSystem.Address()
+}
+
+// Members counts the members this node currently sees, itself included
+func (n *ClusterNode) Members() int {
+	return n.cluster.MemberList.Length()
+}
+
+// Shutdown leaves the cluster; later calls do nothing. Grains hosted here pick up their state
+// from the Store when next activated on another member, provided the members share it.
+func (n *ClusterNode) Shutdown(graceful bool) {
+	n.stopOnce.Do(func() { n.cluster.Shutdown(graceful) })
+}
+
+func (n *ClusterNode) CreateSubreddit(ctx context.Context, msg CreateSubreddit) error {
+	_, err := n.write(ctx, msg.Name, GrainSubreddit, &enginepb.CreateSubreddit{Name: msg.Name, CreatorId: int64(msg.CreatorID), RequestId: msg.RequestID})
+	return err
+}
+
+func (n *ClusterNode) JoinSubreddit(ctx context.Context, msg JoinSubreddit) error {
+	_, err := n.write(ctx, msg.Name, GrainSubreddit, &enginepb.JoinSubreddit{UserId: int64(msg.UserID), Name: msg.Name, RequestId: msg.RequestID})
+	return err
+}
+
+func (n *ClusterNode) LeaveSubreddit(ctx context.Context, msg LeaveSubreddit) error {
+	_, err := n.write(ctx, msg.Name, GrainSubreddit, &enginepb.LeaveSubreddit{UserId: int64(msg.UserID), Name: msg.Name, RequestId: msg.RequestID})
+	return err
+}
+
+// CreatePost takes a post ID from the sequence grain and lists it in the subreddit grain, which
+// turns away links it already has, then activates the post grain with it. An ID the subreddit
+// turns away is not used again.
+func (n *ClusterNode) CreatePost(ctx context.Context, msg PostMessage) (int, error) {
+	if err := ValidatePost(&msg); err != nil {
+		return 0, err
+	}
+	postID, err := n.write(ctx, postSequence, GrainSequence, &enginepb.NextPostID{RequestId: msg.RequestID})
+	if err != nil {
+		return 0, err
+	}
+	if _, err := n.write(ctx, msg.Subreddit, GrainSubreddit, &enginepb.AddPost{PostId: int64(postID), Kind: msg.Kind, Url: msg.URL, RequestId: msg.RequestID}); err != nil {
+		return 0, err
+	}
+	_, err = n.write(ctx, postIdentity(msg.Subreddit, postID), GrainPost, &enginepb.InitPost{UserId: int64(msg.UserID), Title: msg.Title, Kind: msg.Kind, Url: msg.URL, Content: msg.Content, RequestId: msg.RequestID})
+	return postID, err
+}
+
+// AddComment comments on post msg.PostID of subreddit and returns the comment ID
+func (n *ClusterNode) AddComment(ctx context.Context, subreddit string, msg CommentMessage) (int, error) {
+	return n.write(ctx, postIdentity(subreddit, msg.PostID), GrainPost, &enginepb.CommentMessage{UserId: int64(msg.UserID), PostId: int64(msg.PostID), ParentId: int64(msg.ParentID), Content: msg.Content, RequestId: msg.RequestID})
+}
+
+// VotePost votes on post msg.ID of subreddit
+func (n *ClusterNode) VotePost(ctx context.Context, subreddit string, msg Vote) error {
+	_, err := n.write(ctx, postIdentity(subreddit, msg.ID), GrainPost, &enginepb.Vote{UserId: int64(msg.UserID), Target: msg.Target, Id: int64(msg.ID), Type: msg.Type, RequestId: msg.RequestID})
+	return err
+}
+
+// GetSubreddit fetches a subreddit; the bool is false when it does not exist.
+func (n *ClusterNode) GetSubreddit(ctx context.Context, msg GetSubreddit) (*Subreddit, bool, error) {
+	res, err := n.request(ctx, msg.Name, GrainSubreddit, &enginepb.GetSubreddit{RequestId: msg.RequestID})
+	if err != nil {
+		return nil, false, err
+	}
+	result, ok := res.(*enginepb.SubredditResult)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected subreddit response %T", res)
+	}
+	if !result.Found {
+		return nil, false, nil
+	}
+	return subredditFromProto(result.Subreddit), true, nil
+}
+
+// GetPost fetches post postID of subreddit with its comments; the bool is false when it does not exist.
+func (n *ClusterNode) GetPost(ctx context.Context, subreddit string, postID int) (*Post, bool, error) {
+	res, err := n.request(ctx, postIdentity(subreddit, postID), GrainPost, &enginepb.GetPost{})
+	if err != nil {
+		return nil, false, err
+	}
+	result, ok := res.(*enginepb.PostResult)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected post response %T", res)
+	}
+	if !result.Found {
+		return nil, false, nil
+	}
+	return postFromProto(result.Post), true, nil
+}
+
+// request sends msg to the grain, waiting no longer than RequestTimeout or ctx's deadline
+func (n *ClusterNode) request(ctx context.Context, identity, kind string, msg proto.Message) (interface{}, error) {
+	timeout := n.RequestTimeout
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	res, err := n.cluster.Request(identity, kind, msg, cluster.WithTimeout(timeout))
+	if err != nil {
+		return nil, fmt.Errorf("%s %q: %w", kind, identity, err)
+	}
+	return res, nil
+}
+
+// write sends a grain write and turns its GrainResult into the created ID or an error
+func (n *ClusterNode) write(ctx context.Context, identity, kind string, msg proto.Message) (int, error) {
+	res, err := n.request(ctx, identity, kind, msg)
+	if err != nil {
+		return 0, err
+	}
+	result, ok := res.(*enginepb.GrainResult)
+	if !ok {
+		return 0, fmt.Errorf("unexpected %s response %T", kind, res)
+	}
+	if result.Error != "" {
+		return 0, grainError(result.Error)
+	}
+	return int(result.Id), nil
+}
+
+// grainError restores the sentinel errors grains can only send as text
+func grainError(text string) error {
+	for _, err := range []error{ErrNoSuchSubreddit, ErrSubredditExists, ErrNoSuchPost, ErrPostExists, ErrDuplicateLink} {
+		if err.Error() == text {
+			return err
+		}
+	}
+	return errors.New(text)
+}
+
+func postIdentity(subreddit string, postID int) string {
+	return subreddit + "/" + strconv.Itoa(postID)
+}
+
+// subredditGrain owns one subreddit. It exists as soon as it is activated, but only holds a
+// subreddit once CreateSubreddit reached it. It loads the subreddit from the store on
+// activation and saves it on every change, so it carries on wherever it is activated next.
+type subredditGrain struct {
+	name      string
+	subreddit *Subreddit
+	links     map[string]int // post ID by URL, for link posts
+	store     Store
+	logger    *slog.Logger
+}
+
+// load picks up the subreddit where an earlier activation, on this or another member, left it
+func (g *subredditGrain) load() {
+	g.subreddit = g.store.Subreddit(g.name)
+	g.links = make(map[string]int)
+	if g.subreddit == nil {
+		return
+	}
+	for _, id := range g.subreddit.Posts {
+		if post := g.store.Post(id); post != nil && post.Kind == PostLink {
+			g.links[post.URL] = id
+		}
+	}
+}
+
+func (g *subredditGrain) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *cluster.ClusterInit:
+		g.name = msg.Identity.Identity
+		g.load()
+
+	case *enginepb.CreateSubreddit:
+		if g.subreddit != nil {
+			ctx.Respond(&enginepb.GrainResult{Error: ErrSubredditExists.Error()})
+			return
+		}
+		now := time.Now()
+		g.subreddit = &Subreddit{Name: g.name, Members: make(map[int]bool), Moderators: make(map[int]bool), CreatedAt: now, LastActivity: now}
+		if msg.CreatorId != 0 {
+			g.subreddit.Members[int(msg.CreatorId)] = true
+			g.subreddit.Moderators[int(msg.CreatorId)] = true
+		}
+		g.store.SaveSubreddit(g.subreddit)
+		g.logger.Info("subreddit created", "request_id", msg.RequestId, "subreddit", g.name, "node", ctx.ActorSystem().Address())
+		ctx.Respond(&enginepb.GrainResult{})
+
+	case *enginepb.JoinSubreddit:
+		if g.subreddit == nil {
+			ctx.Respond(&enginepb.GrainResult{Error: ErrNoSuchSubreddit.Error()})
+			return
+		}
+		g.subreddit.Members[int(msg.UserId)] = true
+		g.store.SaveSubreddit(g.subreddit)
+		g.logger.Info("user joined subreddit", "request_id", msg.RequestId, "subreddit", g.name, "user_id", msg.UserId)
+		ctx.Respond(&enginepb.GrainResult{})
+
+	case *enginepb.LeaveSubreddit:
+		if g.subreddit == nil {
+			ctx.Respond(&enginepb.GrainResult{Error: ErrNoSuchSubreddit.Error()})
+			return
+		}
+		delete(g.subreddit.Members, int(msg.UserId))
+		g.store.SaveSubreddit(g.subreddit)
+		g.logger.Info("user left subreddit", "request_id", msg.RequestId, "subreddit", g.name, "user_id", msg.UserId)
+		ctx.Respond(&enginepb.GrainResult{})
+
+	case *enginepb.AddPost:
+		if g.subreddit == nil {
+			ctx.Respond(&enginepb.GrainResult{Error: ErrNoSuchSubreddit.Error()})
+			return
+		}
+		if msg.Kind == PostLink {
+			if g.links[msg.Url] != 0 {
+				ctx.Respond(&enginepb.GrainResult{Error: ErrDuplicateLink.Error()})
+				return
+			}
+			g.links[msg.Url] = int(msg.PostId)
+		}
+		g.subreddit.Posts = append(g.subreddit.Posts, int(msg.PostId))
+		g.subreddit.LastActivity = time.Now()
+		g.store.SaveSubreddit(g.subreddit)
+		ctx.Respond(&enginepb.GrainResult{Id: msg.PostId})
+
+	case *enginepb.GetSubreddit:
+		if g.subreddit == nil {
+			ctx.Respond(&enginepb.SubredditResult{})
+			return
+		}
+		ctx.Respond(&enginepb.SubredditResult{Subreddit: subredditToProto(g.subreddit), Found: true})
+	}
+}
+
+// sequenceGrain hands out post IDs. It saves the last one before answering, so an activation
+// on another member carries on after it instead of handing an ID out twice.
+type sequenceGrain struct {
+	last   int
+	store  Store
+	logger *slog.Logger
+}
+
+func (g *sequenceGrain) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *cluster.ClusterInit:
+		// A store the single-process engine wrote has posts but no sequence
+		g.last = g.store.PostSequence()
+		for id := range g.store.Posts() {
+			g.last = max(g.last, id)
+		}
+		g.logger.Info("post sequence activated", "last_post_id", g.last, "node", ctx.ActorSystem().Address())
+
+	case *enginepb.NextPostID:
+		g.last++
+		g.store.SavePostSequence(g.last)
+		g.logger.Debug("post ID handed out", "request_id", msg.RequestId, "post_id", g.last)
+		ctx.Respond(&enginepb.GrainResult{Id: int64(g.last)})
+	}
+}
+
+// postGrain owns one post and its comments, loading them from the store on activation and
+// saving them on every change
+type postGrain struct {
+	subreddit string
+	id        int
+	post      *Post
+	store     Store
+	logger    *slog.Logger
+}
+
+func (g *postGrain) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *cluster.ClusterInit:
+		// The identity was built by postIdentity
+		subreddit, id, _ := strings.Cut(msg.Identity.Identity, "/")
+		postID, err := strconv.Atoi(id)
+		if err != nil || postID <= 0 {
+			g.logger.Error("invalid post grain identity", "identity", msg.Identity.Identity)
+			return
+		}
+		g.subreddit, g.id = subreddit, postID
+		if post := g.store.Post(postID); post != nil && post.Subreddit == subreddit {
+			g.post = post
+		}
+
+	case *enginepb.InitPost:
+		switch {
+		case g.id == 0:
+			ctx.Respond(&enginepb.GrainResult{Error: ErrNoSuchPost.Error()})
+			return
+		case g.post != nil:
+			ctx.Respond(&enginepb.GrainResult{Error: ErrPostExists.Error()})
+			return
+		}
+		g.post = &Post{ID: g.id, UserID: int(msg.UserId), Subreddit: g.subreddit, Title: msg.Title, Kind: msg.Kind, URL: msg.Url, Content: msg.Content, ContentHTML: RenderMarkdown(msg.Content)}
+		if msg.Url != "" {
+			g.post.Domain = domainOf(msg.Url)
+		}
+		g.store.SavePost(g.post)
+		g.logger.Info("post created", "request_id", msg.RequestId, "subreddit", g.subreddit, "post_id", g.id, "user_id", msg.UserId, "node", ctx.ActorSystem().Address())
+		ctx.Respond(&enginepb.GrainResult{Id: int64(g.id)})
+
+	case *enginepb.CommentMessage:
+		if g.post == nil || g.post.Deleted {
+			ctx.Respond(&enginepb.GrainResult{Error: ErrNoSuchPost.Error()})
+			return
+		}
+		comment := &Comment{
+			ID:          len(g.post.Comments) + 1,
+			PostID:      g.id,
+			ParentID:    int(msg.ParentId),
+			UserID:      int(msg.UserId),
+			Content:     msg.Content,
+			ContentHTML: RenderMarkdown(msg.Content),
+		}
+		g.post.Comments = append(g.post.Comments, comment)
+		g.store.SavePost(g.post)
+		g.logger.Info("comment added", "request_id", msg.RequestId, "subreddit", g.subreddit, "post_id", g.id, "comment_id", comment.ID, "user_id", msg.UserId)
+		ctx.Respond(&enginepb.GrainResult{Id: int64(comment.ID)})
+
+	case *enginepb.Vote:
+		if g.post == nil || g.post.Deleted {
+			ctx.Respond(&enginepb.GrainResult{Error: ErrNoSuchPost.Error()})
+			return
+		}
+		switch msg.Type {
+		case "upvote":
+			g.post.Upvotes++
+		case "downvote":
+			g.post.Downvotes++
+		}
+		g.store.SavePost(g.post)
+		g.logger.Debug("post voted", "request_id", msg.RequestId, "subreddit", g.subreddit, "post_id", g.id, "vote", msg.Type, "user_id", msg.UserId)
+		ctx.Respond(&enginepb.GrainResult{Id: int64(g.id)})
+
+	case *enginepb.GetPost:
+		if g.post == nil {
+			ctx.Respond(&enginepb.PostResult{})
+			return
+		}
+		ctx.Respond(&enginepb.PostResult{Post: postToProto(g.post), Found: true})
+	}
+}
+
+func subredditToProto(subreddit *Subreddit) *enginepb.Subreddit {
+	out := &enginepb.Subreddit{
+		Name:         subreddit.Name,
+		CreatedAt:    timestamppb.New(subreddit.CreatedAt),
+		LastActivity: timestamppb.New(subreddit.LastActivity),
+	}
+	for id := range subreddit.Members {
+		out.Members = append(out.Members, int64(id))
+	}
+	sort.Slice(out.Members, func(i, j int) bool { return out.Members[i] < out.Members[j] })
+	for _, id := range subreddit.Posts {
+		out.Posts = append(out.Posts, int64(id))
+	}
+	return out
+}
+
+func subredditFromProto(subreddit *enginepb.Subreddit) *Subreddit {
+	out := &Subreddit{
+		Name:         subreddit.Name,
+		Members:      make(map[int]bool, len(subreddit.Members)),
+		CreatedAt:    subreddit.CreatedAt.AsTime(),
+		LastActivity: subreddit.LastActivity.AsTime(),
+	}
+	for _, id := range subreddit.Members {
+		out.Members[int(id)] = true
+	}
+	for _, id := range subreddit.Posts {
+		out.Posts = append(out.Posts, int(id))
+	}
+	return out
+}
+
+func postToProto(post *Post) *enginepb.Post {
+	out := &enginepb.Post{
+		Id:        int64(post.ID),
+		UserId:    int64(post.UserID),
+		Subreddit: post.Subreddit,
+		Title:     post.Title,
+		Kind:      post.Kind,
+		Url:       post.URL,
+		Domain:    post.Domain,
+		Content:   post.Content,
+		Upvotes:   int64(post.Upvotes),
+		Downvotes: int64(post.Downvotes),
+	}
+	for _, comment := range post.Comments {
+		out.Comments = append(out.Comments, &enginepb.Comment{Id: int64(comment.ID), ParentId: int64(comment.ParentID), UserId: int64(comment.UserID), Content: comment.Content})
+	}
+	return out
+}
+
+func postFromProto(post *enginepb.Post) *Post {
+	out := &Post{
+		ID:        int(post.Id),
+		UserID:    int(post.UserId),
+		Subreddit: post.Subreddit,
+		Title:     post.Title,
+		Kind:      post.Kind,
+		URL:       post.Url,
+		Domain:    post.Domain,
+		Content:   post.Content,
+		Upvotes:   int(post.Upvotes),
+		Downvotes: int(post.Downvotes),
+	}
+	for _, comment := range post.Comments {
+		out.Comments = append(out.Comments, &Comment{ID: int(comment.Id), PostID: out.ID, ParentID: int(comment.ParentId), UserID: int(comment.UserId), Content: comment.Content})
+	}
+	return out
+}
@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"reddit_clone2/enginepb"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/cluster"
+)
+
+// startTestCluster starts three members on localhost, each with the provider newProvider
+// returns and sharing store, and waits until every member sees the others.
+func startTestCluster(t *testing.T, newProvider func() cluster.ClusterProvider, store Store) []*ClusterNode {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	var nodes []*ClusterNode
+	t.Cleanup(func() {
+		var wg sync.WaitGroup
+		for _, node := range nodes {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				node.Shutdown(false)
+			}()
+		}
+		wg.Wait()
+	})
+	for range 3 {
+		node, err := StartClusterNode(ClusterOptions{Name: "test", Addr: "127.0.0.1:0", Provider: newProvider(), Store: store, RequestTimeout: 5 * time.Second, Logger: logger})
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes = append(nodes, node)
+	}
+	eventually(t, "the members to see each other", func() bool {
+		for _, node := range nodes {
+			if node.Members() != 3 {
+				return false
+			}
+		}
+		return true
+	})
+	return nodes
+}
+
+// subredditOn returns a subreddit name whose grain the cluster places on node
+func subredditOn(t *testing.T, nodes []*ClusterNode, node *ClusterNode) string {
+	t.Helper()
+	for i := range 1000 {
+		name := fmt.Sprintf("sub%d", i)
+		if pid := nodes[0].cluster.Get(name, GrainSubreddit); pid != nil && pid.Address == node.Address() {
+			return name
+		}
+	}
+	t.Fatalf("no subreddit grain lands on %s", node.Address())
+	return ""
+}
+
+func TestClusterRoutesToGrainsOnEveryMember(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts three cluster members")
+	}
+	providers := map[string]func(t *testing.T) func() cluster.ClusterProvider{
+		"in-process": func(*testing.T) func() cluster.ClusterProvider {
+			return NewInProcessMembership().Provider
+		},
+		"directory": func(t *testing.T) func() cluster.ClusterProvider {
+			dir := t.TempDir()
+			return func() cluster.ClusterProvider {
+				provider := NewDirProvider(dir)
+				provider.Refresh = 50 * time.Millisecond
+				return provider
+			}
+		},
+	}
+	for name, newProvider := range providers {
+		t.Run(name, func(t *testing.T) {
+			nodes := startTestCluster(t, newProvider(t), NewMemoryStore())
+			ctx := context.Background()
+
+			for owner, node := range nodes {
+				subreddit := subredditOn(t, nodes, node)
+				// Write through every member, so two of the three requests are forwarded
+				if err := nodes[(owner+1)%3].CreateSubreddit(ctx, CreateSubreddit{Name: subreddit, CreatorID: 1}); err != nil {
+					t.Fatalf("creating r/%s hosted on member %d: %v", subreddit, owner, err)
+				}
+				if err := nodes[(owner+2)%3].JoinSubreddit(ctx, JoinSubreddit{UserID: 2, Name: subreddit}); err != nil {
+					t.Fatalf("joining r/%s hosted on member %d: %v", subreddit, owner, err)
+				}
+				postID, err := node.CreatePost(ctx, PostMessage{UserID: 2, Subreddit: subreddit, Title: "hello"})
+				if err != nil {
+					t.Fatalf("posting to r/%s hosted on member %d: %v", subreddit, owner, err)
+				}
+				if _, err := nodes[(owner+1)%3].AddComment(ctx, subreddit, CommentMessage{UserID: 1, PostID: postID, Content: "hi"}); err != nil {
+					t.Fatalf("commenting in r/%s: %v", subreddit, err)
+				}
+				if err := nodes[(owner+2)%3].VotePost(ctx, subreddit, Vote{UserID: 1, Target: "post", ID: postID, Type: "upvote"}); err != nil {
+					t.Fatalf("voting in r/%s: %v", subreddit, err)
+				}
+
+				// Every member reads back the same state
+				for reader, via := range nodes {
+					found, ok, err := via.GetSubreddit(ctx, GetSubreddit{Name: subreddit})
+					if err != nil || !ok || !found.Members[1] || !found.Members[2] {
+						t.Fatalf("member %d read r/%s as %+v, %v, %v", reader, subreddit, found, ok, err)
+					}
+					post, ok, err := via.GetPost(ctx, subreddit, postID)
+					if err != nil || !ok || post.Title != "hello" || len(post.Comments) != 1 || post.Upvotes != 1 {
+						t.Fatalf("member %d read post %d of r/%s as %+v, %v, %v", reader, postID, subreddit, post, ok, err)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestClusterGrainsMoveWhenAMemberLeaves(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts three cluster members")
+	}
+	nodes := startTestCluster(t, NewInProcessMembership().Provider, NewMemoryStore())
+	ctx := context.Background()
+	leaving := nodes[2]
+	subreddit := subredditOn(t, nodes, leaving)
+	if err := nodes[0].CreateSubreddit(ctx, CreateSubreddit{Name: subreddit, CreatorID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodes[0].JoinSubreddit(ctx, JoinSubreddit{UserID: 2, Name: subreddit}); err != nil {
+		t.Fatal(err)
+	}
+	// Post until one of the posts is hosted on the leaving member too
+	var postID int
+	for postID == 0 || nodes[0].cluster.Get(postIdentity(subreddit, postID), GrainPost).Address != leaving.Address() {
+		id, err := nodes[0].CreatePost(ctx, PostMessage{UserID: 2, Subreddit: subreddit, Title: "hello", Kind: PostLink, URL: fmt.Sprintf("https://example.com/%d", postID)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		postID = id
+	}
+	if _, err := nodes[0].AddComment(ctx, subreddit, CommentMessage{UserID: 1, PostID: postID, Content: "hi"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := nodes[0].VotePost(ctx, subreddit, Vote{UserID: 1, Target: "post", ID: postID, Type: "upvote"}); err != nil {
+		t.Fatal(err)
+	}
+
+	leaving.Shutdown(true)
+	eventually(t, "the remaining members to drop the one that left", func() bool {
+		return nodes[0].Members() == 2 && nodes[1].Members() == 2
+	})
+	// The grains come back on the remaining members with the state they saved
+	eventually(t, "the subreddit and post to be activated elsewhere", func() bool {
+		ctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer cancel()
+		found, ok, err := nodes[1].GetSubreddit(ctx, GetSubreddit{Name: subreddit})
+		if err != nil || !ok || !found.Members[2] || len(found.Posts) != postID {
+			return false
+		}
+		post, ok, err := nodes[1].GetPost(ctx, subreddit, postID)
+		return err == nil && ok && post.Title == "hello" && len(post.Comments) == 1 && post.Upvotes == 1
+	})
+
+	// The subreddit still knows its links, and post IDs carry on past the one the duplicate took
+	if _, err := nodes[0].CreatePost(ctx, PostMessage{UserID: 2, Subreddit: subreddit, Title: "again", Kind: PostLink, URL: "https://example.com/0"}); !errors.Is(err, ErrDuplicateLink) {
+		t.Errorf("reposting a link after the move: %v, want ErrDuplicateLink", err)
+	}
+	next, err := nodes[0].CreatePost(ctx, PostMessage{UserID: 2, Subreddit: subreddit, Title: "next"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next <= postID+1 {
+		t.Errorf("post created after the move got ID %d, want one after %d", next, postID+1)
+	}
+	if _, err := nodes[1].write(ctx, postIdentity(subreddit, postID), GrainPost, &enginepb.InitPost{UserId: 3, Title: "overwrite"}); !errors.Is(err, ErrPostExists) {
+		t.Errorf("initialising an existing post: %v, want ErrPostExists", err)
+	}
+}
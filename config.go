@@ -0,0 +1,610 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config holds every tunable of the server, the engine and the simulator.
+// Values are resolved in order: defaults, config file, REDDIT_* environment variables, flags.
+type Config struct {
+	HTTP      HTTPConfig      `json:"http"`
+	GRPC      GRPCConfig      `json:"grpc"`
+	Remote    RemoteConfig    `json:"remote"`
+	Cluster   ClusterConfig   `json:"cluster"`
+	Engine    EngineConfig    `json:"engine"`
+	RateLimit RateLimitConfig `json:"rate_limit"`
+	Storage   StorageConfig   `json:"storage"`
+	Webhooks  WebhookConfig   `json:"webhooks"`
+	Media     MediaConfig     `json:"media"`
+	Spam      SpamConfig      `json:"spam"`
+	Votes     VoteConfig      `json:"votes"`
+	Log       LogConfig       `json:"log"`
+	Tracing   TracingConfig   `json:"tracing"`
+	Simulator SimulatorConfig `json:"simulator"`
+	Headless  bool            `json:"headless"`
+
+	// Adjusted lists the defaults Load changed to fit the other settings, for the caller to log
+	Adjusted []string `json:"-"`
+}
+
+type HTTPConfig struct {
+	Addr            string   `json:"addr"`
+	ShutdownTimeout Duration `json:"shutdown_timeout"`
+	MaxBodyBytes    int64    `json:"max_body_bytes"`
+	StreamBuffer    int      `json:"stream_buffer"`
+	StreamHeartbeat Duration `json:"stream_heartbeat"`
+}
+
+type GRPCConfig struct {
+	Addr string `json:"addr"` // empty disables the gRPC server
+}
+
+// RemoteConfig serves the engine actors over protoactor remote. With Engine set the process runs
+// only the simulator, driving the engine at that address; Addr is then the simulator's own node.
+type RemoteConfig struct {
+	Addr   string `json:"addr"`   // empty disables remoting
+	Engine string `json:"engine"` // engine node to connect to instead of running one
+}
+
+// ClusterConfig runs this process as members of an engine cluster instead of a single engine.
+// Members find each other in memory, or through Dir when processes share it.
+type ClusterConfig struct {
+	Nodes int    `json:"nodes"` // members to start in this process; 0 disables cluster mode
+	Name  string `json:"name"`
+	Addr  string `json:"addr"` // first member's address; the others take the following ports
+	Dir   string `json:"dir"`  // membership directory shared by the cluster's processes
+}
+
+type EngineConfig struct {
+	RequestTimeout   Duration `json:"request_timeout"`
+	MaxContentLength int      `json:"max_content_length"`
+	Admins           string   `json:"admins"` // comma separated IDs of the users who may read admin reports
+}
+
+// AdminIDs parses Admins, skipping entries that are not user IDs; Validate reports those.
+func (e EngineConfig) AdminIDs() []int {
+	var ids []int
+	for _, field := range strings.Split(e.Admins, ",") {
+		if id, err := strconv.Atoi(strings.TrimSpace(field)); err == nil && id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// RateLimitConfig is expressed as requests per minute with a burst allowance. Accounts younger
+// than NewAccountAge or with less karma than LowKarma get limits and burst divided by RestrictedDivisor.
+type RateLimitConfig struct {
+	Enabled             bool     `json:"enabled"`
+	PostsPerMinute      int      `json:"posts_per_minute"`
+	CommentsPerMinute   int      `json:"comments_per_minute"`
+	VotesPerMinute      int      `json:"votes_per_minute"`
+	ReportsPerMinute    int      `json:"reports_per_minute"`
+	UploadsPerMinute    int      `json:"uploads_per_minute"`
+	SubredditsPerMinute int      `json:"subreddits_per_minute"`
+	Burst               int      `json:"burst"`
+	NewAccountAge       Duration `json:"new_account_age"`
+	LowKarma            int      `json:"low_karma"`
+	RestrictedDivisor   int      `json:"restricted_divisor"`
+}
+
+// PerMinute returns the limit for "post", "comment", "vote", "report", "upload" or "subreddit",
+// tightened for restricted accounts.
+func (r RateLimitConfig) PerMinute(action string, restricted bool) int {
+	var limit int
+	switch action {
+	case "post":
+		limit = r.PostsPerMinute
+	case "comment":
+		limit = r.CommentsPerMinute
+	case "vote":
+		limit = r.VotesPerMinute
+	case "report":
+		limit = r.ReportsPerMinute
+	case "upload":
+		limit = r.UploadsPerMinute
+	case "subreddit":
+		limit = r.SubredditsPerMinute
+	}
+	if restricted && r.RestrictedDivisor > 1 {
+		limit /= r.RestrictedDivisor
+	}
+	return max(limit, 1)
+}
+
+// BurstFor returns the burst allowance, tightened for restricted accounts.
+func (r RateLimitConfig) BurstFor(restricted bool) int {
+	if restricted && r.RestrictedDivisor > 1 {
+		return r.Burst / r.RestrictedDivisor
+	}
+	return r.Burst
+}
+
+type StorageConfig struct {
+	Backend       string   `json:"backend"`        // "memory" or "file"
+	Path          string   `json:"path"`           // JSON state file used by the file backend
+	FlushInterval Duration `json:"flush_interval"` // how often the file is written while changes are pending
+	FlushAfter    int      `json:"flush_after"`    // write the file as soon as this many changes are pending
+}
+
+// WebhookConfig controls outbound webhook delivery. A failed delivery is retried up to
+// MaxAttempts times, waiting Backoff, then twice as long, and so on. An endpoint is disabled
+// after DisableAfter deliveries in a row have failed. Up to QueueSize deliveries per endpoint
+// wait while an earlier one is in flight; further ones are dropped.
+type WebhookConfig struct {
+	Timeout      Duration `json:"timeout"`
+	MaxAttempts  int      `json:"max_attempts"`
+	Backoff      Duration `json:"backoff"`
+	DisableAfter int      `json:"disable_after"`
+	QueueSize    int      `json:"queue_size"`
+}
+
+// MediaConfig controls uploads, which are kept as files in Dir. Uploads no post refers to
+// are deleted every GCInterval once they are older than GCGrace.
+type MediaConfig struct {
+	Dir           string   `json:"dir"`
+	MaxBytes      int64    `json:"max_bytes"`
+	ThumbnailSize int      `json:"thumbnail_size"`
+	GCInterval    Duration `json:"gc_interval"`
+	GCGrace       Duration `json:"gc_grace"`
+}
+
+// SpamConfig controls the spam filter, which holds new posts and comments scoring Threshold or
+// more for moderators. With Export or Train set the process instead writes the moderator
+// decisions the filter learned from to that file, or retrains the filter from a dataset in the
+// same format, and exits; both work on the file storage backend while the server is stopped.
+type SpamConfig struct {
+	Threshold   float64 `json:"threshold"` // 0 disables the filter
+	MinExamples int     `json:"min_examples"`
+	MaxExamples int     `json:"max_examples"`
+	Export      string  `json:"export"` // "-" writes to stdout
+	Train       string  `json:"train"`
+}
+
+// VoteConfig controls vote analysis, which discounts post votes from accounts voting in
+// lockstep, from brigades of another subreddit's members and, with Fingerprint, from accounts
+// sharing a client. Accounts vote in lockstep after LockstepMinVotes votes on the same posts,
+// each within LockstepGap of the other's; a brigade is BrigadeMinVotes votes from outsiders
+// within BrigadeWindow. Cluster mode does not analyse votes; Load turns Audit off there unless
+// it was set explicitly, which Validate then rejects.
+type VoteConfig struct {
+	Audit            bool     `json:"audit"`
+	Fingerprint      bool     `json:"fingerprint"`
+	Window           Duration `json:"window"` // how long votes are remembered for analysis
+	LockstepGap      Duration `json:"lockstep_gap"`
+	LockstepMinVotes int      `json:"lockstep_min_votes"`
+	BrigadeWindow    Duration `json:"brigade_window"`
+	BrigadeMinVotes  int      `json:"brigade_min_votes"`
+}
+
+type LogConfig struct {
+	Format string `json:"format"`
+	Level  string `json:"level"`
+}
+
+type TracingConfig struct {
+	Exporter     string `json:"exporter"`
+	OTLPEndpoint string `json:"otlp_endpoint"`
+	OTLPHeaders  string `json:"otlp_headers" secret:"true"` // e.g. "api-key=..." for a hosted collector
+}
+
+// Headers parses OTLPHeaders ("key=value,key2=value2").
+func (t TracingConfig) Headers() map[string]string {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(t.OTLPHeaders, ",") {
+		if key, value, ok := strings.Cut(pair, "="); ok {
+			headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return headers
+}
+
+type SimulatorConfig struct {
+	APIBaseURL string `json:"api_base_url"`
+}
+
+// Duration is a time.Duration that reads and writes as a string such as "5s" in JSON.
+type Duration time.Duration
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) String() string { return time.Duration(d).String() }
+
+// Default returns the configuration used when nothing is overridden.
+func Default() Config {
+	return Config{
+		HTTP: HTTPConfig{
+			Addr:            ":8080",
+			ShutdownTimeout: Duration(10 * time.Second),
+			MaxBodyBytes:    1 << 20,
+			StreamBuffer:    64,
+			StreamHeartbeat: Duration(15 * time.Second),
+		},
+		GRPC:    GRPCConfig{Addr: ":9090"},
+		Cluster: ClusterConfig{Name: "reddit", Addr: "127.0.0.1:0"},
+		Engine: EngineConfig{
+			RequestTimeout:   Duration(5 * time.Second),
+			MaxContentLength: 40000,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:             true,
+			PostsPerMinute:      10,
+			CommentsPerMinute:   30,
+			VotesPerMinute:      120,
+			ReportsPerMinute:    10,
+			UploadsPerMinute:    10,
+			SubredditsPerMinute: 2,
+			Burst:               5,
+			NewAccountAge:       Duration(24 * time.Hour),
+			LowKarma:            10,
+			RestrictedDivisor:   4,
+		},
+		Storage: StorageConfig{Backend: "memory", Path: "reddit_state.json", FlushInterval: Duration(30 * time.Second), FlushAfter: 1000},
+		Webhooks: WebhookConfig{
+			Timeout:      Duration(5 * time.Second),
+			MaxAttempts:  5,
+			Backoff:      Duration(time.Second),
+			DisableAfter: 3,
+			QueueSize:    100,
+		},
+		Media: MediaConfig{
+			Dir:           "media",
+			MaxBytes:      10 << 20,
+			ThumbnailSize: 320,
+			GCInterval:    Duration(10 * time.Minute),
+			GCGrace:       Duration(time.Hour),
+		},
+		Spam:    SpamConfig{Threshold: 0.95, MinExamples: 10, MaxExamples: 10000},
+		Log:     LogConfig{Format: "text", Level: "info"},
+		Tracing: TracingConfig{Exporter: "none", OTLPEndpoint: "localhost:4318"},
+		Votes: VoteConfig{
+			Audit:            true,
+			Window:           Duration(24 * time.Hour),
+			LockstepGap:      Duration(5 * time.Minute),
+			LockstepMinVotes: 5,
+			BrigadeWindow:    Duration(15 * time.Minute),
+			BrigadeMinVotes:  10,
+		},
+	}
+}
+
+// Load resolves the configuration from args (normally os.Args[1:]), the environment and
+// the file named by -config or REDDIT_CONFIG, then validates it.
+func Load(args []string) (Config, error) {
+	cfg := Default()
+	fs := flag.NewFlagSet("reddit_clone2", flag.ContinueOnError)
+	path := fs.String("config", os.Getenv("REDDIT_CONFIG"), "path to a JSON config file")
+	bind(fs, &cfg)
+	if err := fs.Parse(args); err != nil {
+		return cfg, err
+	}
+
+	// Remember flags given on the command line so they can win over file and environment
+	explicit := map[string]string{}
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = f.Value.String() })
+
+	// Start over from the defaults; the flags still point into cfg
+	cfg = Default()
+	_, auditRequested := explicit["vote-audit"]
+	if *path != "" {
+		data, err := os.ReadFile(*path)
+		if err != nil {
+			return cfg, fmt.Errorf("reading config file: %w", err)
+		}
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("parsing config file %s: %w", *path, err)
+		}
+		var votes struct {
+			Votes struct {
+				Audit *bool `json:"audit"`
+			} `json:"votes"`
+		}
+		if json.Unmarshal(data, &votes) == nil && votes.Votes.Audit != nil {
+			auditRequested = true
+		}
+	}
+	if _, ok := os.LookupEnv(EnvName("vote-audit")); ok {
+		auditRequested = true
+	}
+
+	var errs []error
+	fs.VisitAll(func(f *flag.Flag) {
+		if f.Name == "config" {
+			return
+		}
+		if value, ok := os.LookupEnv(EnvName(f.Name)); ok {
+			if err := f.Value.Set(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", EnvName(f.Name), err))
+			}
+		}
+	})
+	for name, value := range explicit {
+		if err := fs.Set(name, value); err != nil {
+			errs = append(errs, fmt.Errorf("-%s: %w", name, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return cfg, err
+	}
+
+	if cfg.Simulator.APIBaseURL == "" {
+		cfg.Simulator.APIBaseURL = baseURL(cfg.HTTP.Addr)
+	}
+	// Vote analysis is on by default but cluster mode can't do it; only refuse if it was asked for
+	if cfg.Cluster.Nodes > 0 && cfg.Votes.Audit && !auditRequested {
+		cfg.Votes.Audit = false
+		cfg.Adjusted = append(cfg.Adjusted, "votes.audit turned off: cluster mode does not analyse votes")
+	}
+	return cfg, cfg.Validate()
+}
+
+// bind registers one flag per setting, pointing straight at the fields of cfg.
+func bind(fs *flag.FlagSet, cfg *Config) {
+	fs.StringVar(&cfg.HTTP.Addr, "http-addr", cfg.HTTP.Addr, "REST API listen address")
+	fs.StringVar(&cfg.GRPC.Addr, "grpc-addr", cfg.GRPC.Addr, "gRPC API listen address; empty disables it")
+	fs.StringVar(&cfg.Remote.Addr, "remote-addr", cfg.Remote.Addr, "protoactor remote listen address for the engine actors; empty disables it")
+	fs.StringVar(&cfg.Remote.Engine, "remote-engine", cfg.Remote.Engine, "run only the simulator, against the engine node at this address")
+	fs.IntVar(&cfg.Cluster.Nodes, "cluster-nodes", cfg.Cluster.Nodes, "run this many engine cluster members instead of a single engine; 0 disables cluster mode")
+	fs.StringVar(&cfg.Cluster.Name, "cluster-name", cfg.Cluster.Name, "name of the engine cluster to form or join")
+	fs.StringVar(&cfg.Cluster.Addr, "cluster-addr", cfg.Cluster.Addr, "address of the first cluster member; the others use the following ports (port 0 picks free ones)")
+	fs.StringVar(&cfg.Cluster.Dir, "cluster-dir", cfg.Cluster.Dir, "membership directory shared by cluster processes; empty keeps membership in this process")
+	fs.Var((*durationFlag)(&cfg.HTTP.ShutdownTimeout), "shutdown-timeout", "how long to wait for in-flight work when shutting down")
+	fs.Int64Var(&cfg.HTTP.MaxBodyBytes, "max-body-bytes", cfg.HTTP.MaxBodyBytes, "maximum size of a request body")
+	fs.IntVar(&cfg.HTTP.StreamBuffer, "stream-buffer", cfg.HTTP.StreamBuffer, "events a live stream client may fall behind by before it is disconnected")
+	fs.Var((*durationFlag)(&cfg.HTTP.StreamHeartbeat), "stream-heartbeat", "interval between keep-alive comments on idle live streams")
+	fs.Var((*durationFlag)(&cfg.Engine.RequestTimeout), "request-timeout", "timeout for request/response calls to the engine actors")
+	fs.IntVar(&cfg.Engine.MaxContentLength, "max-content-length", cfg.Engine.MaxContentLength, "maximum length of post and comment content")
+	fs.StringVar(&cfg.Engine.Admins, "admins", cfg.Engine.Admins, "comma separated IDs of the users who may read admin reports")
+	fs.BoolVar(&cfg.RateLimit.Enabled, "rate-limit", cfg.RateLimit.Enabled, "enforce write rate limits")
+	fs.IntVar(&cfg.RateLimit.PostsPerMinute, "rate-limit-posts", cfg.RateLimit.PostsPerMinute, "posts allowed per minute")
+	fs.IntVar(&cfg.RateLimit.CommentsPerMinute, "rate-limit-comments", cfg.RateLimit.CommentsPerMinute, "comments allowed per minute")
+	fs.IntVar(&cfg.RateLimit.VotesPerMinute, "rate-limit-votes", cfg.RateLimit.VotesPerMinute, "votes allowed per minute")
+	fs.IntVar(&cfg.RateLimit.ReportsPerMinute, "rate-limit-reports", cfg.RateLimit.ReportsPerMinute, "reports allowed per minute")
+	fs.IntVar(&cfg.RateLimit.UploadsPerMinute, "rate-limit-uploads", cfg.RateLimit.UploadsPerMinute, "media uploads allowed per minute")
+	fs.IntVar(&cfg.RateLimit.SubredditsPerMinute, "rate-limit-subreddits", cfg.RateLimit.SubredditsPerMinute, "subreddits created per minute")
+	fs.IntVar(&cfg.RateLimit.Burst, "rate-limit-burst", cfg.RateLimit.Burst, "extra requests allowed in a burst")
+	fs.Var((*durationFlag)(&cfg.RateLimit.NewAccountAge), "rate-limit-new-account-age", "accounts younger than this get restricted limits")
+	fs.IntVar(&cfg.RateLimit.LowKarma, "rate-limit-low-karma", cfg.RateLimit.LowKarma, "accounts with less karma than this get restricted limits")
+	fs.IntVar(&cfg.RateLimit.RestrictedDivisor, "rate-limit-restricted-divisor", cfg.RateLimit.RestrictedDivisor, "divides the limits of new and low-karma accounts")
+	fs.StringVar(&cfg.Storage.Backend, "storage", cfg.Storage.Backend, "storage backend: memory or file")
+	fs.StringVar(&cfg.Storage.Path, "storage-path", cfg.Storage.Path, "state file used by the file storage backend")
+	fs.Var((*durationFlag)(&cfg.Storage.FlushInterval), "storage-flush-interval", "how often the state file is written while changes are pending (0 only writes it on shutdown)")
+	fs.IntVar(&cfg.Storage.FlushAfter, "storage-flush-after", cfg.Storage.FlushAfter, "write the state file as soon as this many changes are pending (0 turns it off)")
+	fs.Var((*durationFlag)(&cfg.Webhooks.Timeout), "webhook-timeout", "timeout of a single webhook request")
+	fs.IntVar(&cfg.Webhooks.MaxAttempts, "webhook-max-attempts", cfg.Webhooks.MaxAttempts, "attempts per webhook delivery before it counts as failed")
+	fs.Var((*durationFlag)(&cfg.Webhooks.Backoff), "webhook-backoff", "wait before the first webhook retry; doubles on every further retry")
+	fs.IntVar(&cfg.Webhooks.DisableAfter, "webhook-disable-after", cfg.Webhooks.DisableAfter, "failed deliveries in a row after which a webhook is disabled")
+	fs.IntVar(&cfg.Webhooks.QueueSize, "webhook-queue", cfg.Webhooks.QueueSize, "deliveries per webhook waiting behind the one in flight; more are dropped")
+	fs.StringVar(&cfg.Media.Dir, "media-dir", cfg.Media.Dir, "directory uploaded media are kept in")
+	fs.Int64Var(&cfg.Media.MaxBytes, "media-max-bytes", cfg.Media.MaxBytes, "maximum size of an uploaded file")
+	fs.IntVar(&cfg.Media.ThumbnailSize, "media-thumbnail-size", cfg.Media.ThumbnailSize, "longest side of image thumbnails, in pixels")
+	fs.Var((*durationFlag)(&cfg.Media.GCInterval), "media-gc-interval", "interval between collections of uploads no post refers to")
+	fs.Var((*durationFlag)(&cfg.Media.GCGrace), "media-gc-grace", "age an upload must reach before it is collected, to give clients time to post it")
+	fs.Float64Var(&cfg.Spam.Threshold, "spam-threshold", cfg.Spam.Threshold, "spam probability from which new posts and comments are held for moderators; 0 disables the filter")
+	fs.IntVar(&cfg.Spam.MinExamples, "spam-min-examples", cfg.Spam.MinExamples, "spam and non-spam decisions each the filter needs before it holds anything")
+	fs.IntVar(&cfg.Spam.MaxExamples, "spam-max-examples", cfg.Spam.MaxExamples, "moderator decisions kept for -spam-export")
+	fs.StringVar(&cfg.Spam.Export, "spam-export", cfg.Spam.Export, "write the spam filter's training decisions as JSON Lines to this file (- for stdout) and exit")
+	fs.StringVar(&cfg.Spam.Train, "spam-train", cfg.Spam.Train, "retrain the spam filter from a JSON Lines dataset, as written by -spam-export, and exit")
+	fs.BoolVar(&cfg.Votes.Audit, "vote-audit", cfg.Votes.Audit, "discount post votes that look manipulated; not supported in cluster mode")
+	fs.BoolVar(&cfg.Votes.Fingerprint, "vote-fingerprint", cfg.Votes.Fingerprint, "also discount votes on a post from a client another account voted on it from; users behind one NAT share a client")
+	fs.Var((*durationFlag)(&cfg.Votes.Window), "vote-window", "how long votes are remembered for analysis")
+	fs.Var((*durationFlag)(&cfg.Votes.LockstepGap), "vote-lockstep-gap", "longest gap between two accounts' votes on a post that counts as voting together")
+	fs.IntVar(&cfg.Votes.LockstepMinVotes, "vote-lockstep-min", cfg.Votes.LockstepMinVotes, "votes together after which two accounts count as voting in lockstep")
+	fs.Var((*durationFlag)(&cfg.Votes.BrigadeWindow), "vote-brigade-window", "window in which votes from another subreddit's members count towards a brigade")
+	fs.IntVar(&cfg.Votes.BrigadeMinVotes, "vote-brigade-min", cfg.Votes.BrigadeMinVotes, "votes from another subreddit's members within -vote-brigade-window that make a brigade")
+	fs.StringVar(&cfg.Log.Format, "log-format", cfg.Log.Format, "log output format: text or json")
+	fs.StringVar(&cfg.Log.Level, "log-level", cfg.Log.Level, "minimum log level: debug, info, warn or error")
+	fs.StringVar(&cfg.Tracing.Exporter, "trace-exporter", cfg.Tracing.Exporter, "trace exporter: none, stdout or otlp")
+	fs.StringVar(&cfg.Tracing.OTLPEndpoint, "otlp-endpoint", cfg.Tracing.OTLPEndpoint, "OTLP/HTTP collector address used by -trace-exporter otlp")
+	fs.StringVar(&cfg.Tracing.OTLPHeaders, "otlp-headers", cfg.Tracing.OTLPHeaders, "comma separated key=value headers sent to the OTLP collector")
+	fs.StringVar(&cfg.Simulator.APIBaseURL, "api-base-url", cfg.Simulator.APIBaseURL, "base URL the simulator uses for API tests (derived from -http-addr when empty)")
+	fs.BoolVar(&cfg.Headless, "headless", cfg.Headless, "run only the REST API server, without the interactive simulator")
+}
+
+// EnvName is the environment variable that overrides the flag of the same name.
+func EnvName(flagName string) string {
+	return "REDDIT_" + strings.ToUpper(strings.ReplaceAll(flagName, "-", "_"))
+}
+
+type durationFlag Duration
+
+func (d *durationFlag) String() string { return Duration(*d).String() }
+
+func (d *durationFlag) Set(s string) error {
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = durationFlag(parsed)
+	return nil
+}
+
+func baseURL(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "http://localhost:8080"
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port)
+}
+
+// Validate reports every setting that is out of range.
+func (c Config) Validate() error {
+	var errs []error
+	if _, _, err := net.SplitHostPort(c.HTTP.Addr); err != nil {
+		errs = append(errs, fmt.Errorf("http.addr %q: %w", c.HTTP.Addr, err))
+	}
+	if c.GRPC.Addr != "" {
+		if _, _, err := net.SplitHostPort(c.GRPC.Addr); err != nil {
+			errs = append(errs, fmt.Errorf("grpc.addr %q: %w", c.GRPC.Addr, err))
+		}
+	}
+	for name, addr := range map[string]string{"remote.addr": c.Remote.Addr, "remote.engine": c.Remote.Engine} {
+		if addr == "" {
+			continue
+		}
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			errs = append(errs, fmt.Errorf("%s %q: %w", name, addr, err))
+		}
+	}
+	if c.Cluster.Nodes < 0 {
+		errs = append(errs, errors.New("cluster.nodes must not be negative"))
+	}
+	if c.Cluster.Nodes > 0 {
+		if c.Cluster.Name == "" {
+			errs = append(errs, errors.New("cluster.name must not be empty"))
+		}
+		if _, _, err := net.SplitHostPort(c.Cluster.Addr); err != nil {
+			errs = append(errs, fmt.Errorf("cluster.addr %q: %w", c.Cluster.Addr, err))
+		}
+		// Grains count votes themselves, so analysis that was asked for would silently be off
+		if c.Votes.Audit {
+			errs = append(errs, errors.New("votes.audit is not supported in cluster mode"))
+		}
+	}
+	if c.HTTP.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("http.shutdown_timeout must be positive"))
+	}
+	if c.HTTP.MaxBodyBytes <= 0 {
+		errs = append(errs, errors.New("http.max_body_bytes must be positive"))
+	}
+	if c.HTTP.StreamBuffer <= 0 {
+		errs = append(errs, errors.New("http.stream_buffer must be positive"))
+	}
+	if c.HTTP.StreamHeartbeat <= 0 {
+		errs = append(errs, errors.New("http.stream_heartbeat must be positive"))
+	}
+	if c.Engine.RequestTimeout <= 0 {
+		errs = append(errs, errors.New("engine.request_timeout must be positive"))
+	}
+	if c.Engine.MaxContentLength <= 0 {
+		errs = append(errs, errors.New("engine.max_content_length must be positive"))
+	}
+	if c.RateLimit.Enabled {
+		if c.RateLimit.PostsPerMinute <= 0 || c.RateLimit.CommentsPerMinute <= 0 || c.RateLimit.VotesPerMinute <= 0 ||
+			c.RateLimit.ReportsPerMinute <= 0 || c.RateLimit.UploadsPerMinute <= 0 || c.RateLimit.SubredditsPerMinute <= 0 {
+			errs = append(errs, errors.New("rate_limit per-minute limits must be positive"))
+		}
+		if c.RateLimit.Burst < 0 {
+			errs = append(errs, errors.New("rate_limit.burst must not be negative"))
+		}
+		if c.RateLimit.RestrictedDivisor < 1 {
+			errs = append(errs, errors.New("rate_limit.restricted_divisor must be at least 1"))
+		}
+	}
+	switch c.Storage.Backend {
+	case "memory":
+	case "file":
+		if c.Storage.Path == "" {
+			errs = append(errs, errors.New("storage.path is required for the file backend"))
+		}
+		if c.Storage.FlushInterval < 0 || c.Storage.FlushAfter < 0 {
+			errs = append(errs, errors.New("storage.flush_interval and storage.flush_after must not be negative"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("storage.backend %q must be memory or file", c.Storage.Backend))
+	}
+	if c.Webhooks.Timeout <= 0 || c.Webhooks.Backoff <= 0 {
+		errs = append(errs, errors.New("webhooks.timeout and webhooks.backoff must be positive"))
+	}
+	if c.Webhooks.MaxAttempts < 1 || c.Webhooks.DisableAfter < 1 || c.Webhooks.QueueSize < 1 {
+		errs = append(errs, errors.New("webhooks.max_attempts, webhooks.disable_after and webhooks.queue_size must be at least 1"))
+	}
+	if c.Media.Dir == "" {
+		errs = append(errs, errors.New("media.dir must not be empty"))
+	}
+	if c.Media.MaxBytes <= 0 || c.Media.ThumbnailSize <= 0 {
+		errs = append(errs, errors.New("media.max_bytes and media.thumbnail_size must be positive"))
+	}
+	if c.Media.GCInterval <= 0 || c.Media.GCGrace < 0 {
+		errs = append(errs, errors.New("media.gc_interval must be positive and media.gc_grace not negative"))
+	}
+	if c.Spam.Threshold < 0 || c.Spam.Threshold > 1 {
+		errs = append(errs, errors.New("spam.threshold must be between 0 and 1"))
+	}
+	if c.Spam.MinExamples < 1 || c.Spam.MaxExamples < 1 {
+		errs = append(errs, errors.New("spam.min_examples and spam.max_examples must be at least 1"))
+	}
+	if c.Spam.Export != "" && c.Spam.Train != "" {
+		errs = append(errs, errors.New("spam.export and spam.train cannot be combined"))
+	}
+	if (c.Spam.Export != "" || c.Spam.Train != "") && c.Storage.Backend != "file" {
+		errs = append(errs, errors.New("spam.export and spam.train need the file storage backend"))
+	}
+	for _, field := range strings.Split(c.Engine.Admins, ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		if id, err := strconv.Atoi(field); err != nil || id <= 0 {
+			errs = append(errs, fmt.Errorf("engine.admins: %q is not a user ID", field))
+		}
+	}
+	if c.Votes.Window <= 0 || c.Votes.LockstepGap <= 0 || c.Votes.BrigadeWindow <= 0 {
+		errs = append(errs, errors.New("votes.window, votes.lockstep_gap and votes.brigade_window must be positive"))
+	}
+	if c.Votes.LockstepMinVotes < 2 || c.Votes.BrigadeMinVotes < 2 {
+		errs = append(errs, errors.New("votes.lockstep_min_votes and votes.brigade_min_votes must be at least 2"))
+	}
+	if c.Log.Format != "text" && c.Log.Format != "json" {
+		errs = append(errs, fmt.Errorf("log.format %q must be text or json", c.Log.Format))
+	}
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level %q must be debug, info, warn or error", c.Log.Level))
+	}
+	switch c.Tracing.Exporter {
+	case "none", "stdout":
+	case "otlp":
+		if c.Tracing.OTLPEndpoint == "" {
+			errs = append(errs, errors.New("tracing.otlp_endpoint is required for the otlp exporter"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("tracing.exporter %q must be none, stdout or otlp", c.Tracing.Exporter))
+	}
+	return errors.Join(errs...)
+}
+
+// Redacted returns a copy of c with every field tagged secret:"true" masked, for printing.
+func (c Config) Redacted() Config {
+	redact(reflect.ValueOf(&c).Elem())
+	return c
+}
+
+func redact(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		switch {
+		case field.Kind() == reflect.Struct:
+			redact(field)
+		case v.Type().Field(i).Tag.Get("secret") == "true" && field.Kind() == reflect.String && field.String() != "":
+			field.SetString("[REDACTED]")
+		}
+	}
+}
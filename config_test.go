@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadClusterVoteAudit(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(file, []byte(`{"votes": {"audit": true}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		args    []string
+		env     string
+		wantErr bool
+	}{
+		{name: "default", args: []string{"-cluster-nodes", "3"}},
+		{name: "flag off", args: []string{"-cluster-nodes", "3", "-vote-audit=false"}},
+		{name: "flag on", args: []string{"-cluster-nodes", "3", "-vote-audit"}, wantErr: true},
+		{name: "env on", args: []string{"-cluster-nodes", "3"}, env: "true", wantErr: true},
+		{name: "file on", args: []string{"-config", file, "-cluster-nodes", "3"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				t.Setenv(EnvName("vote-audit"), tt.env)
+			}
+			cfg, err := Load(tt.args)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "votes.audit") {
+					t.Fatalf("Load(%v) error = %v, want votes.audit rejected", tt.args, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Load(%v): %v", tt.args, err)
+			}
+			if cfg.Votes.Audit {
+				t.Fatalf("Load(%v) left votes.audit on in cluster mode", tt.args)
+			}
+		})
+	}
+
+	cfg, err := Load(nil)
+	if err != nil || !cfg.Votes.Audit || len(cfg.Adjusted) != 0 {
+		t.Fatalf("Load(nil) = audit %v, adjusted %v, %v; want audit on by default", cfg.Votes.Audit, cfg.Adjusted, err)
+	}
+}
+
+func TestRestrictedRateLimits(t *testing.T) {
+	limits := Default().RateLimit
+	for _, action := range []string{"post", "comment", "vote", "report", "upload", "subreddit"} {
+		normal, restricted := limits.PerMinute(action, false), limits.PerMinute(action, true)
+		if restricted >= normal && normal > 1 {
+			t.Errorf("%s: restricted accounts get %d per minute, others %d", action, restricted, normal)
+		}
+		if restricted < 1 {
+			t.Errorf("%s: restricted accounts get %d per minute, want at least 1", action, restricted)
+		}
+	}
+	if got, want := limits.BurstFor(true), limits.Burst/limits.RestrictedDivisor; got != want {
+		t.Errorf("restricted burst = %d, want %d", got, want)
+	}
+	if got := limits.BurstFor(false); got != limits.Burst {
+		t.Errorf("burst = %d, want %d", got, limits.Burst)
+	}
+}
@@ -0,0 +1,112 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+var (
+	ErrNotMember        = errors.New("user is not a member of the subreddit")
+	ErrInvalidCrosspost = errors.New("invalid crosspost")
+)
+
+// crosspostResponse answers Crosspost with the new post
+type crosspostResponse struct {
+	post PostSummary
+	err  error
+}
+
+// crosspost copies msg.PostID into msg.Subreddit; p.mu must be held. A crosspost of a
+// crosspost refers to the original post while that is still live. Like any new post, the copy
+// goes past AutoModerator and the spam filter first; author is the crossposter, as withAuthor
+// found them.
+func (p *PostActor) crosspost(ctx actor.Context, msg *Crosspost, author *User) (*Post, error) {
+	parent, exists := p.posts[msg.PostID]
+	if !exists || !parent.live() {
+		return nil, ErrNoSuchPost
+	}
+	if parent.CrosspostOf != nil {
+		if original, exists := p.posts[parent.CrosspostOf.PostID]; exists && original.live() {
+			parent = original
+		}
+	}
+	title := strings.TrimSpace(msg.Title)
+	if title == "" {
+		title = parent.Title
+	}
+	switch {
+	case parent.Subreddit == msg.Subreddit:
+		return nil, fmt.Errorf("%w: the post is already in r/%s", ErrInvalidCrosspost, msg.Subreddit)
+	case utf8.RuneCountInString(title) > MaxTitleLength:
+		return nil, fmt.Errorf("%w: title is longer than %d characters", ErrInvalidCrosspost, MaxTitleLength)
+	}
+	for _, post := range p.posts {
+		if !post.Deleted && post.Subreddit == msg.Subreddit && post.CrosspostOf != nil && post.CrosspostOf.PostID == parent.ID {
+			return nil, fmt.Errorf("%w: already crossposted to r/%s as post %d", ErrInvalidCrosspost, msg.Subreddit, post.ID)
+		}
+	}
+	if parent.Kind == PostLink {
+		if existing := findLink(p.posts, msg.Subreddit, parent.URL); existing != nil {
+			return nil, fmt.Errorf("%w as post %d", ErrDuplicateLink, existing.ID)
+		}
+	}
+
+	post := &Post{
+		ID:          len(p.posts) + 1,
+		UserID:      msg.UserID,
+		Subreddit:   msg.Subreddit,
+		Title:       title,
+		Kind:        parent.Kind,
+		URL:         parent.URL,
+		Domain:      parent.Domain,
+		Content:     parent.Content,
+		Media:       append([]Media(nil), parent.Media...),
+		CrosspostOf: &CrosspostParent{PostID: parent.ID, Subreddit: parent.Subreddit, UserID: parent.UserID},
+	}
+	verdict, spamScore, spam := p.screenPost(post, author)
+	p.addPost(ctx, post)
+	parent.Crossposts++
+	p.store.SavePost(parent)
+	p.enforceAutoMod(ctx, post, nil, verdict, msg.RequestID)
+	if spam {
+		p.holdSpam(ctx, ReportPost, post.ID, post.Subreddit, spamScore, msg.RequestID)
+	}
+	return post, nil
+}
+
+// Crosspost shares a live post into another subreddit on behalf of one of its members who
+// isn't banned there, and returns the new post.
+func (as *ActorSystem) Crosspost(ctx context.Context, msg Crosspost) (PostSummary, error) {
+	result, err := as.requestFuture(ctx, as.SubredditActor, &GetSubreddit{Name: msg.Subreddit, RequestID: msg.RequestID}, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching subreddit", "request_id", msg.RequestID, "subreddit", msg.Subreddit, "error", err)
+		return PostSummary{}, err
+	}
+	subreddit, ok := result.(*Subreddit)
+	switch {
+	case !ok:
+		return PostSummary{}, fmt.Errorf("unexpected subreddit %T", result)
+	case subreddit == nil:
+		return PostSummary{}, ErrNoSuchSubreddit
+	case subreddit.Banned[msg.UserID]:
+		return PostSummary{}, fmt.Errorf("%w: r/%s", ErrBanned, msg.Subreddit)
+	case !subreddit.Members[msg.UserID]:
+		return PostSummary{}, fmt.Errorf("%w: join r/%s to crosspost to it", ErrNotMember, msg.Subreddit)
+	}
+
+	result, err = as.requestFuture(ctx, as.PostActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error crossposting", "request_id", msg.RequestID, "post_id", msg.PostID, "error", err)
+		return PostSummary{}, err
+	}
+	response, ok := result.(crosspostResponse)
+	if !ok {
+		return PostSummary{}, fmt.Errorf("unexpected crosspost response %T", result)
+	}
+	return response.post, response.err
+}
@@ -0,0 +1,95 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestCrosspostFollowsAutoModRules(t *testing.T) {
+	ctx := context.Background()
+	as := newTestActorSystem(t, NewMemoryStore())
+	as.CreateSubreddit(ctx, CreateSubreddit{Name: "golang", CreatorID: 1})
+	as.CreateSubreddit(ctx, CreateSubreddit{Name: "news", CreatorID: 1})
+	eventually(t, "r/news to set its rules", func() bool {
+		return as.SetAutoModRules(ctx, SetAutoModRules{Subreddit: "news", UserID: 1, Rules: []AutoModRule{
+			{Name: "no gophers", Type: AutoModPost, TitleRegex: "(?i)gopher", Action: AutoModRemove},
+			{Name: "hold rumours", Type: AutoModPost, BodyRegex: "(?i)rumou?r", Action: AutoModFilter},
+		}}) == nil
+	})
+
+	as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "gopher spotted", Content: "a gopher"})
+	as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "Go 2", Content: "a rumour"})
+	as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "Go 1.24", Content: "released"})
+	eventually(t, "the posts", func() bool {
+		_, err := as.livePost(ctx, 3, "")
+		return err == nil
+	})
+
+	removed, err := as.Crosspost(ctx, Crosspost{UserID: 1, PostID: 1, Subreddit: "news"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := as.livePost(ctx, removed.ID, ""); !errors.Is(err, ErrNoSuchPost) {
+		t.Errorf("crosspost matching a remove rule is live: %v", err)
+	}
+	filtered, err := as.Crosspost(ctx, Crosspost{UserID: 1, PostID: 2, Subreddit: "news"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !filtered.Filtered {
+		t.Error("crosspost matching a filter rule is not held for moderators")
+	}
+	allowed, err := as.Crosspost(ctx, Crosspost{UserID: 1, PostID: 3, Subreddit: "news"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if allowed.Filtered || allowed.Content != "released" {
+		t.Errorf("crosspost matching no rule = %+v, want it live", allowed)
+	}
+}
+
+func TestCrosspostRejectsBannedUser(t *testing.T) {
+	ctx := context.Background()
+	as := newTestActorSystem(t, NewMemoryStore())
+	as.CreateSubreddit(ctx, CreateSubreddit{Name: "golang", CreatorID: 1})
+	as.CreateSubreddit(ctx, CreateSubreddit{Name: "news", CreatorID: 1})
+	as.JoinSubreddit(ctx, JoinSubreddit{Name: "news", UserID: 2})
+	as.CreatePost(ctx, PostMessage{UserID: 2, Subreddit: "golang", Title: "Go 1.24", Content: "released"})
+	eventually(t, "the post", func() bool {
+		_, err := as.livePost(ctx, 1, "")
+		return err == nil
+	})
+
+	if err := as.BanUser(ctx, BanUser{Subreddit: "news", UserID: 2, BannedID: 1}); !errors.Is(err, ErrNotModerator) {
+		t.Fatalf("ban by a member = %v, want ErrNotModerator", err)
+	}
+	if err := as.BanUser(ctx, BanUser{Subreddit: "news", UserID: 1, BannedID: 1}); !errors.Is(err, ErrInvalidBan) {
+		t.Fatalf("ban of a moderator = %v, want ErrInvalidBan", err)
+	}
+	if err := as.BanUser(ctx, BanUser{Subreddit: "news", UserID: 1, BannedID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := as.Crosspost(ctx, Crosspost{UserID: 2, PostID: 1, Subreddit: "news"}); !errors.Is(err, ErrBanned) {
+		t.Fatalf("crosspost by a banned user = %v, want ErrBanned", err)
+	}
+	if err := as.CheckPoster(ctx, "news", 2, ""); !errors.Is(err, ErrBanned) {
+		t.Fatalf("posting check for a banned user = %v, want ErrBanned", err)
+	}
+	// The ban ended the membership and keeps them from joining again
+	as.JoinSubreddit(ctx, JoinSubreddit{Name: "news", UserID: 2})
+	if news, err := as.subreddit(ctx, "news", ""); err != nil || news.Members[2] {
+		t.Fatalf("banned user is a member of r/news (%v)", err)
+	}
+
+	if err := as.UnbanUser(ctx, UnbanUser{Subreddit: "news", UserID: 1, BannedID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	as.JoinSubreddit(ctx, JoinSubreddit{Name: "news", UserID: 2})
+	if err := as.CheckPoster(ctx, "news", 2, ""); err != nil {
+		t.Fatalf("posting check after the ban was lifted = %v", err)
+	}
+	if _, err := as.Crosspost(ctx, Crosspost{UserID: 2, PostID: 1, Subreddit: "news"}); err != nil {
+		t.Fatalf("crosspost after the ban was lifted = %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// Subreddit listing sort orders
+const (
+	SortMembers  = "members"
+	SortActivity = "activity"
+	SortNewest   = "newest"
+)
+
+const (
+	DefaultListPageSize = 25
+	MaxListPageSize     = 100
+)
+
+// Trending weighs recent activity by kind and halves its weight every trendingHalfLife.
+// Events older than trendingWindow are dropped.
+const (
+	trendingHalfLife = time.Hour
+	trendingWindow   = 24 * time.Hour
+)
+
+var activityWeights = map[string]float64{"post": 3, "comment": 2, "vote": 1}
+
+// SubredditSummary is the public view of a subreddit in listings
+type SubredditSummary struct {
+	Name          string    `json:"name"`
+	Members       int       `json:"members"`
+	Posts         int       `json:"posts"`
+	CreatedAt     time.Time `json:"created_at"`
+	LastActivity  time.Time `json:"last_activity"`
+	TrendingScore float64   `json:"trending_score,omitempty"`
+}
+
+type activityEvent struct {
+	kind string
+	at   time.Time
+}
+
+func summarize(subreddit *Subreddit) SubredditSummary {
+	return SubredditSummary{
+		Name:         subreddit.Name,
+		Members:      len(subreddit.Members),
+		Posts:        len(subreddit.Posts),
+		CreatedAt:    subreddit.CreatedAt,
+		LastActivity: subreddit.LastActivity,
+	}
+}
+
+// sortSubreddits orders summaries for a listing; ties fall back to name.
+func sortSubreddits(summaries []SubredditSummary, order string) {
+	sort.Slice(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		switch order {
+		case SortMembers:
+			if a.Members != b.Members {
+				return a.Members > b.Members
+			}
+		case SortActivity:
+			if !a.LastActivity.Equal(b.LastActivity) {
+				return a.LastActivity.After(b.LastActivity)
+			}
+		case SortNewest:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		}
+		return a.Name < b.Name
+	})
+}
+
+// paginate returns the 1-based page of items, clamping the page size to [1, maxSize].
+func paginate[T any](items []T, page, pageSize, defaultSize, maxSize int) []T {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultSize
+	}
+	pageSize = min(pageSize, maxSize)
+	// Compared before multiplying, so a huge page cannot overflow into a negative start
+	if page-1 > len(items)/pageSize {
+		return []T{}
+	}
+	start := (page - 1) * pageSize
+	if start >= len(items) {
+		return []T{}
+	}
+	return items[start:min(start+pageSize, len(items))]
+}
+
+// trendingScore decays each event's weight by its age, so a burst of recent posts and
+// votes outranks a larger amount of older activity.
+func trendingScore(events []activityEvent, now time.Time) float64 {
+	score := 0.0
+	for _, event := range events {
+		age := now.Sub(event.at)
+		score += activityWeights[event.kind] * math.Exp2(-age.Hours()/trendingHalfLife.Hours())
+	}
+	return score
+}
+
+// pruneActivity drops events that fell out of the trending window.
+func pruneActivity(events []activityEvent, now time.Time) []activityEvent {
+	kept := events[:0]
+	for _, event := range events {
+		if now.Sub(event.at) <= trendingWindow {
+			kept = append(kept, event)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,27 @@
+package engine
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	for _, tc := range []struct {
+		page, pageSize int
+		want           []int
+	}{
+		{1, 2, []int{1, 2}},
+		{3, 2, []int{5}},
+		{4, 2, []int{}},
+		{0, 0, []int{1, 2, 3}},      // page 1 of the default size
+		{1, 100, []int{1, 2, 3, 4}}, // clamped to the max size
+		{math.MaxInt64 / 10, 4, []int{}},
+		{math.MaxInt64, 4, []int{}},
+	} {
+		if got := paginate(items, tc.page, tc.pageSize, 3, 4); !slices.Equal(got, tc.want) {
+			t.Errorf("paginate(page %d, size %d) = %v, want %v", tc.page, tc.pageSize, got, tc.want)
+		}
+	}
+}
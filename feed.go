@@ -0,0 +1,113 @@
+package engine
+
+import "sort"
+
+const (
+	DefaultFeedPageSize = 25
+	MaxFeedPageSize     = 100
+)
+
+// PostSummary is the public view of a post in feeds and listings
+type PostSummary struct {
+	ID          int              `json:"id"`
+	UserID      int              `json:"user_id"`
+	Subreddit   string           `json:"subreddit"`
+	Title       string           `json:"title,omitempty"`
+	Kind        string           `json:"kind"`
+	URL         string           `json:"url,omitempty"`
+	Domain      string           `json:"domain,omitempty"`
+	Content     string           `json:"content"` // markdown source
+	ContentHTML string           `json:"content_html"`
+	Media       []Media          `json:"media,omitempty"`
+	Flair       *FlairTemplate   `json:"flair,omitempty"`
+	AuthorFlair *FlairTemplate   `json:"author_flair,omitempty"` // filled in by the Feed facade
+	CrosspostOf *CrosspostParent `json:"crosspost_parent,omitempty"`
+	Crossposts  int              `json:"crossposts"`
+	Upvotes     int              `json:"upvotes"`
+	Downvotes   int              `json:"downvotes"`
+	Score       int              `json:"score"`
+	Comments    int              `json:"comments"`
+	Filtered    bool             `json:"filtered,omitempty"` // awaiting a moderator's approval
+	Locked      bool             `json:"locked,omitempty"`
+}
+
+func summarizePost(post *Post) PostSummary {
+	comments := 0
+	for _, comment := range post.Comments {
+		if comment.live() {
+			comments++
+		}
+	}
+	kind := post.Kind
+	if kind == "" {
+		kind = PostText
+	}
+	return PostSummary{
+		ID:          post.ID,
+		UserID:      post.UserID,
+		Subreddit:   post.Subreddit,
+		Title:       post.Title,
+		Kind:        kind,
+		URL:         post.URL,
+		Domain:      post.Domain,
+		Content:     post.Content,
+		ContentHTML: post.ContentHTML,
+		Media:       post.Media,
+		Flair:       post.Flair,
+		CrosspostOf: post.CrosspostOf,
+		Crossposts:  post.Crossposts,
+		Upvotes:     post.Upvotes,
+		Downvotes:   post.Downvotes,
+		Score:       post.Upvotes - post.Downvotes,
+		Comments:    comments,
+		Filtered:    post.Filtered,
+		Locked:      post.Locked,
+	}
+}
+
+// CommentSummary is the public view of a comment outside its thread
+type CommentSummary struct {
+	ID          int    `json:"id"`
+	PostID      int    `json:"post_id"`
+	ParentID    int    `json:"parent_id,omitempty"`
+	UserID      int    `json:"user_id"`
+	Subreddit   string `json:"subreddit"`
+	Content     string `json:"content"`
+	ContentHTML string `json:"content_html"`
+	Upvotes     int    `json:"upvotes"`
+	Downvotes   int    `json:"downvotes"`
+	Score       int    `json:"score"`
+	Filtered    bool   `json:"filtered,omitempty"`
+}
+
+func summarizeComment(post *Post, comment *Comment) CommentSummary {
+	return CommentSummary{
+		ID:          comment.ID,
+		PostID:      post.ID,
+		ParentID:    comment.ParentID,
+		UserID:      comment.UserID,
+		Subreddit:   post.Subreddit,
+		Content:     comment.Content,
+		ContentHTML: comment.ContentHTML,
+		Upvotes:     comment.Upvotes,
+		Downvotes:   comment.Downvotes,
+		Score:       comment.Upvotes - comment.Downvotes,
+		Filtered:    comment.Filtered,
+	}
+}
+
+// feed returns the live posts in any of subreddits, newest first.
+func feed(posts map[int]*Post, subreddits []string) []PostSummary {
+	wanted := make(map[string]bool, len(subreddits))
+	for _, name := range subreddits {
+		wanted[name] = true
+	}
+	summaries := []PostSummary{}
+	for _, post := range posts {
+		if post.live() && wanted[post.Subreddit] {
+			summaries = append(summaries, summarizePost(post))
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].ID > summaries[j].ID })
+	return summaries
+}
@@ -0,0 +1,205 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Flair template types
+const (
+	FlairPost = "post"
+	FlairUser = "user"
+)
+
+const (
+	MaxFlairTextLength = 64
+	MaxFlairTemplates  = 100 // per subreddit, post and user flair together
+)
+
+var (
+	ErrNoSuchFlair  = errors.New("flair does not exist")
+	ErrInvalidFlair = errors.New("invalid flair")
+)
+
+var flairColor = regexp.MustCompile(`^#[0-9a-f]{6}$`)
+
+// flairResponse answers the flair messages, and SetPostFlair once PostActor applied it
+type flairResponse struct {
+	flairs    []FlairTemplate
+	moderator bool // whether the requesting user moderates the subreddit, for ResolveFlair
+	err       error
+}
+
+// validateFlair normalizes a new template in place, or says what is wrong with it.
+func validateFlair(msg *CreateFlair) error {
+	msg.Text = strings.TrimSpace(msg.Text)
+	msg.Color = strings.ToLower(strings.TrimSpace(msg.Color))
+	switch {
+	case msg.Type != FlairPost && msg.Type != FlairUser:
+		return fmt.Errorf("%w: type must be %q or %q", ErrInvalidFlair, FlairPost, FlairUser)
+	case msg.Text == "":
+		return fmt.Errorf("%w: text is required", ErrInvalidFlair)
+	case utf8.RuneCountInString(msg.Text) > MaxFlairTextLength:
+		return fmt.Errorf("%w: text is longer than %d characters", ErrInvalidFlair, MaxFlairTextLength)
+	case msg.Color != "" && !flairColor.MatchString(msg.Color):
+		return fmt.Errorf("%w: color must look like #1a2b3c", ErrInvalidFlair)
+	}
+	return nil
+}
+
+// flair returns the flairType template with the given ID, or nil.
+func (s *Subreddit) flair(id int, flairType string) *FlairTemplate {
+	for i := range s.Flairs {
+		if s.Flairs[i].ID == id && s.Flairs[i].Type == flairType {
+			return &s.Flairs[i]
+		}
+	}
+	return nil
+}
+
+// applicableFlair returns the template userID may apply, or why they may not.
+func (s *Subreddit) applicableFlair(id int, flairType string, userID int) (*FlairTemplate, error) {
+	template := s.flair(id, flairType)
+	switch {
+	case template == nil:
+		return nil, fmt.Errorf("%w: no %s flair %d in r/%s", ErrNoSuchFlair, flairType, id, s.Name)
+	case template.ModOnly && !s.Moderators[userID]:
+		return nil, fmt.Errorf("%w: flair %q is for moderators only", ErrNotModerator, template.Text)
+	}
+	return template, nil
+}
+
+// filterFlair keeps the posts flaired with template flairID
+func filterFlair(posts []PostSummary, flairID int) []PostSummary {
+	kept := []PostSummary{}
+	for _, post := range posts {
+		if post.Flair != nil && post.Flair.ID == flairID {
+			kept = append(kept, post)
+		}
+	}
+	return kept
+}
+
+// CreateFlair adds a flair template to a subreddit on behalf of one of its moderators.
+func (as *ActorSystem) CreateFlair(ctx context.Context, msg CreateFlair) (FlairTemplate, error) {
+	if err := validateFlair(&msg); err != nil {
+		return FlairTemplate{}, err
+	}
+	response, err := as.flairRequest(ctx, &msg, msg.RequestID)
+	if err != nil {
+		return FlairTemplate{}, err
+	}
+	return response.flairs[0], nil
+}
+
+// ListFlairs returns a subreddit's flair templates of msg.Type, or all of them.
+func (as *ActorSystem) ListFlairs(ctx context.Context, msg ListFlairs) ([]FlairTemplate, error) {
+	response, err := as.flairRequest(ctx, &msg, msg.RequestID)
+	return response.flairs, err
+}
+
+// DeleteFlair removes a template. Posts keep their copy of it; users who picked it lose it.
+func (as *ActorSystem) DeleteFlair(ctx context.Context, msg DeleteFlair) error {
+	_, err := as.flairRequest(ctx, &msg, msg.RequestID)
+	return err
+}
+
+func (as *ActorSystem) SetUserFlair(ctx context.Context, msg SetUserFlair) error {
+	_, err := as.flairRequest(ctx, &msg, msg.RequestID)
+	return err
+}
+
+// AttachFlair resolves msg.FlairID to the post flair msg.UserID may apply in msg.Subreddit,
+// so PostActor can store it with the post.
+func (as *ActorSystem) AttachFlair(ctx context.Context, msg *PostMessage) error {
+	msg.Flair = nil
+	if msg.FlairID == 0 {
+		return nil
+	}
+	response, err := as.flairRequest(ctx, &ResolveFlair{Subreddit: msg.Subreddit, FlairID: msg.FlairID, UserID: msg.UserID, RequestID: msg.RequestID}, msg.RequestID)
+	if err != nil {
+		return err
+	}
+	msg.Flair = &response.flairs[0]
+	return nil
+}
+
+// SetPostFlair changes the flair of a live post, or clears it when msg.FlairID is 0. The
+// post's author and the subreddit's moderators may.
+func (as *ActorSystem) SetPostFlair(ctx context.Context, msg SetPostFlair) error {
+	result, err := as.requestFuture(ctx, as.PostActor, &GetPost{PostID: msg.PostID, RequestID: msg.RequestID}, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching post", "request_id", msg.RequestID, "post_id", msg.PostID, "error", err)
+		return err
+	}
+	post, ok := result.(*Post)
+	if !ok {
+		return fmt.Errorf("unexpected post %T", result)
+	}
+	if post == nil || post.Deleted {
+		return ErrNoSuchPost
+	}
+	response, err := as.flairRequest(ctx, &ResolveFlair{Subreddit: post.Subreddit, FlairID: msg.FlairID, UserID: msg.UserID, RequestID: msg.RequestID}, msg.RequestID)
+	if err != nil {
+		return err
+	}
+	if post.UserID != msg.UserID && !response.moderator {
+		return fmt.Errorf("%w: only the author or a moderator may change a post's flair", ErrNotModerator)
+	}
+	msg.Flair = nil
+	if len(response.flairs) > 0 {
+		msg.Flair = &response.flairs[0]
+	}
+	result, err = as.requestFuture(ctx, as.PostActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error setting post flair", "request_id", msg.RequestID, "post_id", msg.PostID, "error", err)
+		return err
+	}
+	if response, ok := result.(flairResponse); ok {
+		return response.err
+	}
+	return fmt.Errorf("unexpected flair response %T", result)
+}
+
+// addAuthorFlairs fills in the flair each post's author picked in the post's subreddit
+func (as *ActorSystem) addAuthorFlairs(ctx context.Context, posts []PostSummary, requestID string) error {
+	var subreddits []string
+	for _, post := range posts {
+		subreddits = append(subreddits, post.Subreddit)
+	}
+	if len(subreddits) == 0 {
+		return nil
+	}
+	result, err := as.requestFuture(ctx, as.SubredditActor, &GetUserFlairs{Subreddits: subreddits, RequestID: requestID}, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching user flairs", "request_id", requestID, "error", err)
+		return err
+	}
+	flairs, ok := result.(map[string]map[int]FlairTemplate)
+	if !ok {
+		return fmt.Errorf("unexpected user flairs %T", result)
+	}
+	for i, post := range posts {
+		if flair, ok := flairs[post.Subreddit][post.UserID]; ok {
+			posts[i].AuthorFlair = &flair
+		}
+	}
+	return nil
+}
+
+func (as *ActorSystem) flairRequest(ctx context.Context, msg interface{}, requestID string) (flairResponse, error) {
+	result, err := as.requestFuture(ctx, as.SubredditActor, msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error managing flair", "request_id", requestID, "error", err)
+		return flairResponse{}, err
+	}
+	response, ok := result.(flairResponse)
+	if !ok {
+		return flairResponse{}, fmt.Errorf("unexpected flair response %T", result)
+	}
+	return response, response.err
+}
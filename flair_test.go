@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFlairPermissions(t *testing.T) {
+	ctx := context.Background()
+	as := newTestActorSystem(t, NewMemoryStore())
+	as.CreateSubreddit(ctx, CreateSubreddit{Name: "golang", CreatorID: 1}) // user 1 moderates r/golang
+	var discussion FlairTemplate
+	eventually(t, "r/golang", func() bool {
+		var err error
+		discussion, err = as.CreateFlair(ctx, CreateFlair{Subreddit: "golang", UserID: 1, Type: FlairPost, Text: " Discussion ", Color: "#00AA00"})
+		return err == nil
+	})
+	if discussion.Text != "Discussion" || discussion.Color != "#00aa00" {
+		t.Fatalf("created flair %+v, want its text trimmed and color lowercased", discussion)
+	}
+	create := func(msg CreateFlair) FlairTemplate {
+		t.Helper()
+		template, err := as.CreateFlair(ctx, msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return template
+	}
+	announcement := create(CreateFlair{Subreddit: "golang", UserID: 1, Type: FlairPost, Text: "Announcement", ModOnly: true})
+	gopher := create(CreateFlair{Subreddit: "golang", UserID: 1, Type: FlairUser, Text: "Gopher"})
+	staff := create(CreateFlair{Subreddit: "golang", UserID: 1, Type: FlairUser, Text: "Staff", ModOnly: true})
+
+	as.CreatePost(ctx, PostMessage{UserID: 2, Subreddit: "golang", Title: "question"})
+	eventually(t, "user 2's post", func() bool {
+		_, err := as.livePost(ctx, 1, "")
+		return err == nil
+	})
+
+	attach := func(userID, flairID int) error {
+		return as.AttachFlair(ctx, &PostMessage{UserID: userID, Subreddit: "golang", FlairID: flairID})
+	}
+	tests := []struct {
+		name string
+		do   func() error
+		want error
+	}{
+		{"members can't create templates", func() error {
+			_, err := as.CreateFlair(ctx, CreateFlair{Subreddit: "golang", UserID: 2, Type: FlairPost, Text: "Mine"})
+			return err
+		}, ErrNotModerator},
+		{"templates need a valid color", func() error {
+			_, err := as.CreateFlair(ctx, CreateFlair{Subreddit: "golang", UserID: 1, Type: FlairPost, Text: "Red", Color: "red"})
+			return err
+		}, ErrInvalidFlair},
+		{"members can't delete templates", func() error {
+			return as.DeleteFlair(ctx, DeleteFlair{Subreddit: "golang", UserID: 2, FlairID: discussion.ID})
+		}, ErrNotModerator},
+		{"members post with open flair", func() error { return attach(2, discussion.ID) }, nil},
+		{"members can't post with mod-only flair", func() error { return attach(2, announcement.ID) }, ErrNotModerator},
+		{"moderators post with mod-only flair", func() error { return attach(1, announcement.ID) }, nil},
+		{"user flair is not post flair", func() error { return attach(1, gopher.ID) }, ErrNoSuchFlair},
+		{"members pick open user flair", func() error {
+			return as.SetUserFlair(ctx, SetUserFlair{Subreddit: "golang", UserID: 2, FlairID: gopher.ID})
+		}, nil},
+		{"members can't pick mod-only user flair", func() error {
+			return as.SetUserFlair(ctx, SetUserFlair{Subreddit: "golang", UserID: 2, FlairID: staff.ID})
+		}, ErrNotModerator},
+		{"others can't flair a post", func() error {
+			return as.SetPostFlair(ctx, SetPostFlair{UserID: 3, PostID: 1, FlairID: discussion.ID})
+		}, ErrNotModerator},
+		{"authors flair their post", func() error {
+			return as.SetPostFlair(ctx, SetPostFlair{UserID: 2, PostID: 1, FlairID: discussion.ID})
+		}, nil},
+		{"authors can't use mod-only flair on their post", func() error {
+			return as.SetPostFlair(ctx, SetPostFlair{UserID: 2, PostID: 1, FlairID: announcement.ID})
+		}, ErrNotModerator},
+		{"moderators flair anyone's post", func() error {
+			return as.SetPostFlair(ctx, SetPostFlair{UserID: 1, PostID: 1, FlairID: announcement.ID})
+		}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.do(); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	post, err := as.livePost(ctx, 1, "")
+	if err != nil || post.Flair == nil || post.Flair.ID != announcement.ID {
+		t.Fatalf("post flair = %+v (%v), want the moderator's announcement", post.Flair, err)
+	}
+}
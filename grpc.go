@@ -0,0 +1,475 @@
+package main
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative reddit.proto
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"reddit_clone2/engine"
+	"reddit_clone2/redditpb"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// rateLimitedRPCs maps the write RPCs to the action whose limit applies to them
+var rateLimitedRPCs = map[string]string{
+	redditpb.Reddit_CreatePost_FullMethodName:      "post",
+	redditpb.Reddit_Crosspost_FullMethodName:       "post",
+	redditpb.Reddit_AddComment_FullMethodName:      "comment",
+	redditpb.Reddit_Vote_FullMethodName:            "vote",
+	redditpb.Reddit_CreateSubreddit_FullMethodName: "subreddit",
+}
+
+// redditService serves the Reddit gRPC service from the same ActorSystem facade as the REST handlers.
+type redditService struct {
+	redditpb.UnimplementedRedditServer
+}
+
+// newGRPCServer builds the gRPC server with request ID, logging and rate limit interceptors.
+func newGRPCServer() *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryLogging, unaryRateLimiting),
+		grpc.StreamInterceptor(streamLogging),
+	)
+	redditpb.RegisterRedditServer(server, &redditService{})
+	return server
+}
+
+// withRequestID tags the call with the x-request-id metadata the client sent, or a new ID.
+func withRequestID(ctx context.Context) (context.Context, string) {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("x-request-id")) > 0 {
+		id = md.Get("x-request-id")[0]
+	}
+	if id == "" {
+		id = newRequestID()
+	}
+	grpc.SetHeader(ctx, metadata.Pairs("x-request-id", id))
+	return context.WithValue(ctx, requestIDKey, id), id
+}
+
+func contextRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+func unaryLogging(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, id := withRequestID(ctx)
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	logger.Info("grpc request", "request_id", id, "method", info.FullMethod, "code", status.Code(err).String(), "duration", time.Since(start))
+	return resp, err
+}
+
+func streamLogging(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, id := withRequestID(ss.Context())
+	start := time.Now()
+	err := handler(srv, &requestIDStream{ServerStream: ss, ctx: ctx})
+	logger.Info("grpc stream", "request_id", id, "method", info.FullMethod, "code", status.Code(err).String(), "duration", time.Since(start))
+	return err
+}
+
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDStream) Context() context.Context { return s.ctx }
+
+// peerIP is the address an RPC came from, without the port
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
+// unaryRateLimiting applies the REST write limits to the matching RPCs.
+func unaryRateLimiting(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	action, limited := rateLimitedRPCs[info.FullMethod]
+	if !settings.RateLimit.Enabled || !limited {
+		return handler(ctx, req)
+	}
+	ip := peerIP(ctx)
+	userID := 0
+	switch r := req.(type) {
+	case interface{ GetUserId() int64 }:
+		userID = int(r.GetUserId())
+	case interface{ GetCreatorId() int64 }:
+		userID = int(r.GetCreatorId())
+	}
+	if retryAfter, key, value, ok := allowWrite(ctx, contextRequestID(ctx), action, ip, userID); !ok {
+		logger.Warn("rate limited", "request_id", contextRequestID(ctx), "method", info.FullMethod, key, value, "retry_after", retryAfter)
+		grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))))
+		return nil, status.Error(codes.ResourceExhausted, "rate limit exceeded")
+	}
+	return handler(ctx, req)
+}
+
+// checkRPCContent rejects content longer than the configured limit.
+func checkRPCContent(content string) error {
+	if len(content) > settings.Engine.MaxContentLength {
+		return status.Error(codes.InvalidArgument, "content too long")
+	}
+	return nil
+}
+
+func (s *redditService) RegisterUser(ctx context.Context, req *redditpb.RegisterUserRequest) (*emptypb.Empty, error) {
+	actorSystem.RegisterUser(ctx, engine.RegisterUser{Username: req.Username, Password: req.Password, RequestID: contextRequestID(ctx)})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *redditService) GetUser(ctx context.Context, req *redditpb.GetUserRequest) (*redditpb.User, error) {
+	user, found := actorSystem.GetUser(ctx, engine.GetUser{UserID: int(req.Id), Username: req.Username, RequestID: contextRequestID(ctx)})
+	if !found {
+		return nil, status.Error(codes.NotFound, "user does not exist")
+	}
+	return userProto(user), nil
+}
+
+func (s *redditService) ListUsers(ctx context.Context, req *redditpb.ListUsersRequest) (*redditpb.ListUsersResponse, error) {
+	users := actorSystem.GetAllUsers(ctx, engine.GetAllUsers{RequestID: contextRequestID(ctx)})
+	if users == nil {
+		return nil, status.Error(codes.Unavailable, "fetching users failed")
+	}
+	resp := &redditpb.ListUsersResponse{}
+	for _, user := range users {
+		resp.Users = append(resp.Users, userProto(user))
+	}
+	sort.Slice(resp.Users, func(i, j int) bool { return resp.Users[i].Id < resp.Users[j].Id })
+	return resp, nil
+}
+
+func (s *redditService) CreateSubreddit(ctx context.Context, req *redditpb.CreateSubredditRequest) (*emptypb.Empty, error) {
+	actorSystem.CreateSubreddit(ctx, engine.CreateSubreddit{Name: req.Name, CreatorID: int(req.CreatorId), RequestID: contextRequestID(ctx)})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *redditService) JoinSubreddit(ctx context.Context, req *redditpb.MembershipRequest) (*emptypb.Empty, error) {
+	actorSystem.JoinSubreddit(ctx, engine.JoinSubreddit{Name: req.Subreddit, UserID: int(req.UserId), RequestID: contextRequestID(ctx)})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *redditService) LeaveSubreddit(ctx context.Context, req *redditpb.MembershipRequest) (*emptypb.Empty, error) {
+	actorSystem.LeaveSubreddit(ctx, engine.LeaveSubreddit{Name: req.Subreddit, UserID: int(req.UserId), RequestID: contextRequestID(ctx)})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *redditService) ListSubreddits(ctx context.Context, req *redditpb.ListSubredditsRequest) (*redditpb.ListSubredditsResponse, error) {
+	order := req.Sort
+	switch order {
+	case "":
+		order = engine.SortMembers
+	case engine.SortMembers, engine.SortActivity, engine.SortNewest:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "sort must be members, activity or newest")
+	}
+	summaries, err := actorSystem.ListSubreddits(ctx, engine.ListSubreddits{
+		Sort:      order,
+		Page:      int(req.Page),
+		PageSize:  int(req.PageSize),
+		RequestID: contextRequestID(ctx),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, "listing subreddits failed")
+	}
+	resp := &redditpb.ListSubredditsResponse{}
+	for _, summary := range summaries {
+		resp.Subreddits = append(resp.Subreddits, &redditpb.Subreddit{
+			Name:         summary.Name,
+			Members:      int64(summary.Members),
+			Posts:        int64(summary.Posts),
+			CreatedAt:    timestamppb.New(summary.CreatedAt),
+			LastActivity: timestamppb.New(summary.LastActivity),
+		})
+	}
+	return resp, nil
+}
+
+func (s *redditService) CreatePost(ctx context.Context, req *redditpb.CreatePostRequest) (*emptypb.Empty, error) {
+	if err := checkRPCContent(req.Content); err != nil {
+		return nil, err
+	}
+	post := engine.PostMessage{UserID: int(req.UserId), Subreddit: req.Subreddit, Title: req.Title, Kind: req.Kind, URL: req.Url, Content: req.Content, MediaIDs: req.MediaIds, FlairID: int(req.FlairId), RequestID: contextRequestID(ctx)}
+	if err := engine.ValidatePost(&post); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := actorSystem.CheckPoster(ctx, post.Subreddit, post.UserID, post.RequestID); err != nil {
+		if errors.Is(err, engine.ErrBanned) {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, status.Error(codes.Unavailable, "checking ban failed")
+	}
+	if err := actorSystem.AttachMedia(ctx, &post); err != nil {
+		if errors.Is(err, engine.ErrNoSuchMedia) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Unavailable, "attaching media failed")
+	}
+	if err := actorSystem.AttachFlair(ctx, &post); err != nil {
+		switch {
+		case errors.Is(err, engine.ErrNoSuchFlair), errors.Is(err, engine.ErrNoSuchSubreddit):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, engine.ErrNotModerator):
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, status.Error(codes.Unavailable, "attaching flair failed")
+	}
+	if post.Kind == engine.PostLink {
+		existing, err := actorSystem.FindLink(ctx, engine.FindLink{Subreddit: post.Subreddit, URL: post.URL, RequestID: post.RequestID})
+		if err != nil {
+			return nil, status.Error(codes.Unavailable, "checking link failed")
+		}
+		if existing != 0 {
+			return nil, status.Errorf(codes.AlreadyExists, "%v as post %d", engine.ErrDuplicateLink, existing)
+		}
+	}
+	actorSystem.CreatePost(ctx, post)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *redditService) EditPost(ctx context.Context, req *redditpb.EditPostRequest) (*emptypb.Empty, error) {
+	if err := checkRPCContent(req.Content); err != nil {
+		return nil, err
+	}
+	actorSystem.EditPost(ctx, engine.EditPost{PostID: int(req.PostId), UserID: int(req.UserId), Content: req.Content, RequestID: contextRequestID(ctx)})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *redditService) DeletePost(ctx context.Context, req *redditpb.DeletePostRequest) (*emptypb.Empty, error) {
+	actorSystem.DeletePost(ctx, engine.DeletePost{PostID: int(req.PostId), UserID: int(req.UserId), RequestID: contextRequestID(ctx)})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *redditService) Crosspost(ctx context.Context, req *redditpb.CrosspostRequest) (*redditpb.Post, error) {
+	post, err := actorSystem.Crosspost(ctx, engine.Crosspost{PostID: int(req.PostId), UserID: int(req.UserId), Subreddit: req.Subreddit, Title: req.Title, RequestID: contextRequestID(ctx)})
+	switch {
+	case err == nil:
+		return postSummaryToProto(post), nil
+	case errors.Is(err, engine.ErrInvalidCrosspost):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, engine.ErrNotMember), errors.Is(err, engine.ErrBanned):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, engine.ErrNoSuchPost), errors.Is(err, engine.ErrNoSuchSubreddit):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, engine.ErrDuplicateLink):
+		return nil, status.Error(codes.AlreadyExists, err.Error())
+	}
+	return nil, status.Error(codes.Unavailable, "crossposting failed")
+}
+
+func (s *redditService) AddComment(ctx context.Context, req *redditpb.AddCommentRequest) (*emptypb.Empty, error) {
+	if err := checkRPCContent(req.Content); err != nil {
+		return nil, err
+	}
+	actorSystem.AddComment(ctx, engine.CommentMessage{
+		PostID:    int(req.PostId),
+		ParentID:  int(req.ParentId),
+		UserID:    int(req.UserId),
+		Content:   req.Content,
+		RequestID: contextRequestID(ctx),
+	})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *redditService) EditComment(ctx context.Context, req *redditpb.EditCommentRequest) (*emptypb.Empty, error) {
+	if err := checkRPCContent(req.Content); err != nil {
+		return nil, err
+	}
+	actorSystem.EditComment(ctx, engine.EditComment{CommentID: int(req.CommentId), UserID: int(req.UserId), Content: req.Content, RequestID: contextRequestID(ctx)})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *redditService) DeleteComment(ctx context.Context, req *redditpb.DeleteCommentRequest) (*emptypb.Empty, error) {
+	actorSystem.DeleteComment(ctx, engine.DeleteComment{CommentID: int(req.CommentId), UserID: int(req.UserId), RequestID: contextRequestID(ctx)})
+	return &emptypb.Empty{}, nil
+}
+
+func (s *redditService) Vote(ctx context.Context, req *redditpb.VoteRequest) (*emptypb.Empty, error) {
+	vote := engine.Vote{UserID: int(req.UserId), Target: "post", ID: int(req.PostId), RequestID: contextRequestID(ctx)}
+	userAgent := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get("user-agent")) > 0 {
+		userAgent = md.Get("user-agent")[0]
+	}
+	vote.Fingerprint = engine.ClientFingerprint(peerIP(ctx), userAgent)
+	switch req.Direction {
+	case redditpb.VoteRequest_DIRECTION_UP:
+		vote.Type = "upvote"
+	case redditpb.VoteRequest_DIRECTION_DOWN:
+		vote.Type = "downvote"
+	default:
+		return nil, status.Error(codes.InvalidArgument, "direction must be up or down")
+	}
+	actorSystem.VotePost(ctx, vote)
+	return &emptypb.Empty{}, nil
+}
+
+func (s *redditService) GetFeed(ctx context.Context, req *redditpb.GetFeedRequest) (*redditpb.GetFeedResponse, error) {
+	if req.UserId == 0 && len(req.Subreddits) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id or subreddits is required")
+	}
+	posts, err := actorSystem.Feed(ctx, engine.GetFeed{
+		UserID:     int(req.UserId),
+		Subreddits: req.Subreddits,
+		FlairID:    int(req.FlairId),
+		Page:       int(req.Page),
+		PageSize:   int(req.PageSize),
+		RequestID:  contextRequestID(ctx),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, "fetching feed failed")
+	}
+	resp := &redditpb.GetFeedResponse{}
+	for _, post := range posts {
+		resp.Posts = append(resp.Posts, postSummaryToProto(post))
+	}
+	return resp, nil
+}
+
+func (s *redditService) ListDomainPosts(ctx context.Context, req *redditpb.ListDomainPostsRequest) (*redditpb.ListDomainPostsResponse, error) {
+	if req.Domain == "" {
+		return nil, status.Error(codes.InvalidArgument, "domain is required")
+	}
+	posts, err := actorSystem.DomainPosts(ctx, engine.GetDomainPosts{
+		Domain:    req.Domain,
+		UserID:    int(req.UserId),
+		Page:      int(req.Page),
+		PageSize:  int(req.PageSize),
+		RequestID: contextRequestID(ctx),
+	})
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, "listing domain posts failed")
+	}
+	resp := &redditpb.ListDomainPostsResponse{}
+	for _, post := range posts {
+		resp.Posts = append(resp.Posts, postSummaryToProto(post))
+	}
+	return resp, nil
+}
+
+func postSummaryToProto(post engine.PostSummary) *redditpb.Post {
+	var media []*redditpb.Media
+	for _, m := range post.Media {
+		media = append(media, &redditpb.Media{Id: m.ID, ContentType: m.ContentType, Size: m.Size, Width: int32(m.Width), Height: int32(m.Height), Thumbnail: m.Thumbnail})
+	}
+	out := &redditpb.Post{
+		Id:          int64(post.ID),
+		UserId:      int64(post.UserID),
+		Subreddit:   post.Subreddit,
+		Title:       post.Title,
+		Kind:        post.Kind,
+		Url:         post.URL,
+		Domain:      post.Domain,
+		Content:     post.Content,
+		ContentHtml: post.ContentHTML,
+		Upvotes:     int64(post.Upvotes),
+		Downvotes:   int64(post.Downvotes),
+		Score:       int64(post.Score),
+		Comments:    int64(post.Comments),
+		Media:       media,
+		Flair:       flairToProto(post.Flair),
+		AuthorFlair: flairToProto(post.AuthorFlair),
+		Crossposts:  int64(post.Crossposts),
+	}
+	if parent := post.CrosspostOf; parent != nil {
+		out.CrosspostParent = &redditpb.CrosspostParent{PostId: int64(parent.PostID), Subreddit: parent.Subreddit, UserId: int64(parent.UserID)}
+	}
+	return out
+}
+
+func flairToProto(flair *engine.FlairTemplate) *redditpb.Flair {
+	if flair == nil {
+		return nil
+	}
+	return &redditpb.Flair{Id: int64(flair.ID), Type: flair.Type, Text: flair.Text, Color: flair.Color, ModOnly: flair.ModOnly}
+}
+
+func (s *redditService) StreamSubredditActivity(req *redditpb.StreamSubredditActivityRequest, stream redditpb.Reddit_StreamSubredditActivityServer) error {
+	if req.Subreddit == "" && req.PostId == 0 {
+		return status.Error(codes.InvalidArgument, "subreddit or post_id is required")
+	}
+	sub := actorSystem.Subscribe(engine.StreamFilter{Subreddit: req.Subreddit, PostID: int(req.PostId)}, settings.HTTP.StreamBuffer)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-streams.Done():
+			return status.Error(codes.Unavailable, "server is shutting down")
+		case event, open := <-sub.Events:
+			if !open {
+				if sub.Overflowed() {
+					return status.Error(codes.ResourceExhausted, "client fell behind the event stream")
+				}
+				return nil
+			}
+			err := stream.Send(&redditpb.Event{
+				Type:        event.Type,
+				Subreddit:   event.Subreddit,
+				PostId:      int64(event.PostID),
+				CommentId:   int64(event.CommentID),
+				UserId:      int64(event.UserID),
+				Content:     event.Content,
+				ContentHtml: event.ContentHTML,
+				Score:       int64(event.Score),
+				At:          timestamppb.New(event.At),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func userProto(user *engine.User) *redditpb.User {
+	return &redditpb.User{
+		Id:        int64(user.ID),
+		Username:  user.Username,
+		Karma:     int64(user.Karma),
+		CreatedAt: timestamppb.New(user.CreatedAt),
+	}
+}
+
+// serveGRPC runs the gRPC server until it is stopped; errors other than a normal stop are returned.
+func serveGRPC(server *grpc.Server, addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	logger.Info("starting gRPC server", "addr", listener.Addr().String())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// stopGRPC lets in-flight RPCs finish, cutting them off when ctx expires.
+func stopGRPC(ctx context.Context, server *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logger.Error("gRPC server shutdown timed out, closing open calls")
+		server.Stop()
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"net"
+	"reddit_clone2/redditpb"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestGRPCClient serves the Reddit service over an in-memory connection to a fresh engine
+func newTestGRPCClient(t *testing.T) redditpb.RedditClient {
+	t.Helper()
+	useTestEngine(t)
+	listener := bufconn.Listen(1 << 20)
+	server := newGRPCServer()
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return redditpb.NewRedditClient(conn)
+}
+
+func TestGRPCRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	client := newTestGRPCClient(t)
+
+	if _, err := client.RegisterUser(ctx, &redditpb.RegisterUserRequest{Username: "alice", Password: "secret"}); err != nil {
+		t.Fatal(err)
+	}
+	var header metadata.MD
+	eventually(t, "alice", func() bool {
+		user, err := client.GetUser(ctx, &redditpb.GetUserRequest{Username: "alice"}, grpc.Header(&header))
+		return err == nil && user.Id == 1
+	})
+	if ids := header.Get("x-request-id"); len(ids) != 1 || ids[0] == "" {
+		t.Errorf("x-request-id header = %v, want a request ID", ids)
+	}
+
+	if _, err := client.CreateSubreddit(ctx, &redditpb.CreateSubredditRequest{Name: "golang", CreatorId: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.JoinSubreddit(ctx, &redditpb.MembershipRequest{Subreddit: "golang", UserId: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CreatePost(ctx, &redditpb.CreatePostRequest{UserId: 1, Subreddit: "golang", Title: "hello", Content: "over gRPC"}); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "the post in the feed", func() bool {
+		feed, err := client.GetFeed(ctx, &redditpb.GetFeedRequest{Subreddits: []string{"golang"}})
+		return err == nil && len(feed.Posts) == 1 && feed.Posts[0].Title == "hello"
+	})
+	if _, err := client.Vote(ctx, &redditpb.VoteRequest{UserId: 2, PostId: 1, Direction: redditpb.VoteRequest_DIRECTION_UP}); err != nil {
+		t.Fatal(err)
+	}
+	eventually(t, "the vote", func() bool {
+		feed, err := client.GetFeed(ctx, &redditpb.GetFeedRequest{Subreddits: []string{"golang"}})
+		return err == nil && len(feed.Posts) == 1 && feed.Posts[0].Upvotes == 1
+	})
+}
+
+func TestGRPCErrorCodes(t *testing.T) {
+	ctx := context.Background()
+	client := newTestGRPCClient(t)
+
+	for _, tt := range []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"unknown user", func() error {
+			_, err := client.GetUser(ctx, &redditpb.GetUserRequest{Id: 42})
+			return err
+		}, codes.NotFound},
+		{"vote without a direction", func() error {
+			_, err := client.Vote(ctx, &redditpb.VoteRequest{UserId: 1, PostId: 1})
+			return err
+		}, codes.InvalidArgument},
+		{"feed for nobody", func() error {
+			_, err := client.GetFeed(ctx, &redditpb.GetFeedRequest{})
+			return err
+		}, codes.InvalidArgument},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(tt.call()); got != tt.want {
+				t.Fatalf("code %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
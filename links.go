@@ -0,0 +1,148 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Post kinds
+const (
+	PostText  = "text"
+	PostLink  = "link"
+	PostImage = "image"
+)
+
+const MaxTitleLength = 300
+
+var (
+	ErrInvalidPost   = errors.New("invalid post")
+	ErrDuplicateLink = errors.New("link already submitted to the subreddit")
+)
+
+// ValidatePost checks msg against its kind and normalizes it in place: an empty Kind becomes
+// PostText, the title is trimmed and the URL normalized. Text posts may leave the title out;
+// link and image posts need a title and an http or https URL, which image posts may replace
+// with uploaded media. Link posts carry no media.
+func ValidatePost(msg *PostMessage) error {
+	if msg.Kind == "" {
+		msg.Kind = PostText
+	}
+	msg.Title = strings.TrimSpace(msg.Title)
+	if utf8.RuneCountInString(msg.Title) > MaxTitleLength {
+		return fmt.Errorf("%w: title longer than %d characters", ErrInvalidPost, MaxTitleLength)
+	}
+	if len(msg.MediaIDs) > MaxPostMedia {
+		return fmt.Errorf("%w: more than %d media", ErrInvalidPost, MaxPostMedia)
+	}
+	hasMedia := len(msg.MediaIDs) > 0 || len(msg.Media) > 0
+
+	switch msg.Kind {
+	case PostText:
+		if msg.URL != "" {
+			return fmt.Errorf("%w: text posts have no URL", ErrInvalidPost)
+		}
+		return nil
+	case PostLink, PostImage:
+		if msg.Title == "" {
+			return fmt.Errorf("%w: %s posts need a title", ErrInvalidPost, msg.Kind)
+		}
+		if msg.Kind == PostLink && hasMedia {
+			return fmt.Errorf("%w: link posts have no media", ErrInvalidPost)
+		}
+		if msg.Kind == PostImage && msg.URL == "" {
+			if !hasMedia {
+				return fmt.Errorf("%w: image posts need a URL or media", ErrInvalidPost)
+			}
+			return nil
+		}
+		normalized, _, err := NormalizeLink(msg.URL)
+		if err != nil {
+			return err
+		}
+		msg.URL = normalized
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown kind %q", ErrInvalidPost, msg.Kind)
+	}
+}
+
+// NormalizeLink returns the canonical form of an http or https URL, so the same page submitted
+// twice compares equal, together with its domain. Scheme and host are lowercased, default ports,
+// fragments and utm_* tracking parameters dropped and the remaining query sorted.
+func NormalizeLink(raw string) (normalized, domain string, err error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", "", fmt.Errorf("%w: malformed URL", ErrInvalidPost)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" || u.User != nil {
+		return "", "", fmt.Errorf("%w: URL must be an absolute http or https URL", ErrInvalidPost)
+	}
+
+	host, port := strings.ToLower(u.Hostname()), u.Port()
+	if (u.Scheme == "http" && port == "80") || (u.Scheme == "https" && port == "443") {
+		port = ""
+	}
+	u.Host = host
+	if port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]"
+	}
+
+	u.Fragment, u.RawFragment = "", ""
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	query := u.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") {
+			query.Del(key)
+		}
+	}
+	u.RawQuery = query.Encode() // sorted by key
+	u.ForceQuery = false
+
+	return u.String(), LinkDomain(host), nil
+}
+
+// LinkDomain is the domain a link is listed under: its lowercased host without "www."
+func LinkDomain(host string) string {
+	return strings.TrimPrefix(strings.ToLower(host), "www.")
+}
+
+// domainOf returns the domain of a URL NormalizeLink already accepted
+func domainOf(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return LinkDomain(u.Hostname())
+}
+
+// findLink returns the live link post of link in subreddit, or nil.
+func findLink(posts map[int]*Post, subreddit, link string) *Post {
+	for _, post := range posts {
+		if !post.Deleted && post.Kind == PostLink && post.Subreddit == subreddit && post.URL == link {
+			return post
+		}
+	}
+	return nil
+}
+
+// domainPosts returns the live posts linking to domain, newest first.
+func domainPosts(posts map[int]*Post, domain string) []PostSummary {
+	summaries := []PostSummary{}
+	for _, post := range posts {
+		if post.live() && post.Domain != "" && post.Domain == domain {
+			summaries = append(summaries, summarizePost(post))
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].ID > summaries[j].ID })
+	return summaries
+}
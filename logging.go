@@ -0,0 +1,30 @@
+package engine
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Log output formats accepted by NewLogger
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// NewLogger builds the structured logger shared by the actors and the REST handlers.
+// format is either LogFormatText or LogFormatJSON; anything else falls back to text.
+func NewLogger(w io.Writer, format string, level slog.Level) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: level}
+	if strings.EqualFold(format, LogFormatJSON) {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// ParseLogLevel maps "debug", "info", "warn" or "error" to a slog level.
+func ParseLogLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	err := level.UnmarshalText([]byte(s))
+	return level, err
+}
@@ -25,82 +25,1588 @@ func main() {
 package main
 
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
-	"log"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"reddit_clone2/config"
 	"reddit_clone2/engine"
+	"reddit_clone2/ratelimit"
 	"reddit_clone2/simulator"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gorilla/mux"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
 )
 
 var (
 	actorSystem *engine.ActorSystem
+	logger      *slog.Logger
+	settings    config.Config
+	limiter     = ratelimit.New()
+	// streams is cancelled when the server starts shutting down, ending open live streams
+	streams context.Context
 )
 
+// rateLimitedRoutes maps the write endpoints to the action whose limit applies to them
+var rateLimitedRoutes = map[string]string{
+	"/api/posts":                       "post",
+	"/api/posts/{id:[0-9]+}/crosspost": "post",
+	"/api/comments":                    "comment",
+	"/api/votes":                       "vote",
+	"/api/reports":                     "report",
+	"/api/media":                       "upload",
+	"/api/subreddits":                  "subreddit",
+}
+
+type contextKey string
+
+const requestIDKey contextKey = "request_id"
+
 func main() {
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		slog.Error("invalid configuration", "error", err)
+		os.Exit(2)
+	}
+
+	level, err := engine.ParseLogLevel(cfg.Log.Level)
+	if err != nil {
+		slog.Error("invalid log level", "level", cfg.Log.Level, "error", err)
+		os.Exit(2)
+	}
+	// Logs go to stderr so they stay separate from the interactive menu on stdout
+	logger = engine.NewLogger(os.Stderr, cfg.Log.Format, level)
+	logger.Info("configuration loaded", "config", cfg.Redacted())
+	for _, adjusted := range cfg.Adjusted {
+		logger.Warn("configuration adjusted", "setting", adjusted)
+	}
+	settings = cfg
+
+	if cfg.Spam.Export != "" || cfg.Spam.Train != "" {
+		if err := runSpamDataset(cfg); err != nil {
+			logger.Error("spam dataset failed", "export", cfg.Spam.Export, "train", cfg.Spam.Train, "error", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	shutdownTracing, err := engine.SetupTracing(context.Background(), cfg.Tracing.Exporter, cfg.Tracing.OTLPEndpoint, cfg.Tracing.Headers())
+	if err != nil {
+		logger.Error("failed to set up tracing", "exporter", cfg.Tracing.Exporter, "error", err)
+		os.Exit(2)
+	}
+
+	if cfg.Remote.Engine != "" {
+		runRemoteSimulator(cfg)
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("tracing shutdown failed", "error", err)
+		}
+		return
+	}
+	if cfg.Cluster.Nodes > 0 {
+		runCluster(cfg)
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("tracing shutdown failed", "error", err)
+		}
+		return
+	}
+
 	// Initialize the Actor System
-	actorSystem = engine.NewActorSystem()
+	actorSystem = engine.NewActorSystem(logger)
+	actorSystem.RequestTimeout = time.Duration(cfg.Engine.RequestTimeout)
+	actorSystem.Webhooks = engine.WebhookOptions{
+		Client:       &http.Client{Timeout: time.Duration(cfg.Webhooks.Timeout)},
+		MaxAttempts:  cfg.Webhooks.MaxAttempts,
+		Backoff:      time.Duration(cfg.Webhooks.Backoff),
+		DisableAfter: cfg.Webhooks.DisableAfter,
+		QueueSize:    cfg.Webhooks.QueueSize,
+	}
+	if cfg.Storage.Backend == "file" {
+		store, err := engine.NewFileStore(cfg.Storage.Path)
+		if err != nil {
+			logger.Error("failed to open state file", "path", cfg.Storage.Path, "error", err)
+			os.Exit(1)
+		}
+		actorSystem.Store = store
+		actorSystem.FlushInterval = time.Duration(cfg.Storage.FlushInterval)
+		actorSystem.FlushAfter = cfg.Storage.FlushAfter
+	}
+	blobs, err := engine.NewFileBlobStore(cfg.Media.Dir)
+	if err != nil {
+		logger.Error("failed to open media directory", "dir", cfg.Media.Dir, "error", err)
+		os.Exit(1)
+	}
+	actorSystem.Media.Blobs = blobs
+	actorSystem.Media.MaxBytes = cfg.Media.MaxBytes
+	actorSystem.Media.ThumbnailSize = cfg.Media.ThumbnailSize
+	actorSystem.Media.GCInterval = time.Duration(cfg.Media.GCInterval)
+	actorSystem.Media.GCGrace = time.Duration(cfg.Media.GCGrace)
+	actorSystem.VoteAudit.Enabled = cfg.Votes.Audit
+	actorSystem.VoteAudit.Fingerprint = cfg.Votes.Fingerprint
+	actorSystem.VoteAudit.Window = time.Duration(cfg.Votes.Window)
+	actorSystem.VoteAudit.LockstepGap = time.Duration(cfg.Votes.LockstepGap)
+	actorSystem.VoteAudit.LockstepMinVotes = cfg.Votes.LockstepMinVotes
+	actorSystem.VoteAudit.BrigadeWindow = time.Duration(cfg.Votes.BrigadeWindow)
+	actorSystem.VoteAudit.BrigadeMinVotes = cfg.Votes.BrigadeMinVotes
+	actorSystem.Admins = map[int]bool{}
+	for _, id := range cfg.Engine.AdminIDs() {
+		actorSystem.Admins[id] = true
+	}
+	actorSystem.Spam = engine.SpamOptions{Threshold: cfg.Spam.Threshold, MinExamples: cfg.Spam.MinExamples, MaxExamples: cfg.Spam.MaxExamples}
 	actorSystem.SetupActors()
+	if cfg.Remote.Addr != "" {
+		if err := actorSystem.StartRemote(cfg.Remote.Addr); err != nil {
+			logger.Error("failed to start engine remote", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// Stop on SIGINT/SIGTERM, or when the interactive simulator exits
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Setup the router
+	// Start REST API Server
+	server := &http.Server{Addr: cfg.HTTP.Addr, Handler: newRouter()}
+	// Live streams never finish on their own, so Shutdown would otherwise wait them out
+	var stopStreams context.CancelFunc
+	streams, stopStreams = context.WithCancel(context.Background())
+	server.RegisterOnShutdown(stopStreams)
+	go func() {
+		logger.Info("starting REST API server", "addr", server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("REST API server stopped", "error", err)
+			stop()
+		}
+	}()
+
+	var grpcServer *grpc.Server
+	if cfg.GRPC.Addr != "" {
+		grpcServer = newGRPCServer()
+		go func() {
+			if err := serveGRPC(grpcServer, cfg.GRPC.Addr); err != nil {
+				logger.Error("gRPC server stopped", "error", err)
+				stop()
+			}
+		}()
+	}
+
+	// Start the interactive simulator
+	if !cfg.Headless {
+		go func() {
+			simulator.SimulateUsers(actorSystem, cfg.Simulator.APIBaseURL)
+			stop()
+		}()
+	}
+
+	<-ctx.Done()
+	logger.Info("shutting down", "timeout", cfg.HTTP.ShutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.HTTP.ShutdownTimeout))
+	defer cancel()
+
+	// Stop accepting requests first, then drain the actors they feed, then flush spans
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("REST API server shutdown failed", "error", err)
+	}
+	if grpcServer != nil {
+		stopGRPC(shutdownCtx, grpcServer)
+	}
+	if err := actorSystem.Shutdown(shutdownCtx); err != nil {
+		logger.Error("actor system shutdown failed", "error", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		logger.Error("tracing shutdown failed", "error", err)
+	}
+}
+
+// newRouter routes the REST API to its handlers behind the logging, tracing, rate limiting and
+// JSON middleware.
+func newRouter() *mux.Router {
 	r := mux.NewRouter()
+	r.Use(requestLogging, requestTracing, rateLimiting, jsonResponses)
 
 	// API Endpoints
 	r.HandleFunc("/api/users", RegisterUser).Methods("POST")
 	r.HandleFunc("/api/subreddits", CreateSubreddit).Methods("POST")
+	r.HandleFunc("/api/subreddits", ListSubreddits).Methods("GET")
+	r.HandleFunc("/api/subreddits/autocomplete", AutocompleteSubreddits).Methods("GET")
+	r.HandleFunc("/api/subreddits/trending", TrendingSubreddits).Methods("GET")
+	r.HandleFunc("/api/subreddits/{name}/join", JoinSubreddit).Methods("POST")
+	r.HandleFunc("/api/subreddits/{name}/leave", LeaveSubreddit).Methods("POST")
+	r.HandleFunc("/api/subreddits/{name}/webhooks", RegisterWebhook).Methods("POST")
+	r.HandleFunc("/api/subreddits/{name}/webhooks", ListWebhooks).Methods("GET")
+	r.HandleFunc("/api/subreddits/{name}/webhooks/{id:[0-9]+}", DeleteWebhook).Methods("DELETE")
+	r.HandleFunc("/api/subreddits/{name}/flairs", CreateFlair).Methods("POST")
+	r.HandleFunc("/api/subreddits/{name}/flairs", ListFlairs).Methods("GET")
+	r.HandleFunc("/api/subreddits/{name}/flairs/{id:[0-9]+}", DeleteFlair).Methods("DELETE")
+	r.HandleFunc("/api/subreddits/{name}/flair", SetUserFlair).Methods("PUT")
+	r.HandleFunc("/api/subreddits/{name}/posts", ListSubredditPosts).Methods("GET")
+	r.HandleFunc("/api/subreddits/{name}/report-reasons", GetReportReasons).Methods("GET")
+	r.HandleFunc("/api/subreddits/{name}/report-reasons", SetReportReasons).Methods("PUT")
+	r.HandleFunc("/api/subreddits/{name}/modqueue", GetModQueue).Methods("GET")
+	r.HandleFunc("/api/subreddits/{name}/bans/{id:[0-9]+}", BanUser).Methods("PUT")
+	r.HandleFunc("/api/subreddits/{name}/bans/{id:[0-9]+}", UnbanUser).Methods("DELETE")
+	r.HandleFunc("/api/subreddits/{name}/automod", GetAutoModRules).Methods("GET")
+	r.HandleFunc("/api/subreddits/{name}/automod", SetAutoModRules).Methods("PUT")
+	r.HandleFunc("/api/subreddits/{name}/automod/test", TestAutoMod).Methods("POST")
+	r.HandleFunc("/api/subreddits/{name}/modqueue/{target:post|comment}/{id:[0-9]+}", ResolveReports).Methods("POST")
 	r.HandleFunc("/api/posts", CreatePost).Methods("POST")
+	r.HandleFunc("/api/posts/{id:[0-9]+}", EditPost).Methods("PUT")
+	r.HandleFunc("/api/posts/{id:[0-9]+}", DeletePost).Methods("DELETE")
+	r.HandleFunc("/api/posts/{id:[0-9]+}/flair", SetPostFlair).Methods("PUT")
+	r.HandleFunc("/api/posts/{id:[0-9]+}/crosspost", Crosspost).Methods("POST")
+	r.HandleFunc("/api/posts/{id:[0-9]+}/save", SavePost).Methods("POST")
+	r.HandleFunc("/api/posts/{id:[0-9]+}/unsave", UnsavePost).Methods("POST")
+	r.HandleFunc("/api/posts/{id:[0-9]+}/hide", HidePost).Methods("POST")
+	r.HandleFunc("/api/posts/{id:[0-9]+}/unhide", UnhidePost).Methods("POST")
 	r.HandleFunc("/api/comments", AddComment).Methods("POST")
+	r.HandleFunc("/api/comments/{id:[0-9]+}", EditComment).Methods("PUT")
+	r.HandleFunc("/api/comments/{id:[0-9]+}", DeleteComment).Methods("DELETE")
+	r.HandleFunc("/api/comments/{id:[0-9]+}/save", SaveComment).Methods("POST")
+	r.HandleFunc("/api/comments/{id:[0-9]+}/unsave", UnsaveComment).Methods("POST")
 	r.HandleFunc("/api/votes", VotePost).Methods("POST")
+	r.HandleFunc("/api/reports", ReportContent).Methods("POST")
+	r.HandleFunc("/api/domains/{domain}/posts", ListDomainPosts).Methods("GET")
+	r.HandleFunc("/api/media", UploadMedia).Methods("POST")
+	r.HandleFunc("/api/media/{id:[0-9a-f]+}", GetMedia).Methods("GET")
+	r.HandleFunc("/api/media/{id:[0-9a-f]+}/thumbnail", GetMediaThumbnail).Methods("GET")
 	r.HandleFunc("/api/users/karma", GetAllUsers).Methods("GET")
+	r.HandleFunc("/api/users/{id:[0-9]+}/notifications", GetNotifications).Methods("GET")
+	r.HandleFunc("/api/users/{id:[0-9]+}/notifications/read", MarkNotificationsRead).Methods("POST")
+	r.HandleFunc("/api/users/{id:[0-9]+}/saved", GetSaved).Methods("GET")
+	r.HandleFunc("/api/search", Search).Methods("GET")
+	r.HandleFunc("/api/spam-filter", GetSpamStats).Methods("GET")
+	r.HandleFunc("/api/admin/votes", GetVoteReport).Methods("GET")
+	r.HandleFunc("/api/stream", Stream).Methods("GET")
+	r.HandleFunc("/api/health", GetHealth).Methods("GET")
+	return r
+}
 
-	// Start REST API Server
-	go func() {
-		log.Println("Starting REST API server on :8080")
-		log.Fatal(http.ListenAndServe(":8080", r))
+// runRemoteSimulator runs only the interactive simulator, driving the engine node at
+// cfg.Remote.Engine instead of an engine in this process.
+func runRemoteSimulator(cfg config.Config) {
+	localAddr := cfg.Remote.Addr
+	if localAddr == "" {
+		localAddr = "127.0.0.1:0"
+	}
+	client, err := engine.DialRemote(localAddr, cfg.Remote.Engine, time.Duration(cfg.Engine.RequestTimeout), logger)
+	if err != nil {
+		logger.Error("failed to connect to engine", "engine", cfg.Remote.Engine, "error", err)
+		os.Exit(1)
+	}
+	defer client.Close()
+	logger.Info("connected to engine", "engine", cfg.Remote.Engine)
+	simulator.SimulateUsers(client, cfg.Simulator.APIBaseURL)
+}
+
+// runCluster runs cfg.Cluster.Nodes engine cluster members in place of the single-process
+// engine. Unless headless it drives them with the cluster simulation and prints the result;
+// the members keep serving until interrupted either way, for other processes in the cluster.
+// The members share one store, the state file with the file backend.
+func runCluster(cfg config.Config) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	host, portStr, err := net.SplitHostPort(cfg.Cluster.Addr)
+	if err != nil {
+		logger.Error("invalid cluster address", "addr", cfg.Cluster.Addr, "error", err)
+		os.Exit(1)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		logger.Error("invalid cluster port", "addr", cfg.Cluster.Addr, "error", err)
+		os.Exit(1)
+	}
+	var store engine.Store = engine.NewMemoryStore()
+	if cfg.Storage.Backend == "file" {
+		if store, err = engine.NewFileStore(cfg.Storage.Path); err != nil {
+			logger.Error("failed to open state file", "path", cfg.Storage.Path, "error", err)
+			os.Exit(1)
+		}
+	}
+	membership := engine.NewInProcessMembership()
+	var nodes []*engine.ClusterNode
+	for i := 0; i < cfg.Cluster.Nodes; i++ {
+		opts := engine.ClusterOptions{
+			Name:           cfg.Cluster.Name,
+			Addr:           net.JoinHostPort(host, "0"),
+			Provider:       membership.Provider(),
+			Store:          store,
+			RequestTimeout: time.Duration(cfg.Engine.RequestTimeout),
+			Logger:         logger,
+		}
+		if port != 0 {
+			opts.Addr = net.JoinHostPort(host, strconv.Itoa(port+i))
+		}
+		if cfg.Cluster.Dir != "" {
+			opts.Provider = engine.NewDirProvider(cfg.Cluster.Dir)
+		}
+		node, err := engine.StartClusterNode(opts)
+		if err != nil {
+			logger.Error("failed to start cluster member", "addr", opts.Addr, "error", err)
+			shutdownCluster(nodes)
+			os.Exit(1)
+		}
+		nodes = append(nodes, node)
+	}
+	defer func() {
+		shutdownCluster(nodes)
+		if err := store.Flush(); err != nil {
+			logger.Error("failed to save state", "path", cfg.Storage.Path, "error", err)
+		}
 	}()
 
-	// Start the interactive simulator
-	simulator.SimulateUsers(actorSystem)
+	if !cfg.Headless {
+		report := simulator.SimulateCluster(ctx, nodes, simulator.DefaultClusterWorkload())
+		logger.Info("cluster simulation finished", "requests", report.Requests, "failures", report.Failures, "elapsed", report.Elapsed, "per_node", report.PerNode)
+		for _, failure := range report.Errors {
+			logger.Warn("cluster simulation request failed", "error", failure)
+		}
+	}
+	logger.Info("cluster members running", "members", nodes[0].Members())
+	<-ctx.Done()
+	logger.Info("shutting down cluster members")
+}
+
+// shutdownCluster stops the members together; each waits a moment for its grains to move on
+func shutdownCluster(nodes []*engine.ClusterNode) {
+	var wg sync.WaitGroup
+	for _, node := range nodes {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			node.Shutdown(true)
+		}()
+	}
+	wg.Wait()
+}
+
+// runSpamDataset exports the spam filter's training decisions from the state file, or retrains
+// the filter from a dataset and saves it there. The server must not be running, or it would
+// overwrite the state file on its next flush.
+func runSpamDataset(cfg config.Config) error {
+	store, err := engine.NewFileStore(cfg.Storage.Path)
+	if err != nil {
+		return fmt.Errorf("opening state file: %w", err)
+	}
+	if cfg.Spam.Export != "" {
+		examples := store.SpamExamples()
+		if cfg.Spam.Export == "-" {
+			return engine.WriteSpamExamples(os.Stdout, examples)
+		}
+		file, err := os.Create(cfg.Spam.Export)
+		if err != nil {
+			return err
+		}
+		if err := engine.WriteSpamExamples(file, examples); err != nil {
+			file.Close()
+			return err
+		}
+		logger.Info("spam dataset exported", "path", cfg.Spam.Export, "examples", len(examples))
+		return file.Close()
+	}
+
+	file, err := os.Open(cfg.Spam.Train)
+	if err != nil {
+		return err
+	}
+	examples, err := engine.ReadSpamExamples(file)
+	file.Close()
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", cfg.Spam.Train, err)
+	}
+	model := engine.TrainSpamModel(examples)
+	store.SaveSpamModel(model)
+	store.SaveSpamExamples(examples)
+	if err := store.Flush(); err != nil {
+		return fmt.Errorf("saving state file: %w", err)
+	}
+	logger.Info("spam filter retrained", "path", cfg.Spam.Train, "spam_documents", model.Spam.Documents, "ham_documents", model.Ham.Documents, "vocabulary", model.Vocabulary)
+	return nil
+}
+
+// requestLogging tags every request with an ID, taken from X-Request-ID when the client
+// supplies one, and logs the request once it has been served.
+func requestLogging(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get("X-Request-ID")
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		w.Header().Set("X-Request-ID", requestID)
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), requestIDKey, requestID)))
+
+		logger.Info("http request",
+			"request_id", requestID,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.status,
+			"duration", time.Since(start),
+		)
+	})
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush streams.
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// requestTracing continues any trace started by the client (W3C traceparent header) and
+// opens a server span whose context is handed to the actor system.
+func requestTracing(next http.Handler) http.Handler {
+	tracer := otel.Tracer("reddit_clone2")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if tmpl, err := current.GetPathTemplate(); err == nil {
+				route = tmpl
+			}
+		}
+		ctx, span := tracer.Start(ctx, r.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.method", r.Method),
+				attribute.String("http.route", route),
+				attribute.String("request_id", requestID(r)),
+			),
+		)
+		defer span.End()
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+		span.SetAttributes(attribute.Int("http.status_code", rec.status))
+	})
+}
+
+// clientIP is the address a request came from, without the port
+func clientIP(r *http.Request) string {
+	if ip, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return ip
+	}
+	return r.RemoteAddr
+}
+
+// rateLimiting enforces the per-action write limits, once per client IP and once per user.
+// New and low-karma accounts get tighter limits. Throttled requests get 429 with Retry-After.
+// There is no authentication yet, so the user limit is keyed on the UserID (or CreatorID) the
+// client puts in the body; a client that lies about it is only held back by the IP limit.
+// Multipart uploads are not peeked at, UploadMedia checks the user limit once it has the form.
+func rateLimiting(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		action, limited := "", false
+		if route := mux.CurrentRoute(r); route != nil && r.Method == http.MethodPost {
+			if tmpl, err := route.GetPathTemplate(); err == nil {
+				action, limited = rateLimitedRoutes[tmpl]
+			}
+		}
+		if !settings.RateLimit.Enabled || !limited {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ip := clientIP(r)
+		if retryAfter, key, value, ok := allowWrite(r.Context(), requestID(r), action, ip, 0); !ok {
+			tooManyRequests(w, r, retryAfter, key, value)
+			return
+		}
+
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// Peek at the body for the acting user and put it back for the handler
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, settings.HTTP.MaxBodyBytes))
+		if err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		var author struct{ UserID, CreatorID int }
+		if json.Unmarshal(body, &author) == nil && max(author.UserID, author.CreatorID) > 0 {
+			if retryAfter, key, value, ok := allowWrite(r.Context(), requestID(r), action, "", max(author.UserID, author.CreatorID)); !ok {
+				tooManyRequests(w, r, retryAfter, key, value)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// allowWrite takes a token from the client IP's bucket for action, when ip is set, and from
+// the user's, when userID is set. New and low-karma accounts get the restricted limits.
+// A throttled write reports how long to wait and which bucket ran out.
+func allowWrite(ctx context.Context, requestID, action, ip string, userID int) (retryAfter time.Duration, key string, value interface{}, ok bool) {
+	if ip != "" {
+		if ok, retryAfter := limiter.Allow("ip:"+action+":"+ip, settings.RateLimit.PerMinute(action, false), settings.RateLimit.Burst); !ok {
+			return retryAfter, "ip", ip, false
+		}
+	}
+	if userID != 0 {
+		restricted := true // unknown users are treated like new accounts
+		if user, found := actorSystem.GetUser(ctx, engine.GetUser{UserID: userID, RequestID: requestID}); found {
+			restricted = time.Since(user.CreatedAt) < time.Duration(settings.RateLimit.NewAccountAge) || user.Karma < settings.RateLimit.LowKarma
+		}
+		bucket := "user:" + action + ":" + strconv.Itoa(userID)
+		if ok, retryAfter := limiter.Allow(bucket, settings.RateLimit.PerMinute(action, restricted), settings.RateLimit.BurstFor(restricted)); !ok {
+			return retryAfter, "user_id", userID, false
+		}
+	}
+	return 0, "", nil, true
+}
+
+func tooManyRequests(w http.ResponseWriter, r *http.Request, retryAfter time.Duration, key string, value interface{}) {
+	logger.Warn("rate limited", "request_id", requestID(r), "path", r.URL.Path, key, value, "retry_after", retryAfter)
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+}
+
+// jsonResponses labels responses as JSON unless the handler says otherwise, as http.Error and
+// the event stream do.
+func jsonResponses(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		next.ServeHTTP(w, r)
+	})
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func requestID(r *http.Request) string {
+	id, _ := r.Context().Value(requestIDKey).(string)
+	return id
+}
+
+// decodeBody decodes the JSON request body into v, answering 400 when it is malformed
+// or larger than the configured limit.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	r.Body = http.MaxBytesReader(w, r.Body, settings.HTTP.MaxBodyBytes)
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		logger.Warn("invalid request body", "request_id", requestID(r), "path", r.URL.Path, "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// checkContent answers 400 when post or comment content exceeds the configured limit.
+func checkContent(w http.ResponseWriter, r *http.Request, content string) bool {
+	if len(content) > settings.Engine.MaxContentLength {
+		logger.Warn("content too long", "request_id", requestID(r), "path", r.URL.Path, "length", len(content))
+		http.Error(w, "content too long", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// pathID reads the numeric {id} route variable.
+func pathID(r *http.Request) int {
+	id, _ := strconv.Atoi(mux.Vars(r)["id"])
+	return id
+}
+
+// queryInt reads a numeric query parameter, falling back to def when absent or malformed.
+func queryInt(r *http.Request, name string, def int) int {
+	if value, err := strconv.Atoi(r.URL.Query().Get(name)); err == nil {
+		return value
+	}
+	return def
+}
+
+// maxQueryPage bounds the page query parameter; no listing comes near this many pages.
+const maxQueryPage = 1 << 20
+
+// queryPage reads the 1-based page query parameter, clamped to [1, maxQueryPage].
+func queryPage(r *http.Request) int {
+	return min(max(queryInt(r, "page", 1), 1), maxQueryPage)
 }
 
 // REST API Handlers
 func RegisterUser(w http.ResponseWriter, r *http.Request) {
 	var user engine.RegisterUser
-	json.NewDecoder(r.Body).Decode(&user)
-	actorSystem.RegisterUser(user)
+	if !decodeBody(w, r, &user) {
+		return
+	}
+	user.RequestID = requestID(r)
+	actorSystem.RegisterUser(r.Context(), user)
 	w.WriteHeader(http.StatusCreated)
 }
 
 func CreateSubreddit(w http.ResponseWriter, r *http.Request) {
 	var subreddit engine.CreateSubreddit
-	json.NewDecoder(r.Body).Decode(&subreddit)
-	actorSystem.CreateSubreddit(subreddit)
+	if !decodeBody(w, r, &subreddit) {
+		return
+	}
+	subreddit.RequestID = requestID(r)
+	actorSystem.CreateSubreddit(r.Context(), subreddit)
 	w.WriteHeader(http.StatusCreated)
 }
 
+func JoinSubreddit(w http.ResponseWriter, r *http.Request) {
+	var join engine.JoinSubreddit
+	if !decodeBody(w, r, &join) {
+		return
+	}
+	join.Name = mux.Vars(r)["name"]
+	join.RequestID = requestID(r)
+	actorSystem.JoinSubreddit(r.Context(), join)
+	w.WriteHeader(http.StatusOK)
+}
+
+func LeaveSubreddit(w http.ResponseWriter, r *http.Request) {
+	var leave engine.LeaveSubreddit
+	if !decodeBody(w, r, &leave) {
+		return
+	}
+	leave.Name = mux.Vars(r)["name"]
+	leave.RequestID = requestID(r)
+	actorSystem.LeaveSubreddit(r.Context(), leave)
+	w.WriteHeader(http.StatusOK)
+}
+
+// ListSubreddits answers GET /api/subreddits?sort=members|activity|newest&page=&page_size=
+func ListSubreddits(w http.ResponseWriter, r *http.Request) {
+	order := r.URL.Query().Get("sort")
+	switch order {
+	case "":
+		order = engine.SortMembers
+	case engine.SortMembers, engine.SortActivity, engine.SortNewest:
+	default:
+		http.Error(w, "sort must be members, activity or newest", http.StatusBadRequest)
+		return
+	}
+	summaries, err := actorSystem.ListSubreddits(r.Context(), engine.ListSubreddits{
+		Sort:      order,
+		Page:      queryPage(r),
+		PageSize:  queryInt(r, "page_size", engine.DefaultListPageSize),
+		RequestID: requestID(r),
+	})
+	writeListing(w, summaries, err)
+}
+
+// AutocompleteSubreddits answers GET /api/subreddits/autocomplete?prefix=&limit=
+func AutocompleteSubreddits(w http.ResponseWriter, r *http.Request) {
+	summaries, err := actorSystem.AutocompleteSubreddits(r.Context(), engine.AutocompleteSubreddits{
+		Prefix:    r.URL.Query().Get("prefix"),
+		Limit:     queryInt(r, "limit", 10),
+		RequestID: requestID(r),
+	})
+	writeListing(w, summaries, err)
+}
+
+// TrendingSubreddits answers GET /api/subreddits/trending?limit=
+func TrendingSubreddits(w http.ResponseWriter, r *http.Request) {
+	summaries, err := actorSystem.TrendingSubreddits(r.Context(), engine.GetTrendingSubreddits{
+		Limit:     queryInt(r, "limit", 10),
+		RequestID: requestID(r),
+	})
+	writeListing(w, summaries, err)
+}
+
+func writeListing(w http.ResponseWriter, summaries []engine.SubredditSummary, err error) {
+	if err != nil {
+		http.Error(w, "listing subreddits failed", http.StatusInternalServerError)
+		return
+	}
+	if summaries == nil {
+		summaries = []engine.SubredditSummary{}
+	}
+	json.NewEncoder(w).Encode(summaries)
+}
+
 func CreatePost(w http.ResponseWriter, r *http.Request) {
 	var post engine.PostMessage
-	json.NewDecoder(r.Body).Decode(&post)
-	actorSystem.CreatePost(post)
+	if !decodeBody(w, r, &post) || !checkContent(w, r, post.Content) {
+		return
+	}
+	if err := engine.ValidatePost(&post); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	post.RequestID = requestID(r)
+	if err := actorSystem.CheckPoster(r.Context(), post.Subreddit, post.UserID, post.RequestID); err != nil {
+		if errors.Is(err, engine.ErrBanned) {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		http.Error(w, "checking ban failed", http.StatusInternalServerError)
+		return
+	}
+	if err := actorSystem.AttachMedia(r.Context(), &post); err != nil {
+		if errors.Is(err, engine.ErrNoSuchMedia) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "attaching media failed", http.StatusInternalServerError)
+		return
+	}
+	if err := actorSystem.AttachFlair(r.Context(), &post); err != nil {
+		switch {
+		case errors.Is(err, engine.ErrNoSuchFlair), errors.Is(err, engine.ErrNoSuchSubreddit):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, engine.ErrNotModerator):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		default:
+			http.Error(w, "attaching flair failed", http.StatusInternalServerError)
+		}
+		return
+	}
+	if post.Kind == engine.PostLink {
+		existing, err := actorSystem.FindLink(r.Context(), engine.FindLink{Subreddit: post.Subreddit, URL: post.URL, RequestID: post.RequestID})
+		if err != nil {
+			http.Error(w, "checking link failed", http.StatusInternalServerError)
+			return
+		}
+		if existing != 0 {
+			http.Error(w, fmt.Sprintf("%v as post %d", engine.ErrDuplicateLink, existing), http.StatusConflict)
+			return
+		}
+	}
+	actorSystem.CreatePost(r.Context(), post)
 	w.WriteHeader(http.StatusCreated)
 }
 
+// ListDomainPosts answers GET /api/domains/{domain}/posts?user=&page=&page_size=, leaving out
+// posts the user hid.
+func ListDomainPosts(w http.ResponseWriter, r *http.Request) {
+	posts, err := actorSystem.DomainPosts(r.Context(), engine.GetDomainPosts{
+		Domain:    mux.Vars(r)["domain"],
+		UserID:    queryInt(r, "user", 0),
+		Page:      queryPage(r),
+		PageSize:  queryInt(r, "page_size", engine.DefaultFeedPageSize),
+		RequestID: requestID(r),
+	})
+	if err != nil {
+		http.Error(w, "listing domain posts failed", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(posts)
+}
+
+// Crosspost answers POST /api/posts/{id}/crosspost with the new post in the target subreddit.
+func Crosspost(w http.ResponseWriter, r *http.Request) {
+	var crosspost engine.Crosspost
+	if !decodeBody(w, r, &crosspost) {
+		return
+	}
+	crosspost.PostID = pathID(r)
+	crosspost.RequestID = requestID(r)
+	post, err := actorSystem.Crosspost(r.Context(), crosspost)
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(post)
+	case errors.Is(err, engine.ErrInvalidCrosspost):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, engine.ErrNotMember), errors.Is(err, engine.ErrBanned):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, engine.ErrNoSuchPost), errors.Is(err, engine.ErrNoSuchSubreddit):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, engine.ErrDuplicateLink):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, "crossposting failed", http.StatusInternalServerError)
+	}
+}
+
+// ListSubredditPosts answers GET /api/subreddits/{name}/posts?flair=&user=&page=&page_size=,
+// the subreddit's posts newest first, optionally only those with one post flair. Posts the
+// user hid are left out.
+func ListSubredditPosts(w http.ResponseWriter, r *http.Request) {
+	posts, err := actorSystem.Feed(r.Context(), engine.GetFeed{
+		UserID:     queryInt(r, "user", 0),
+		Subreddits: []string{mux.Vars(r)["name"]},
+		FlairID:    queryInt(r, "flair", 0),
+		Page:       queryPage(r),
+		PageSize:   queryInt(r, "page_size", engine.DefaultFeedPageSize),
+		RequestID:  requestID(r),
+	})
+	if err != nil {
+		http.Error(w, "listing subreddit posts failed", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(posts)
+}
+
 func AddComment(w http.ResponseWriter, r *http.Request) {
 	var comment engine.CommentMessage
-	json.NewDecoder(r.Body).Decode(&comment)
-	actorSystem.AddComment(comment)
+	if !decodeBody(w, r, &comment) || !checkContent(w, r, comment.Content) {
+		return
+	}
+	comment.RequestID = requestID(r)
+	actorSystem.AddComment(r.Context(), comment)
 	w.WriteHeader(http.StatusCreated)
 }
 
+func EditPost(w http.ResponseWriter, r *http.Request) {
+	var edit engine.EditPost
+	if !decodeBody(w, r, &edit) || !checkContent(w, r, edit.Content) {
+		return
+	}
+	edit.PostID = pathID(r)
+	edit.RequestID = requestID(r)
+	actorSystem.EditPost(r.Context(), edit)
+	w.WriteHeader(http.StatusOK)
+}
+
+func DeletePost(w http.ResponseWriter, r *http.Request) {
+	var del engine.DeletePost
+	if !decodeBody(w, r, &del) {
+		return
+	}
+	del.PostID = pathID(r)
+	del.RequestID = requestID(r)
+	actorSystem.DeletePost(r.Context(), del)
+	w.WriteHeader(http.StatusOK)
+}
+
+func EditComment(w http.ResponseWriter, r *http.Request) {
+	var edit engine.EditComment
+	if !decodeBody(w, r, &edit) || !checkContent(w, r, edit.Content) {
+		return
+	}
+	edit.CommentID = pathID(r)
+	edit.RequestID = requestID(r)
+	actorSystem.EditComment(r.Context(), edit)
+	w.WriteHeader(http.StatusOK)
+}
+
+func DeleteComment(w http.ResponseWriter, r *http.Request) {
+	var del engine.DeleteComment
+	if !decodeBody(w, r, &del) {
+		return
+	}
+	del.CommentID = pathID(r)
+	del.RequestID = requestID(r)
+	actorSystem.DeleteComment(r.Context(), del)
+	w.WriteHeader(http.StatusOK)
+}
+
 func VotePost(w http.ResponseWriter, r *http.Request) {
 	var vote engine.Vote
-	json.NewDecoder(r.Body).Decode(&vote)
-	actorSystem.VotePost(vote)
+	if !decodeBody(w, r, &vote) {
+		return
+	}
+	vote.RequestID = requestID(r)
+	vote.Fingerprint = engine.ClientFingerprint(clientIP(r), r.UserAgent())
+	actorSystem.VotePost(r.Context(), vote)
 	w.WriteHeader(http.StatusOK)
 }
 
 func GetAllUsers(w http.ResponseWriter, r *http.Request) {
-	users := actorSystem.GetAllUsers()
+	users := actorSystem.GetAllUsers(r.Context(), engine.GetAllUsers{RequestID: requestID(r)})
 	json.NewEncoder(w).Encode(users)
 }
+
+func GetHealth(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(actorSystem.Health())
+}
+
+// GetSpamStats answers GET /api/spam-filter with the spam filter's settings and how much it has learned.
+func GetSpamStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := actorSystem.SpamStats(r.Context(), requestID(r))
+	if err != nil {
+		http.Error(w, "fetching spam filter stats failed", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(stats)
+}
+
+// GetVoteReport answers GET /api/admin/votes?user= with what vote analysis found, for admins.
+func GetVoteReport(w http.ResponseWriter, r *http.Request) {
+	report, err := actorSystem.VoteReport(r.Context(), engine.GetVoteReport{UserID: queryInt(r, "user", 0), RequestID: requestID(r)})
+	switch {
+	case errors.Is(err, engine.ErrNotAdmin):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case err != nil:
+		http.Error(w, "fetching vote report failed", http.StatusInternalServerError)
+	default:
+		json.NewEncoder(w).Encode(report)
+	}
+}
+
+// Search answers GET /api/search?q=...&page=&page_size= with ranked posts and comments.
+func Search(w http.ResponseWriter, r *http.Request) {
+	search := engine.Search{
+		Query:     engine.ParseSearchQuery(r.URL.Query().Get("q")),
+		Page:      queryPage(r),
+		PageSize:  queryInt(r, "page_size", engine.DefaultSearchPageSize),
+		RequestID: requestID(r),
+	}
+	if search.Query.Type != "" && search.Query.Type != "post" && search.Query.Type != "comment" {
+		http.Error(w, "type must be post or comment", http.StatusBadRequest)
+		return
+	}
+	if search.Query.Author != "" {
+		author, found := actorSystem.GetUser(r.Context(), engine.GetUser{Username: search.Query.Author, RequestID: search.RequestID})
+		if !found {
+			json.NewEncoder(w).Encode(engine.SearchResults{Page: search.Page, PageSize: search.PageSize, Hits: []engine.SearchHit{}})
+			return
+		}
+		search.AuthorID = author.ID
+	}
+
+	results, err := actorSystem.Search(r.Context(), search)
+	if err != nil {
+		http.Error(w, "search failed", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(results)
+}
+
+// GetNotifications answers GET /api/users/{id}/notifications?unread=true&page=&page_size=
+func GetNotifications(w http.ResponseWriter, r *http.Request) {
+	page, err := actorSystem.Notifications(r.Context(), engine.GetNotifications{
+		UserID:     pathID(r),
+		UnreadOnly: r.URL.Query().Get("unread") == "true",
+		Page:       queryPage(r),
+		PageSize:   queryInt(r, "page_size", engine.DefaultNotificationPageSize),
+		RequestID:  requestID(r),
+	})
+	if err != nil {
+		http.Error(w, "fetching notifications failed", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(page)
+}
+
+// GetSaved answers GET /api/users/{id}/saved?type=&page=&page_size= with the posts and
+// comments the user saved, most recently saved first.
+func GetSaved(w http.ResponseWriter, r *http.Request) {
+	itemType := r.URL.Query().Get("type")
+	if itemType != "" && itemType != engine.SavedPost && itemType != engine.SavedComment {
+		http.Error(w, "type must be post or comment", http.StatusBadRequest)
+		return
+	}
+	saved, err := actorSystem.Saved(r.Context(), engine.GetSaved{
+		UserID:    pathID(r),
+		Type:      itemType,
+		Page:      queryPage(r),
+		PageSize:  queryInt(r, "page_size", engine.DefaultFeedPageSize),
+		RequestID: requestID(r),
+	})
+	if err != nil {
+		http.Error(w, "fetching saved items failed", http.StatusInternalServerError)
+		return
+	}
+	json.NewEncoder(w).Encode(saved)
+}
+
+func SavePost(w http.ResponseWriter, r *http.Request)    { saveItem(w, r, engine.SavedPost) }
+func SaveComment(w http.ResponseWriter, r *http.Request) { saveItem(w, r, engine.SavedComment) }
+
+func UnsavePost(w http.ResponseWriter, r *http.Request)    { unsaveItem(w, r, engine.SavedPost) }
+func UnsaveComment(w http.ResponseWriter, r *http.Request) { unsaveItem(w, r, engine.SavedComment) }
+
+func saveItem(w http.ResponseWriter, r *http.Request, itemType string) {
+	var save engine.SaveItem
+	if !decodeBody(w, r, &save) {
+		return
+	}
+	save.Type = itemType
+	save.ID = pathID(r)
+	save.RequestID = requestID(r)
+	if err := actorSystem.SaveItem(r.Context(), save); err != nil {
+		savedError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func unsaveItem(w http.ResponseWriter, r *http.Request, itemType string) {
+	var unsave engine.UnsaveItem
+	if !decodeBody(w, r, &unsave) {
+		return
+	}
+	unsave.Type = itemType
+	unsave.ID = pathID(r)
+	unsave.RequestID = requestID(r)
+	actorSystem.UnsaveItem(r.Context(), unsave)
+	w.WriteHeader(http.StatusOK)
+}
+
+func HidePost(w http.ResponseWriter, r *http.Request) {
+	var hide engine.HidePost
+	if !decodeBody(w, r, &hide) {
+		return
+	}
+	hide.PostID = pathID(r)
+	hide.RequestID = requestID(r)
+	if err := actorSystem.HidePost(r.Context(), hide); err != nil {
+		savedError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func UnhidePost(w http.ResponseWriter, r *http.Request) {
+	var unhide engine.UnhidePost
+	if !decodeBody(w, r, &unhide) {
+		return
+	}
+	unhide.PostID = pathID(r)
+	unhide.RequestID = requestID(r)
+	actorSystem.UnhidePost(r.Context(), unhide)
+	w.WriteHeader(http.StatusOK)
+}
+
+func savedError(w http.ResponseWriter, err error) {
+	if errors.Is(err, engine.ErrNoSuchPost) || errors.Is(err, engine.ErrNoSuchComment) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, "saving failed", http.StatusInternalServerError)
+}
+
+// MarkNotificationsRead marks the listed notification IDs read, or all of them when IDs is empty.
+func MarkNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	var mark engine.MarkNotificationsRead
+	if r.ContentLength != 0 && !decodeBody(w, r, &mark) {
+		return
+	}
+	mark.UserID = pathID(r)
+	mark.RequestID = requestID(r)
+	actorSystem.MarkNotificationsRead(r.Context(), mark)
+	w.WriteHeader(http.StatusOK)
+}
+
+// Stream answers GET /api/stream?subreddit=&post=&user= with Server-Sent Events for every
+// matching engine change. user= follows that user's karma and notifications. A client that
+// falls more than -stream-buffer events behind gets an "overflow" event and is disconnected.
+func Stream(w http.ResponseWriter, r *http.Request) {
+	filter := engine.StreamFilter{
+		Subreddit: r.URL.Query().Get("subreddit"),
+		PostID:    queryInt(r, "post", 0),
+		UserID:    queryInt(r, "user", 0),
+	}
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		logger.Error("live stream not supported", "request_id", requestID(r), "error", err)
+		return
+	}
+
+	sub := actorSystem.Subscribe(filter, settings.HTTP.StreamBuffer)
+	defer sub.Close()
+	logger.Info("live stream opened", "request_id", requestID(r), "subreddit", filter.Subreddit, "post_id", filter.PostID, "user_id", filter.UserID)
+
+	heartbeat := time.NewTicker(time.Duration(settings.HTTP.StreamHeartbeat))
+	defer heartbeat.Stop()
+	for id := 1; ; id++ {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-streams.Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case event, open := <-sub.Events:
+			if !open {
+				if sub.Overflowed() {
+					logger.Warn("live stream client fell behind, disconnecting", "request_id", requestID(r))
+					fmt.Fprint(w, "event: overflow\ndata: {}\n\n")
+					rc.Flush()
+				}
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				logger.Error("encoding live event failed", "request_id", requestID(r), "error", err)
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event.Type, data)
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// RegisterWebhook answers POST /api/subreddits/{name}/webhooks with the new webhook, including
+// the secret its deliveries are signed with. Only the subreddit's moderators may register one.
+func RegisterWebhook(w http.ResponseWriter, r *http.Request) {
+	var register engine.RegisterWebhook
+	if !decodeBody(w, r, &register) {
+		return
+	}
+	register.Subreddit = mux.Vars(r)["name"]
+	register.RequestID = requestID(r)
+	webhook, err := actorSystem.RegisterWebhook(r.Context(), register)
+	if err != nil {
+		webhookError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(webhook)
+}
+
+// ListWebhooks answers GET /api/subreddits/{name}/webhooks?user= for a moderator.
+func ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := actorSystem.ListWebhooks(r.Context(), engine.ListWebhooks{
+		Subreddit: mux.Vars(r)["name"],
+		UserID:    queryInt(r, "user", 0),
+		RequestID: requestID(r),
+	})
+	if err != nil {
+		webhookError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(webhooks)
+}
+
+func DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	var del engine.DeleteWebhook
+	if !decodeBody(w, r, &del) {
+		return
+	}
+	del.Subreddit = mux.Vars(r)["name"]
+	del.WebhookID = pathID(r)
+	del.RequestID = requestID(r)
+	if err := actorSystem.DeleteWebhook(r.Context(), del); err != nil {
+		webhookError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func CreateFlair(w http.ResponseWriter, r *http.Request) {
+	var create engine.CreateFlair
+	if !decodeBody(w, r, &create) {
+		return
+	}
+	create.Subreddit = mux.Vars(r)["name"]
+	create.RequestID = requestID(r)
+	flair, err := actorSystem.CreateFlair(r.Context(), create)
+	if err != nil {
+		flairError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(flair)
+}
+
+// ListFlairs answers GET /api/subreddits/{name}/flairs?type=, where type is post or user.
+func ListFlairs(w http.ResponseWriter, r *http.Request) {
+	flairs, err := actorSystem.ListFlairs(r.Context(), engine.ListFlairs{
+		Subreddit: mux.Vars(r)["name"],
+		Type:      r.URL.Query().Get("type"),
+		RequestID: requestID(r),
+	})
+	if err != nil {
+		flairError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(flairs)
+}
+
+func DeleteFlair(w http.ResponseWriter, r *http.Request) {
+	var del engine.DeleteFlair
+	if !decodeBody(w, r, &del) {
+		return
+	}
+	del.Subreddit = mux.Vars(r)["name"]
+	del.FlairID = pathID(r)
+	del.RequestID = requestID(r)
+	if err := actorSystem.DeleteFlair(r.Context(), del); err != nil {
+		flairError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// SetUserFlair answers PUT /api/subreddits/{name}/flair, where a user picks their flair in
+// the subreddit, or clears it with FlairID 0.
+func SetUserFlair(w http.ResponseWriter, r *http.Request) {
+	var set engine.SetUserFlair
+	if !decodeBody(w, r, &set) {
+		return
+	}
+	set.Subreddit = mux.Vars(r)["name"]
+	set.RequestID = requestID(r)
+	if err := actorSystem.SetUserFlair(r.Context(), set); err != nil {
+		flairError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func SetPostFlair(w http.ResponseWriter, r *http.Request) {
+	var set engine.SetPostFlair
+	if !decodeBody(w, r, &set) {
+		return
+	}
+	set.PostID = pathID(r)
+	set.RequestID = requestID(r)
+	if err := actorSystem.SetPostFlair(r.Context(), set); err != nil {
+		flairError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func flairError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, engine.ErrInvalidFlair):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, engine.ErrNotModerator):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, engine.ErrNoSuchSubreddit), errors.Is(err, engine.ErrNoSuchFlair), errors.Is(err, engine.ErrNoSuchPost):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "managing flair failed", http.StatusInternalServerError)
+	}
+}
+
+func GetReportReasons(w http.ResponseWriter, r *http.Request) {
+	reasons, err := actorSystem.ReportReasons(r.Context(), mux.Vars(r)["name"], requestID(r))
+	if err != nil {
+		reportError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(reasons)
+}
+
+// SetReportReasons answers PUT /api/subreddits/{name}/report-reasons, where a moderator
+// replaces the reasons users may report content for; an empty list restores the defaults.
+func SetReportReasons(w http.ResponseWriter, r *http.Request) {
+	var set engine.SetReportReasons
+	if !decodeBody(w, r, &set) {
+		return
+	}
+	set.Subreddit = mux.Vars(r)["name"]
+	set.RequestID = requestID(r)
+	if err := actorSystem.SetReportReasons(r.Context(), set); err != nil {
+		reportError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// ReportContent flags a post or comment for the moderators of its subreddit. Reporting an
+// item twice, or one the moderators chose to ignore, is accepted but not counted.
+func ReportContent(w http.ResponseWriter, r *http.Request) {
+	var report engine.ReportContent
+	if !decodeBody(w, r, &report) {
+		return
+	}
+	report.RequestID = requestID(r)
+	if err := actorSystem.ReportContent(r.Context(), report); err != nil {
+		reportError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// GetModQueue answers GET /api/subreddits/{name}/modqueue?user=&page=&page_size= with the
+// reported items awaiting the moderators, most reported first.
+func GetModQueue(w http.ResponseWriter, r *http.Request) {
+	queue, err := actorSystem.ModQueue(r.Context(), engine.GetModQueue{
+		Subreddit: mux.Vars(r)["name"],
+		UserID:    queryInt(r, "user", 0),
+		Page:      queryPage(r),
+		PageSize:  queryInt(r, "page_size", engine.DefaultFeedPageSize),
+		RequestID: requestID(r),
+	})
+	if err != nil {
+		reportError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(queue)
+}
+
+// ResolveReports answers POST /api/subreddits/{name}/modqueue/{target}/{id}, where a
+// moderator approves, removes or ignores a reported item.
+func ResolveReports(w http.ResponseWriter, r *http.Request) {
+	var resolve engine.ResolveReports
+	if !decodeBody(w, r, &resolve) {
+		return
+	}
+	resolve.Subreddit = mux.Vars(r)["name"]
+	resolve.Target = mux.Vars(r)["target"]
+	resolve.ID = pathID(r)
+	resolve.RequestID = requestID(r)
+	if err := actorSystem.ResolveReports(r.Context(), resolve); err != nil {
+		reportError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetAutoModRules answers GET /api/subreddits/{name}/automod?user= with the subreddit's
+// AutoModerator rules, for its moderators.
+func GetAutoModRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := actorSystem.AutoModRules(r.Context(), mux.Vars(r)["name"], queryInt(r, "user", 0), requestID(r))
+	if err != nil {
+		automodError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(rules)
+}
+
+// SetAutoModRules answers PUT /api/subreddits/{name}/automod?user=, whose body is the new rules
+// document, a YAML or JSON list of rules.
+func SetAutoModRules(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, settings.HTTP.MaxBodyBytes))
+	if err != nil {
+		logger.Warn("invalid request body", "request_id", requestID(r), "path", r.URL.Path, "error", err)
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	rules, err := engine.ParseAutoModRules(data)
+	if err != nil {
+		automodError(w, err)
+		return
+	}
+	err = actorSystem.SetAutoModRules(r.Context(), engine.SetAutoModRules{
+		Subreddit: mux.Vars(r)["name"],
+		UserID:    queryInt(r, "user", 0),
+		Rules:     rules,
+		RequestID: requestID(r),
+	})
+	if err != nil {
+		automodError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// TestAutoMod is a dry run of the subreddit's AutoModerator rules, or of the rules in the
+// request, against a post or comment; nothing is posted.
+func TestAutoMod(w http.ResponseWriter, r *http.Request) {
+	var test engine.TestAutoMod
+	if !decodeBody(w, r, &test) {
+		return
+	}
+	test.Subreddit = mux.Vars(r)["name"]
+	test.RequestID = requestID(r)
+	verdict, err := actorSystem.TestAutoMod(r.Context(), test)
+	if err != nil {
+		automodError(w, err)
+		return
+	}
+	json.NewEncoder(w).Encode(verdict)
+}
+
+// BanUser answers PUT /api/subreddits/{name}/bans/{id}, where a moderator bars the user
+// from posting, crossposting and joining.
+func BanUser(w http.ResponseWriter, r *http.Request) {
+	var ban engine.BanUser
+	if !decodeBody(w, r, &ban) {
+		return
+	}
+	ban.Subreddit = mux.Vars(r)["name"]
+	ban.BannedID = pathID(r)
+	ban.RequestID = requestID(r)
+	if err := actorSystem.BanUser(r.Context(), ban); err != nil {
+		banError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// UnbanUser answers DELETE /api/subreddits/{name}/bans/{id}, lifting a ban.
+func UnbanUser(w http.ResponseWriter, r *http.Request) {
+	var unban engine.UnbanUser
+	if !decodeBody(w, r, &unban) {
+		return
+	}
+	unban.Subreddit = mux.Vars(r)["name"]
+	unban.BannedID = pathID(r)
+	unban.RequestID = requestID(r)
+	if err := actorSystem.UnbanUser(r.Context(), unban); err != nil {
+		banError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func banError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, engine.ErrInvalidBan):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, engine.ErrNotModerator):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, engine.ErrNoSuchSubreddit):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "managing bans failed", http.StatusInternalServerError)
+	}
+}
+
+func automodError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, engine.ErrInvalidAutoMod):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, engine.ErrNotModerator):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, engine.ErrNoSuchSubreddit):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "managing AutoModerator failed", http.StatusInternalServerError)
+	}
+}
+
+func reportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, engine.ErrInvalidReport):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, engine.ErrNotModerator):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, engine.ErrNoSuchSubreddit), errors.Is(err, engine.ErrNoSuchPost), errors.Is(err, engine.ErrNoSuchComment), errors.Is(err, engine.ErrNoSuchReport):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "reporting failed", http.StatusInternalServerError)
+	}
+}
+
+func webhookError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, engine.ErrInvalidWebhook):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, engine.ErrNotModerator):
+		http.Error(w, err.Error(), http.StatusForbidden)
+	case errors.Is(err, engine.ErrNoSuchSubreddit), errors.Is(err, engine.ErrNoSuchWebhook):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, "managing webhooks failed", http.StatusInternalServerError)
+	}
+}
+
+// UploadMedia answers POST /api/media, a multipart form with the uploader's UserID and the
+// upload in a "file" field, with the stored Media. Attach it to a post through MediaIDs.
+func UploadMedia(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, settings.Media.MaxBytes+1<<20) // room for the rest of the form
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			mediaError(w, engine.ErrMediaTooLarge)
+			return
+		}
+		http.Error(w, "invalid multipart form", http.StatusBadRequest)
+		return
+	}
+	defer r.MultipartForm.RemoveAll()
+
+	userID, err := strconv.Atoi(r.FormValue("UserID"))
+	if err != nil || userID <= 0 {
+		http.Error(w, "UserID is required", http.StatusBadRequest)
+		return
+	}
+	if settings.RateLimit.Enabled {
+		if retryAfter, key, value, ok := allowWrite(r.Context(), requestID(r), "upload", "", userID); !ok {
+			tooManyRequests(w, r, retryAfter, key, value)
+			return
+		}
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "file is required", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	media, err := actorSystem.UploadMedia(r.Context(), engine.UploadMedia{
+		UserID:      userID,
+		ContentType: header.Header.Get("Content-Type"),
+		Body:        file,
+		RequestID:   requestID(r),
+	})
+	if err != nil {
+		logger.Warn("upload refused", "request_id", requestID(r), "user_id", userID, "error", err)
+		mediaError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(media)
+}
+
+// GetMedia answers GET /api/media/{id} with the uploaded bytes, honouring Range requests.
+func GetMedia(w http.ResponseWriter, r *http.Request) {
+	serveMedia(w, r, false)
+}
+
+// GetMediaThumbnail answers GET /api/media/{id}/thumbnail with the PNG thumbnail of an image.
+func GetMediaThumbnail(w http.ResponseWriter, r *http.Request) {
+	serveMedia(w, r, true)
+}
+
+func serveMedia(w http.ResponseWriter, r *http.Request, thumbnail bool) {
+	blob, media, err := actorSystem.OpenMedia(r.Context(), mux.Vars(r)["id"], thumbnail)
+	if err != nil {
+		mediaError(w, err)
+		return
+	}
+	defer blob.Close()
+
+	contentType := media.ContentType
+	if thumbnail {
+		contentType = "image/png"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable") // media never change
+	if seeker, ok := blob.(io.ReadSeeker); ok {
+		http.ServeContent(w, r, "", media.UploadedAt, seeker)
+		return
+	}
+	io.Copy(w, blob)
+}
+
+func mediaError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, engine.ErrMediaTooLarge):
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+	case errors.Is(err, engine.ErrUnsupportedMedia):
+		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+	case errors.Is(err, engine.ErrNoSuchMedia):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, engine.ErrMediaDisabled):
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+	default:
+		http.Error(w, "storing media failed", http.StatusInternalServerError)
+	}
+}
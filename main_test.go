@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reddit_clone2/config"
+	"reddit_clone2/engine"
+	"reddit_clone2/ratelimit"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// useTestEngine points the handlers at a fresh engine, default settings and empty rate limit
+// buckets, shut down when the test ends
+func useTestEngine(t *testing.T) {
+	t.Helper()
+	logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	settings = config.Default()
+	limiter = ratelimit.New()
+	actorSystem = engine.NewActorSystem(logger)
+	actorSystem.SetupActors()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		actorSystem.Shutdown(ctx)
+	})
+}
+
+// eventually polls check until it holds, failing the test after a few seconds
+func eventually(t *testing.T, what string, check func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if check() {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+// newRateLimitedRouter serves POST /api/votes behind rateLimiting with fresh buckets and the
+// given limits, answering 202 to the requests it lets through
+func newRateLimitedRouter(t *testing.T, limits config.RateLimitConfig) *mux.Router {
+	t.Helper()
+	useTestEngine(t)
+	settings.RateLimit = limits
+
+	r := mux.NewRouter()
+	r.Use(rateLimiting)
+	r.HandleFunc("/api/votes", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}).Methods("POST")
+	return r
+}
+
+// postVote sends a vote from ip as userID (none when 0) and returns the response
+func postVote(r http.Handler, ip string, userID int) *httptest.ResponseRecorder {
+	body := `{"Target":"post","ID":1,"Type":"upvote"}`
+	if userID != 0 {
+		body = `{"UserID":` + strconv.Itoa(userID) + `,"Target":"post","ID":1,"Type":"upvote"}`
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/votes", strings.NewReader(body))
+	req.RemoteAddr = ip + ":40000"
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRateLimitingRetryAfter(t *testing.T) {
+	r := newRateLimitedRouter(t, config.RateLimitConfig{Enabled: true, VotesPerMinute: 6, Burst: 1})
+	for i := 0; i < 2; i++ {
+		if rec := postVote(r, "192.0.2.1", 0); rec.Code != http.StatusAccepted {
+			t.Fatalf("vote %d: status %d, want %d", i+1, rec.Code, http.StatusAccepted)
+		}
+	}
+	rec := postVote(r, "192.0.2.1", 0)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("third vote: status %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	// One vote every ten seconds
+	if got := rec.Header().Get("Retry-After"); got != "10" {
+		t.Fatalf("Retry-After = %q, want 10", got)
+	}
+	if rec := postVote(r, "192.0.2.2", 0); rec.Code != http.StatusAccepted {
+		t.Fatalf("vote from another address: status %d, want %d", rec.Code, http.StatusAccepted)
+	}
+}
+
+func TestRateLimitingRestrictsUnknownAccounts(t *testing.T) {
+	// Registered accounts are never new or low on karma here; unknown ones are restricted
+	r := newRateLimitedRouter(t, config.RateLimitConfig{Enabled: true, VotesPerMinute: 60, Burst: 4, RestrictedDivisor: 4})
+	actorSystem.RegisterUser(context.Background(), engine.RegisterUser{Username: "alice", Password: "secret"})
+	eventually(t, "the user", func() bool {
+		_, found := actorSystem.GetUser(context.Background(), engine.GetUser{UserID: 1})
+		return found
+	})
+
+	for _, tt := range []struct {
+		name       string
+		userID     int
+		allowed    int
+		retryAfter string
+	}{
+		{"registered", 1, 5, "1"},
+		{"unknown", 99, 2, "4"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// A new address for every vote, so only the user's bucket can run out
+			ip := func(i int) string { return "198.51.100." + strconv.Itoa(tt.userID) + strconv.Itoa(i) }
+			for i := 0; i < tt.allowed; i++ {
+				if rec := postVote(r, ip(i), tt.userID); rec.Code != http.StatusAccepted {
+					t.Fatalf("vote %d: status %d, want %d", i+1, rec.Code, http.StatusAccepted)
+				}
+			}
+			rec := postVote(r, ip(tt.allowed), tt.userID)
+			if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != tt.retryAfter {
+				t.Fatalf("vote %d: status %d, Retry-After %q; want %d and %s", tt.allowed+1, rec.Code, rec.Header().Get("Retry-After"), http.StatusTooManyRequests, tt.retryAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// markdown parses Reddit-flavored markdown: CommonMark with tables, ~~strikethrough~~, bare
+// URLs and r/ and u/ references turned into links. Raw HTML in the source is left out.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(extension.Table, extension.Strikethrough, extension.Linkify),
+	goldmark.WithParserOptions(parser.WithASTTransformers(util.Prioritized(referenceLinker{}, 500))),
+)
+
+// sanitizer is the last line of defense against XSS: only formatting elements and http,
+// https, mailto and relative links survive, and links leaving the site get rel="nofollow".
+var sanitizer = func() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.RequireNoFollowOnLinks(false)
+	policy.RequireNoFollowOnFullyQualifiedLinks(true)
+	policy.AddTargetBlankToFullyQualifiedLinks(true)
+	return policy
+}()
+
+// RenderMarkdown renders post or comment source to sanitized HTML
+func RenderMarkdown(source string) string {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(source), &buf); err != nil {
+		// Writing to a bytes.Buffer doesn't fail, but never serve unrendered source as HTML
+		return "<p>" + html.EscapeString(source) + "</p>"
+	}
+	return string(sanitizer.SanitizeBytes(buf.Bytes()))
+}
+
+// referencePattern finds r/subreddit and u/user references, optionally written with a leading
+// slash, that don't continue a word or a path.
+var referencePattern = regexp.MustCompile(`(?:^|[^\w/])(/?(r/[A-Za-z0-9_]+|u/[A-Za-z0-9_-]+))`)
+
+// referenceLinker turns r/ and u/ references in text into links to /r/name and /u/name.
+// References inside links and code are left alone.
+type referenceLinker struct{}
+
+func (referenceLinker) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+	var parents []ast.Node
+	ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n.(type) {
+		case *ast.Link, *ast.AutoLink, *ast.Image, *ast.CodeSpan, *ast.CodeBlock, *ast.FencedCodeBlock, *ast.HTMLBlock, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		}
+		if n.HasChildren() {
+			parents = append(parents, n)
+		}
+		return ast.WalkContinue, nil
+	})
+	for _, parent := range parents {
+		linkReferences(parent, source)
+	}
+}
+
+// linkReferences rewrites the runs of adjacent text nodes among parent's children. The parser
+// splits text at characters like _ that might start emphasis, so a name like u/some_user can
+// span several nodes.
+func linkReferences(parent ast.Node, source []byte) {
+	child := parent.FirstChild()
+	for child != nil {
+		first, ok := child.(*ast.Text)
+		if !ok {
+			child = child.NextSibling()
+			continue
+		}
+		last := first
+		for !last.SoftLineBreak() && !last.HardLineBreak() {
+			next, ok := last.NextSibling().(*ast.Text)
+			if !ok || next.Segment.Start != last.Segment.Stop || next.Segment.Padding != 0 {
+				break
+			}
+			last = next
+		}
+		child = last.NextSibling()
+
+		start, stop := first.Segment.Start, last.Segment.Stop
+		matches := referencePattern.FindAllSubmatchIndex(source[start:stop], -1)
+		if len(matches) == 0 {
+			continue
+		}
+		var replacement []ast.Node
+		pos := start
+		for _, match := range matches {
+			refStart, refStop, name := start+match[2], start+match[3], source[start+match[4]:start+match[5]]
+			if refStart > pos {
+				replacement = append(replacement, ast.NewTextSegment(text.NewSegment(pos, refStart)))
+			}
+			link := ast.NewLink()
+			link.Destination = append([]byte("/"), name...)
+			link.AppendChild(link, ast.NewTextSegment(text.NewSegment(refStart, refStop)))
+			replacement = append(replacement, link)
+			pos = refStop
+		}
+		tail := ast.NewTextSegment(text.NewSegment(pos, stop))
+		tail.SetSoftLineBreak(last.SoftLineBreak())
+		tail.SetHardLineBreak(last.HardLineBreak())
+		replacement = append(replacement, tail)
+
+		for _, node := range replacement {
+			parent.InsertBefore(parent, first, node)
+		}
+		for node := ast.Node(first); node != child; {
+			next := node.NextSibling()
+			parent.RemoveChild(parent, node)
+			node = next
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdownStripsXSS(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		stripped []string // must not appear in the rendered HTML, case-insensitively
+		kept     string   // must appear
+	}{
+		{"script tag", "hello <script>alert(1)</script> world", []string{"<script", "alert(1)</script>"}, "hello"},
+		{"script block", "<script>\nalert(1)\n</script>\n\nafter", []string{"<script"}, "<p>after</p>"},
+		{"raw html block", "<div onclick=\"steal()\">\n<b>bold</b>\n</div>\n\nafter", []string{"<div", "onclick", "<b>"}, "<p>after</p>"},
+		{"inline html", "a <img src=x onerror=alert(1)> b", []string{"<img", "onerror"}, "a"},
+		{"onerror in markdown image", `![x](https://example.com/x.png" onerror="alert(1))`, []string{"onerror=\"alert"}, ""},
+		{"javascript link", "[click](javascript:alert(1))", []string{"javascript:"}, "click"},
+		{"javascript link, mixed case", "[click](JaVaScRiPt:alert(1))", []string{"javascript:"}, "click"},
+		{"javascript autolink", "<javascript:alert(1)>", []string{"href=\"javascript:"}, ""},
+		{"data link", "[click](data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==)", []string{"data:"}, "click"},
+		{"data image", "![x](data:image/svg+xml;base64,PHN2ZyBvbmxvYWQ9YWxlcnQoMSk+)", []string{"data:"}, ""},
+		{"iframe", "<iframe src=\"https://evil.example\"></iframe>", []string{"<iframe"}, ""},
+		{"style attribute", "<p style=\"background:url(javascript:alert(1))\">x</p>", []string{"style=", "javascript:"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderMarkdown(tt.source)
+			for _, stripped := range tt.stripped {
+				if strings.Contains(strings.ToLower(got), strings.ToLower(stripped)) {
+					t.Errorf("RenderMarkdown(%q) = %q, contains %q", tt.source, got, stripped)
+				}
+			}
+			if !strings.Contains(got, tt.kept) {
+				t.Errorf("RenderMarkdown(%q) = %q, want it to contain %q", tt.source, got, tt.kept)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdownKeepsSafeFormatting(t *testing.T) {
+	tests := []struct {
+		source string
+		want   string
+	}{
+		{"**bold** and ~~gone~~", "<p><strong>bold</strong> and <del>gone</del></p>\n"},
+		{"[docs](/r/golang/wiki)", `<p><a href="/r/golang/wiki">docs</a></p>` + "\n"},
+		{"see https://go.dev", `<p>see <a href="https://go.dev" rel="nofollow noopener" target="_blank">https://go.dev</a></p>` + "\n"},
+		{"mail [me](mailto:me@example.com)", `<p>mail <a href="mailto:me@example.com">me</a></p>` + "\n"},
+	}
+	for _, tt := range tests {
+		if got := RenderMarkdown(tt.source); got != tt.want {
+			t.Errorf("RenderMarkdown(%q) = %q, want %q", tt.source, got, tt.want)
+		}
+	}
+}
+
+func TestRenderMarkdownLinksReferences(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"subreddit", "join r/golang today", `<p>join <a href="/r/golang">r/golang</a> today</p>`},
+		{"user", "thanks u/bar!", `<p>thanks <a href="/u/bar">u/bar</a>!</p>`},
+		{"leading slash", "see /r/golang", `<p>see <a href="/r/golang">/r/golang</a></p>`},
+		{"underscores", "ping u/some_user_name", `<p>ping <a href="/u/some_user_name">u/some_user_name</a></p>`},
+		{"start of text", "r/foo and u/bar", `<p><a href="/r/foo">r/foo</a> and <a href="/u/bar">u/bar</a></p>`},
+		{"inside a word", "bar/r/foo", `<p>bar/r/foo</p>`},
+		{"code span", "run `r/foo` or `u/bar`", `<p>run <code>r/foo</code> or <code>u/bar</code></p>`},
+		{"code block", "```\nr/foo u/bar\n```", "<pre><code>r/foo u/bar\n</code></pre>"},
+		{"inside a link", "[r/foo](https://example.com/u/bar)", `<p><a href="https://example.com/u/bar" rel="nofollow noopener" target="_blank">r/foo</a></p>`},
+		{"inside a bare url", "https://example.com/r/foo", `<p><a href="https://example.com/r/foo" rel="nofollow noopener" target="_blank">https://example.com/r/foo</a></p>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := strings.TrimSpace(RenderMarkdown(tt.source)); got != tt.want {
+				t.Fatalf("RenderMarkdown(%q) = %q, want %q", tt.source, got, tt.want)
+			}
+		})
+	}
+}
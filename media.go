@@ -0,0 +1,383 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // registers the decoders uploads are checked with
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// ThumbnailSuffix is appended to a media ID to get the blob key of its thumbnail
+const ThumbnailSuffix = "_thumb"
+
+const MaxPostMedia = 20
+
+var (
+	ErrMediaDisabled    = errors.New("media uploads are not configured")
+	ErrUnsupportedMedia = errors.New("unsupported media type")
+	ErrMediaTooLarge    = errors.New("media too large")
+	ErrNoSuchMedia      = errors.New("media does not exist")
+)
+
+// MediaOptions controls uploads. Uploads no post refers to are deleted by a collection every
+// GCInterval once they are older than GCGrace, which gives clients time to create the post.
+type MediaOptions struct {
+	Blobs         BlobStore // nil disables uploads
+	MaxBytes      int64
+	ContentTypes  []string
+	ThumbnailSize int // longest side of an image thumbnail, in pixels
+	MaxPixels     int // larger images are refused before they are decoded
+	GCInterval    time.Duration
+	GCGrace       time.Duration
+}
+
+func DefaultMediaOptions() MediaOptions {
+	return MediaOptions{
+		MaxBytes:      10 << 20,
+		ContentTypes:  []string{"image/png", "image/jpeg", "image/gif", "video/mp4", "video/webm"},
+		ThumbnailSize: 320,
+		MaxPixels:     40_000_000,
+		GCInterval:    10 * time.Minute,
+		GCGrace:       time.Hour,
+	}
+}
+
+// mediaUploaded records an upload whose blobs are stored; answered with the *Media
+type mediaUploaded struct {
+	media     *Media
+	requestID string
+}
+
+// collectMedia starts a garbage collection run
+type collectMedia struct{}
+
+// getMediaRefs asks PostActor for the media live posts refer to; answered with map[string]bool
+type getMediaRefs struct{}
+
+// MediaActor keeps the records of uploaded media and deletes the blobs no post refers to.
+type MediaActor struct {
+	media      map[string]*Media
+	postActor  *actor.PID
+	options    MediaOptions
+	timeout    time.Duration
+	collecting bool
+	stop       chan struct{}
+	store      Store
+	logger     *slog.Logger
+	mu         sync.Mutex
+}
+
+func (m *MediaActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		m.mu.Lock()
+		m.media = m.store.Media()
+		m.logger.Debug("state restored", "media", len(m.media))
+		m.mu.Unlock()
+
+		if m.options.Blobs != nil && m.options.GCInterval > 0 {
+			m.stop = make(chan struct{})
+			go m.tick(ctx.ActorSystem().Root, ctx.Self(), m.stop)
+		}
+
+	case *actor.Stopping, *actor.Restarting:
+		if m.stop != nil {
+			close(m.stop)
+			m.stop = nil
+		}
+
+	case *mediaUploaded:
+		m.mu.Lock()
+		m.media[msg.media.ID] = msg.media
+		m.store.SaveMedia(msg.media)
+		m.mu.Unlock()
+		m.logger.Info("media uploaded", "request_id", msg.requestID, "media_id", msg.media.ID, "user_id", msg.media.UserID, "content_type", msg.media.ContentType, "size", msg.media.Size)
+		recorded := *msg.media
+		ctx.Respond(&recorded)
+
+	case *GetMedia:
+		m.mu.Lock()
+		found := []Media{}
+		for _, id := range msg.IDs {
+			if media, exists := m.media[id]; exists {
+				found = append(found, *media)
+			}
+		}
+		m.mu.Unlock()
+		ctx.Respond(found)
+
+	case *collectMedia:
+		if m.collecting {
+			return
+		}
+		m.collecting = true
+		// Ask for the references first so uploads attached meanwhile are never collected
+		started := time.Now()
+		future := ctx.RequestFuture(m.postActor, &getMediaRefs{}, m.timeout)
+		ctx.ReenterAfter(future, func(res interface{}, err error) {
+			m.collecting = false
+			refs, ok := res.(map[string]bool)
+			if err != nil || !ok {
+				m.logger.Error("media collection skipped", "error", err)
+				return
+			}
+			m.collect(refs, started.Add(-m.options.GCGrace))
+		})
+	}
+}
+
+// tick starts a collection every GCInterval until stop is closed
+func (m *MediaActor) tick(root *actor.RootContext, self *actor.PID, stop chan struct{}) {
+	ticker := time.NewTicker(m.options.GCInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			root.Send(self, &collectMedia{})
+		case <-stop:
+			return
+		}
+	}
+}
+
+// collect deletes the media no post refers to that were uploaded before cutoff, along with
+// any blob without a record, which an upload that failed halfway leaves behind.
+func (m *MediaActor) collect(refs map[string]bool, cutoff time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	blobs, err := m.options.Blobs.List(ctx)
+	if err != nil {
+		m.logger.Error("listing media blobs failed", "error", err)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	removed := map[string]bool{}
+	for id, media := range m.media {
+		if refs[id] || !media.UploadedAt.Before(cutoff) {
+			continue
+		}
+		if err := m.deleteBlobs(ctx, id); err != nil {
+			m.logger.Error("deleting media failed", "media_id", id, "error", err)
+			continue
+		}
+		delete(m.media, id)
+		m.store.DeleteMedia(id)
+		removed[id] = true
+	}
+	strays := 0
+	for _, blob := range blobs {
+		id := strings.TrimSuffix(blob.Key, ThumbnailSuffix)
+		if m.media[id] != nil || removed[id] || refs[id] || !blob.ModTime.Before(cutoff) {
+			continue
+		}
+		if err := m.options.Blobs.Delete(ctx, blob.Key); err != nil {
+			m.logger.Error("deleting stray blob failed", "key", blob.Key, "error", err)
+			continue
+		}
+		strays++
+	}
+	m.logger.Info("media collected", "removed", len(removed), "stray_blobs", strays, "kept", len(m.media))
+}
+
+func (m *MediaActor) deleteBlobs(ctx context.Context, id string) error {
+	return errors.Join(m.options.Blobs.Delete(ctx, id), m.options.Blobs.Delete(ctx, id+ThumbnailSuffix))
+}
+
+// UploadMedia checks an upload, stores it with a thumbnail when it is an image, and records it.
+// The content type is sniffed from the data; a declared type that disagrees is refused.
+func (as *ActorSystem) UploadMedia(ctx context.Context, msg UploadMedia) (*Media, error) {
+	options := as.Media
+	if options.Blobs == nil {
+		return nil, ErrMediaDisabled
+	}
+	data, err := io.ReadAll(io.LimitReader(msg.Body, options.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > options.MaxBytes {
+		return nil, fmt.Errorf("%w: larger than %d bytes", ErrMediaTooLarge, options.MaxBytes)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: empty upload", ErrUnsupportedMedia)
+	}
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(data))
+	if !slices.Contains(options.ContentTypes, contentType) {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedMedia, contentType)
+	}
+	if declared, _, err := mime.ParseMediaType(msg.ContentType); err == nil && declared != "application/octet-stream" && declared != contentType {
+		return nil, fmt.Errorf("%w: declared as %s but the data is %s", ErrUnsupportedMedia, declared, contentType)
+	}
+
+	media := &Media{ID: randomHex(16), UserID: msg.UserID, ContentType: contentType, Size: int64(len(data)), UploadedAt: time.Now()}
+	var thumb []byte
+	if strings.HasPrefix(contentType, "image/") {
+		if thumb, err = media.thumbnail(data, options); err != nil {
+			return nil, err
+		}
+		media.Thumbnail = true
+	}
+
+	if err := options.Blobs.Put(ctx, media.ID, bytes.NewReader(data)); err != nil {
+		as.Logger.Error("storing media failed", "request_id", msg.RequestID, "error", err)
+		return nil, err
+	}
+	if thumb != nil {
+		if err := options.Blobs.Put(ctx, media.ID+ThumbnailSuffix, bytes.NewReader(thumb)); err != nil {
+			as.Logger.Error("storing thumbnail failed", "request_id", msg.RequestID, "error", err)
+			return nil, err // the collector removes the stray original
+		}
+	}
+
+	result, err := as.requestFuture(ctx, as.MediaActor, &mediaUploaded{media: media, requestID: msg.RequestID}, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error recording media", "request_id", msg.RequestID, "error", err)
+		return nil, err
+	}
+	recorded, ok := result.(*Media)
+	if !ok {
+		return nil, fmt.Errorf("unexpected media record %T", result)
+	}
+	return recorded, nil
+}
+
+// thumbnail decodes the image in data, fills in its size and returns a PNG at most
+// options.ThumbnailSize pixels on its longest side.
+func (media *Media) thumbnail(data []byte, options MediaOptions) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: undecodable image: %v", ErrUnsupportedMedia, err)
+	}
+	if config.Width*config.Height > options.MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels", ErrMediaTooLarge, config.Width, config.Height)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: undecodable image: %v", ErrUnsupportedMedia, err)
+	}
+	media.Width, media.Height = config.Width, config.Height
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, scaleDown(img, options.ThumbnailSize)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// scaleDown shrinks img to fit in a size×size square, averaging the pixels each thumbnail
+// pixel covers. Images that already fit are only copied.
+func scaleDown(img image.Image, size int) *image.NRGBA {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	tw, th := w, h
+	if w > size || h > size {
+		if w >= h {
+			tw, th = size, max(1, h*size/w)
+		} else {
+			tw, th = max(1, w*size/h), size
+		}
+	}
+
+	thumb := image.NewNRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := bounds.Min.Y+y*h/th, bounds.Min.Y+max((y+1)*h/th, y*h/th+1)
+		for x := 0; x < tw; x++ {
+			x0, x1 := bounds.Min.X+x*w/tw, bounds.Min.X+max((x+1)*w/tw, x*w/tw+1)
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := color.NRGBA64Model.Convert(img.At(sx, sy)).(color.NRGBA64)
+					r, g, b, a, n = r+uint64(c.R), g+uint64(c.G), b+uint64(c.B), a+uint64(c.A), n+1
+				}
+			}
+			thumb.Set(x, y, color.NRGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return thumb
+}
+
+// GetMedia returns the recorded media among msg.IDs; unknown IDs are left out.
+func (as *ActorSystem) GetMedia(ctx context.Context, msg GetMedia) ([]Media, error) {
+	result, err := as.requestFuture(ctx, as.MediaActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching media", "request_id", msg.RequestID, "error", err)
+		return nil, err
+	}
+	media, ok := result.([]Media)
+	if !ok {
+		return nil, fmt.Errorf("unexpected media %T", result)
+	}
+	return media, nil
+}
+
+// AttachMedia resolves msg.MediaIDs into msg.Media. Every ID must name an upload of the
+// post's author.
+func (as *ActorSystem) AttachMedia(ctx context.Context, msg *PostMessage) error {
+	if len(msg.MediaIDs) == 0 {
+		return nil
+	}
+	var ids []string
+	for _, id := range msg.MediaIDs {
+		if !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	found, err := as.GetMedia(ctx, GetMedia{IDs: ids, RequestID: msg.RequestID})
+	if err != nil {
+		return err
+	}
+	owned := make(map[string]Media, len(found))
+	for _, media := range found {
+		if media.UserID == msg.UserID {
+			owned[media.ID] = media
+		}
+	}
+	msg.Media = msg.Media[:0]
+	for _, id := range ids {
+		media, ok := owned[id]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrNoSuchMedia, id)
+		}
+		msg.Media = append(msg.Media, media)
+	}
+	return nil
+}
+
+// OpenMedia returns the bytes of an upload, or of its thumbnail, and the upload's record.
+func (as *ActorSystem) OpenMedia(ctx context.Context, id string, thumbnail bool) (io.ReadCloser, Media, error) {
+	if as.Media.Blobs == nil {
+		return nil, Media{}, ErrMediaDisabled
+	}
+	found, err := as.GetMedia(ctx, GetMedia{IDs: []string{id}})
+	if err != nil {
+		return nil, Media{}, err
+	}
+	if len(found) == 0 || (thumbnail && !found[0].Thumbnail) {
+		return nil, Media{}, ErrNoSuchMedia
+	}
+	key := id
+	if thumbnail {
+		key += ThumbnailSuffix
+	}
+	blob, err := as.Media.Blobs.Open(ctx, key)
+	if errors.Is(err, ErrNoSuchBlob) {
+		return nil, Media{}, ErrNoSuchMedia
+	}
+	return blob, found[0], err
+}
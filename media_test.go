@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+)
+
+// testImage encodes a w×h image as PNG, or as GIF with asGIF
+func testImage(t *testing.T, w, h int, asGIF bool) []byte {
+	t.Helper()
+	img := image.NewPaletted(image.Rect(0, 0, w, h), []color.Color{color.White, color.Black})
+	var buf bytes.Buffer
+	var err error
+	if asGIF {
+		err = gif.Encode(&buf, img, nil)
+	} else {
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newTestMediaSystem(t *testing.T, configure func(*MediaOptions)) *ActorSystem {
+	t.Helper()
+	blobs, err := NewFileBlobStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newTestActorSystem(t, NewMemoryStore(), func(as *ActorSystem) {
+		as.Media.Blobs = blobs
+		if configure != nil {
+			configure(&as.Media)
+		}
+	})
+}
+
+func TestUploadMediaRejects(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     func(*MediaOptions)
+		contentType string
+		data        func(t *testing.T) []byte
+		want        error
+	}{
+		{"too many bytes", func(o *MediaOptions) { o.MaxBytes = 64 }, "image/png", func(t *testing.T) []byte { return testImage(t, 100, 100, false) }, ErrMediaTooLarge},
+		{"too many pixels", func(o *MediaOptions) { o.MaxPixels = 100 * 99 }, "image/png", func(t *testing.T) []byte { return testImage(t, 100, 100, false) }, ErrMediaTooLarge},
+		{"empty", nil, "image/png", func(*testing.T) []byte { return nil }, ErrUnsupportedMedia},
+		{"plain text", nil, "", func(*testing.T) []byte { return []byte("just some text") }, ErrUnsupportedMedia},
+		{"html declared as an image", nil, "image/png", func(*testing.T) []byte { return []byte("<html><script>alert(1)</script></html>") }, ErrUnsupportedMedia},
+		{"gif declared as png", nil, "image/png", func(t *testing.T) []byte { return testImage(t, 10, 10, true) }, ErrUnsupportedMedia},
+		{"type turned off", func(o *MediaOptions) { o.ContentTypes = []string{"image/jpeg"} }, "image/png", func(t *testing.T) []byte { return testImage(t, 10, 10, false) }, ErrUnsupportedMedia},
+		{"uploads disabled", func(o *MediaOptions) { o.Blobs = nil }, "image/png", func(t *testing.T) []byte { return testImage(t, 10, 10, false) }, ErrMediaDisabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			as := newTestMediaSystem(t, tt.options)
+			media, err := as.UploadMedia(context.Background(), UploadMedia{UserID: 1, ContentType: tt.contentType, Body: bytes.NewReader(tt.data(t))})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("UploadMedia = %+v, %v; want %v", media, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestUploadMediaStoresImagesWithThumbnails(t *testing.T) {
+	ctx := context.Background()
+	as := newTestMediaSystem(t, nil)
+
+	// A declared octet-stream is fine, the type is sniffed from the data
+	media, err := as.UploadMedia(ctx, UploadMedia{UserID: 1, ContentType: "application/octet-stream", Body: bytes.NewReader(testImage(t, 640, 480, false))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if media.ContentType != "image/png" || media.Width != 640 || media.Height != 480 || !media.Thumbnail {
+		t.Fatalf("upload = %+v, want a 640x480 PNG with a thumbnail", media)
+	}
+	thumb, _, err := as.OpenMedia(ctx, media.ID, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer thumb.Close()
+	config, err := png.DecodeConfig(thumb)
+	if err != nil || config.Width != 320 || config.Height != 240 {
+		t.Fatalf("thumbnail is %dx%d (%v), want 320x240", config.Width, config.Height, err)
+	}
+
+	// Only the uploader may attach it to a post
+	post := PostMessage{UserID: 2, Subreddit: "golang", Kind: PostImage, Title: "mine now", MediaIDs: []string{media.ID}}
+	if err := as.AttachMedia(ctx, &post); !errors.Is(err, ErrNoSuchMedia) {
+		t.Fatalf("attaching someone else's upload: %v, want ErrNoSuchMedia", err)
+	}
+	post.UserID = 1
+	if err := as.AttachMedia(ctx, &post); err != nil || len(post.Media) != 1 || post.Media[0].ID != media.ID {
+		t.Fatalf("attaching own upload: %+v, %v", post.Media, err)
+	}
+}
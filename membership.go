@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/cluster/clusterproviders/test"
+)
+
+// InProcessMembership lets cluster members started in the same process find each other
+type InProcessMembership struct {
+	agent *test.InMemAgent
+}
+
+func NewInProcessMembership() *InProcessMembership {
+	return &InProcessMembership{agent: test.NewInMemAgent()}
+}
+
+// Provider returns the provider for one more member; every member needs its own.
+func (m *InProcessMembership) Provider() cluster.ClusterProvider {
+	return test.NewTestProvider(m.agent)
+}
+
+// DirProvider finds members through a directory they share, so processes on one machine can
+// form a cluster without a discovery service. Each member keeps a <member id>.json file there
+// up to date and ignores files that weren't refreshed within TTL.
+type DirProvider struct {
+	Dir     string
+	Refresh time.Duration // how often a member rewrites its own file and rereads the others
+	TTL     time.Duration
+
+	cluster *cluster.Cluster
+	self    *memberRecord
+	logger  *slog.Logger
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+type memberRecord struct {
+	ID    string    `json:"id"`
+	Host  string    `json:"host"`
+	Port  int       `json:"port"`
+	Kinds []string  `json:"kinds"`
+	Seen  time.Time `json:"seen"`
+}
+
+func NewDirProvider(dir string) *DirProvider {
+	return &DirProvider{Dir: dir, Refresh: time.Second, TTL: 5 * time.Second}
+}
+
+func (p *DirProvider) StartMember(c *cluster.Cluster) error {
+	host, port, err := c.ActorSystem.GetHostPort()
+	if err != nil {
+		return err
+	}
+	p.self = &memberRecord{ID: c.ActorSystem.ID, Host: host, Port: port, Kinds: c.GetClusterKinds()}
+	return p.start(c)
+}
+
+// StartClient follows the members without registering one
+func (p *DirProvider) StartClient(c *cluster.Cluster) error {
+	return p.start(c)
+}
+
+func (p *DirProvider) start(c *cluster.Cluster) error {
+	if err := os.MkdirAll(p.Dir, 0o755); err != nil {
+		return err
+	}
+	p.cluster = c
+	p.logger = c.Logger()
+	p.stop = make(chan struct{})
+	if err := p.sync(); err != nil {
+		return err
+	}
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.Refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.sync(); err != nil {
+					p.logger.Error("membership refresh failed", "dir", p.Dir, "error", err)
+				}
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// sync refreshes this member's file and hands the live members to the cluster
+func (p *DirProvider) sync() error {
+	if p.self != nil {
+		p.self.Seen = time.Now()
+		if err := p.write(p.self); err != nil {
+			return err
+		}
+	}
+
+	paths, err := filepath.Glob(filepath.Join(p.Dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	var members cluster.Members
+	for _, path := range paths {
+		var record memberRecord
+		data, err := os.ReadFile(path)
+		if err == nil {
+			err = json.Unmarshal(data, &record)
+		}
+		if errors.Is(err, os.ErrNotExist) {
+			continue // removed by a member that just left
+		}
+		if err != nil {
+			p.logger.Warn("skipping unreadable member file", "path", path, "error", err)
+			continue
+		}
+		if time.Since(record.Seen) > p.TTL {
+			continue
+		}
+		members = append(members, &cluster.Member{Id: record.ID, Host: record.Host, Port: int32(record.Port), Kinds: record.Kinds})
+	}
+	p.cluster.MemberList.UpdateClusterTopology(members)
+	return nil
+}
+
+// write replaces the member's file atomically so readers never see it half written
+func (p *DirProvider) write(record *memberRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(p.Dir, record.ID+".json")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("writing member file: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// Shutdown stops refreshing and removes this member's file so the others drop it right away
+func (p *DirProvider) Shutdown(graceful bool) error {
+	if p.stop == nil {
+		return nil
+	}
+	close(p.stop)
+	p.wg.Wait()
+	p.stop = nil
+	if p.self == nil {
+		return nil
+	}
+	if err := os.Remove(filepath.Join(p.Dir, p.self.ID+".json")); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -47,7 +47,6 @@ type AssignUserActor struct {
 type GetAllUsers struct{}
 */
 
-
 /*package engine
 
 type RegisterUser struct {
@@ -82,49 +81,249 @@ type Vote struct {
 type GetAllUsers struct{}
 */
 
-
 package engine
 
-import "github.com/asynkron/protoactor-go/actor"
+import (
+	"io"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
 
 // User Registration
 type RegisterUser struct {
-	Username string
-	Password string
+	Username  string
+	Password  string
+	RequestID string
 }
 
 // Subreddit Management
+// The creator becomes the subreddit's first member and moderator
 type CreateSubreddit struct {
-	Name string
+	Name      string
+	CreatorID int
+	RequestID string
+}
+
+// Retrieve One Subreddit by name; answered with *Subreddit, or nil when it does not exist
+type GetSubreddit struct {
+	Name      string
+	RequestID string
+}
+
+// Retrieve the names of the subreddits a user joined; answered with []string
+type GetMemberships struct {
+	UserID    int
+	RequestID string
+}
+
+// Subreddit Membership
+type JoinSubreddit struct {
+	UserID    int
+	Name      string
+	RequestID string
+}
+
+type LeaveSubreddit struct {
+	UserID    int
+	Name      string
+	RequestID string
+}
+
+// Subreddit Activity, sent by PostActor for every post, comment and vote in a subreddit
+type SubredditActivity struct {
+	Name   string
+	Kind   string // "post", "comment" or "vote"
+	PostID int
+	At     time.Time
+}
+
+// Subreddit Discovery; each is answered with []SubredditSummary
+type ListSubreddits struct {
+	Sort      string // "members", "activity" or "newest"
+	Page      int
+	PageSize  int
+	RequestID string
+}
+
+type AutocompleteSubreddits struct {
+	Prefix    string
+	Limit     int
+	RequestID string
+}
+
+type GetTrendingSubreddits struct {
+	Limit     int
+	RequestID string
 }
 
-// Post Management
+// Post Management; see ValidatePost for what each Kind requires
 type PostMessage struct {
 	UserID    int
 	Subreddit string
+	Title     string
+	Kind      string
+	URL       string
+	Content   string
+	MediaIDs  []string       // uploads to attach, see ActorSystem.AttachMedia
+	Media     []Media        `json:"-"`
+	FlairID   int            // post flair template, see ActorSystem.AttachFlair
+	Flair     *FlairTemplate `json:"-"`
+	RequestID string
+}
+
+// FindLink looks for a live link post of URL in Subreddit; answered with its ID, or 0
+type FindLink struct {
+	Subreddit string
+	URL       string
+	RequestID string
+}
+
+// Submissions linking to a domain, newest first; answered with []PostSummary
+type GetDomainPosts struct {
+	Domain    string
+	UserID    int          // the user listing, whose hidden posts are left out
+	Hidden    map[int]bool `json:"-"`
+	Page      int
+	PageSize  int
+	RequestID string
+}
+
+// Post Editing; only the author may edit or delete a post
+type EditPost struct {
+	UserID    int
+	PostID    int
 	Content   string
+	RequestID string
+}
+
+type DeletePost struct {
+	UserID    int
+	PostID    int
+	RequestID string
+}
+
+// Post Flair; the facade resolves FlairID to Flair, which PostActor stores as it is.
+// Answered with a flairResponse.
+type SetPostFlair struct {
+	UserID    int
+	PostID    int
+	FlairID   int            // 0 clears the flair
+	Flair     *FlairTemplate `json:"-"`
+	RequestID string
+}
+
+// Crosspost shares a live post into another subreddit the user is a member of; answered with
+// a crosspostResponse. Title defaults to the original post's.
+type Crosspost struct {
+	UserID    int
+	PostID    int
+	Subreddit string
+	Title     string
+	RequestID string
+}
+
+// Retrieve One Post by ID; answered with *Post, or nil when it does not exist
+type GetPost struct {
+	PostID    int
+	RequestID string
+}
+
+// Retrieve One Comment by ID, without its replies; answered with *Comment, or nil
+type GetComment struct {
+	CommentID int
+	RequestID string
 }
 
 // Comment Management
 type CommentMessage struct {
-	UserID   int
-	PostID   int
-	ParentID int // For hierarchical comments
-	Content  string
+	UserID    int
+	PostID    int
+	ParentID  int // For hierarchical comments
+	Content   string
+	RequestID string
+}
+
+// Comment Editing; only the author may edit or delete a comment
+type EditComment struct {
+	UserID    int
+	CommentID int
+	Content   string
+	RequestID string
+}
+
+type DeleteComment struct {
+	UserID    int
+	CommentID int
+	RequestID string
+}
+
+// Media Uploads; UploadMedia is handled by the ActorSystem, GetMedia is answered with []Media
+type UploadMedia struct {
+	UserID      int
+	ContentType string // as declared by the client
+	Body        io.Reader
+	RequestID   string
+}
+
+type GetMedia struct {
+	IDs       []string
+	RequestID string
 }
 
 // Voting System
 type Vote struct {
-	UserID int
-	Target string // "post" or "comment"
-	ID     int    // ID of the target being voted on
-	Type   string // "upvote" or "downvote"
+	UserID    int
+	Target    string // "post" or "comment"
+	ID        int    // ID of the target being voted on
+	Type      string // "upvote" or "downvote"
+	RequestID string
+
+	// Fingerprint identifies the client the vote came from, set by the API servers from its
+	// address and user agent; see ClientFingerprint.
+	Fingerprint string `json:"-"`
+}
+
+// GetVoteReport asks VoteAuditActor for the VoteReport, on behalf of an admin
+type GetVoteReport struct {
+	UserID    int
+	RequestID string
+}
+
+// Notification Delivery, sent to NotificationActor. Replies name the recipient by ID;
+// mentions name them by username, which NotificationActor resolves through UserActor.
+type Notify struct {
+	RecipientID   int
+	RecipientName string
+	Notification  Notification
+}
+
+// Notification Inbox; GetNotifications is answered with NotificationPage
+type GetNotifications struct {
+	UserID     int
+	UnreadOnly bool
+	Page       int
+	PageSize   int
+	RequestID  string
+}
+
+type NotificationPage struct {
+	Unread        int            `json:"unread"`
+	Notifications []Notification `json:"notifications"`
+}
+
+// MarkNotificationsRead marks the given notifications read, or all of them when IDs is empty
+type MarkNotificationsRead struct {
+	UserID    int
+	IDs       []int
+	RequestID string
 }
 
 // Karma Management
 type UpdateKarma struct {
 	UserID      int
 	KarmaChange int
+	RequestID   string
 }
 
 // User-Post Linking
@@ -133,4 +332,250 @@ type AssignUserActor struct {
 }
 
 // Retrieve All Users
-type GetAllUsers struct{}
+type GetAllUsers struct {
+	RequestID string
+}
+
+// Retrieve One User by ID, or by Username when it is set; answered with *User, or nil when
+// the user does not exist
+type GetUser struct {
+	UserID    int
+	Username  string
+	RequestID string
+}
+
+// Posts from a set of subreddits, newest first; answered with []PostSummary.
+// The facade fills Subreddits from the user's memberships when UserID is given.
+type GetFeed struct {
+	UserID     int
+	Subreddits []string
+	FlairID    int          // only posts with this post flair, when set
+	Hidden     map[int]bool `json:"-"` // posts UserID hid, filled in by the facade
+	Page       int
+	PageSize   int
+	RequestID  string
+}
+
+// Full-text Search over posts and comments; answered with SearchResults
+type Search struct {
+	Query     SearchQuery
+	AuthorID  int // resolved from Query.Author, 0 for any author
+	Page      int
+	PageSize  int
+	RequestID string
+}
+
+// Webhook Management, moderators only; each is answered with a webhookResponse
+type RegisterWebhook struct {
+	Subreddit string
+	UserID    int
+	URL       string
+	Events    []string
+	RequestID string
+}
+
+type ListWebhooks struct {
+	Subreddit string
+	UserID    int
+	RequestID string
+}
+
+type DeleteWebhook struct {
+	Subreddit string
+	WebhookID int
+	UserID    int
+	RequestID string
+}
+
+// Saved and Hidden Posts, handled by SavedActor. Type is SavedPost or SavedComment; only
+// posts can be hidden.
+type SaveItem struct {
+	UserID    int
+	Type      string
+	ID        int
+	RequestID string
+}
+
+type UnsaveItem struct {
+	UserID    int
+	Type      string
+	ID        int
+	RequestID string
+}
+
+type HidePost struct {
+	UserID    int
+	PostID    int
+	RequestID string
+}
+
+type UnhidePost struct {
+	UserID    int
+	PostID    int
+	RequestID string
+}
+
+// GetSaved is answered with a page of the user's []SavedItem of Type, or of both types when
+// Type is empty, most recently saved first
+type GetSaved struct {
+	UserID    int
+	Type      string
+	Page      int
+	PageSize  int
+	RequestID string
+}
+
+// GetHidden is answered with the IDs of the user's hidden posts, as a map[int]bool
+type GetHidden struct {
+	UserID    int
+	RequestID string
+}
+
+// Flair Management, handled by SubredditActor; each is answered with a flairResponse.
+// Only moderators may create or delete templates.
+type CreateFlair struct {
+	Subreddit string
+	UserID    int
+	Type      string // FlairPost or FlairUser
+	Text      string
+	Color     string
+	ModOnly   bool
+	RequestID string
+}
+
+// ListFlairs lists the templates of Type, or all of them when Type is empty
+type ListFlairs struct {
+	Subreddit string
+	Type      string
+	RequestID string
+}
+
+type DeleteFlair struct {
+	Subreddit string
+	FlairID   int
+	UserID    int
+	RequestID string
+}
+
+// SetUserFlair picks the user's own flair in a subreddit; FlairID 0 clears it
+type SetUserFlair struct {
+	Subreddit string
+	UserID    int
+	FlairID   int
+	RequestID string
+}
+
+// ResolveFlair looks up the post flair UserID may apply in Subreddit, and whether they
+// moderate it. FlairID 0 only answers the latter.
+type ResolveFlair struct {
+	Subreddit string
+	FlairID   int
+	UserID    int
+	RequestID string
+}
+
+// GetUserFlairs is answered with the user flair of each user who picked one, by subreddit
+// and user ID: map[string]map[int]FlairTemplate
+type GetUserFlairs struct {
+	Subreddits []string
+	RequestID  string
+}
+
+// Content Reports, handled by ReportActor. Target is ReportPost or ReportComment; Subreddit
+// is filled in by the engine from the reported item.
+type ReportContent struct {
+	UserID    int
+	Target    string
+	ID        int
+	Reason    string
+	Subreddit string
+	RequestID string
+}
+
+// SetReportReasons replaces a subreddit's report reasons; handled by SubredditActor once
+// the user was checked to be a moderator. No reasons restores DefaultReportReasons.
+type SetReportReasons struct {
+	Subreddit string
+	UserID    int
+	Reasons   []string
+	RequestID string
+}
+
+// BanUser bars BannedID from posting, crossposting and joining a subreddit and ends their
+// membership; handled by SubredditActor once the user was checked to be a moderator.
+type BanUser struct {
+	Subreddit string
+	UserID    int
+	BannedID  int
+	RequestID string
+}
+
+// UnbanUser lifts a ban; BannedID may join again
+type UnbanUser struct {
+	Subreddit string
+	UserID    int
+	BannedID  int
+	RequestID string
+}
+
+// GetModQueue is answered with a page of the subreddit's []*Report still awaiting a
+// moderator, most reported first
+type GetModQueue struct {
+	Subreddit string
+	UserID    int
+	Page      int
+	PageSize  int
+	RequestID string
+}
+
+// ResolveReports applies a moderator's Action (ReportApprove, ReportRemove or ReportIgnore)
+// to a reported item; answered with the resolved *Report, or ErrNoSuchReport
+type ResolveReports struct {
+	Subreddit string
+	UserID    int
+	Target    string
+	ID        int
+	Action    string
+	RequestID string
+}
+
+// RemoveContent takes down a post or comment on a moderator's behalf; handled by PostActor
+type RemoveContent struct {
+	Target      string // ReportPost or ReportComment
+	ID          int
+	ModeratorID int
+	RequestID   string
+}
+
+// SetAutoModRules replaces a subreddit's AutoModerator rules, compiled by the engine first.
+// SubredditActor keeps them and PostActor applies them.
+type SetAutoModRules struct {
+	Subreddit string
+	UserID    int
+	Rules     []AutoModRule
+	RequestID string
+}
+
+// TestAutoMod is a dry run of AutoModerator rules against an item AuthorID might submit
+type TestAutoMod struct {
+	Subreddit string
+	UserID    int // the moderator asking
+	AuthorID  int // whose karma and account age the rules see
+	Item      AutoModItem
+	Rules     []AutoModRule // the subreddit's own rules when nil
+	RequestID string
+}
+
+// ApproveContent lets a post or comment AutoModerator or the spam filter held go live; handled by PostActor
+type ApproveContent struct {
+	Target      string // ReportPost or ReportComment
+	ID          int
+	ModeratorID int
+	Ignored     bool // the moderator ignored the reports rather than approving; the spam filter doesn't learn from it
+	RequestID   string
+}
+
+// GetSpamStats asks PostActor for the SpamStats of its spam filter
+type GetSpamStats struct {
+	RequestID string
+}
@@ -1,5 +1,7 @@
 package engine
 
+import "time"
+
 type User struct {
 	ID           int
 	Username     string
@@ -7,32 +9,112 @@ type User struct {
 	Karma        int
 	PostKarma    int
 	CommentKarma int
+	CreatedAt    time.Time
 }
 
 type Subreddit struct {
-	ID      int
-	Name    string
-	Members map[int]bool
-	Posts   []int
+	ID            int
+	Name          string
+	Members       map[int]bool
+	Moderators    map[int]bool
+	Banned        map[int]bool // users the moderators barred from posting and joining
+	Posts         []int
+	CreatedAt     time.Time
+	LastActivity  time.Time
+	Flairs        []FlairTemplate
+	UserFlairs    map[int]int // the FlairUser template each user picked, by user ID
+	NextFlairID   int
+	ReportReasons []string // what users may report content for; DefaultReportReasons when empty
+	AutoModRules  []AutoModRule
+}
+
+// CrosspostParent names the post a crosspost was made from, as it was when crossposted
+type CrosspostParent struct {
+	PostID    int    `json:"post_id"`
+	Subreddit string `json:"subreddit"`
+	UserID    int    `json:"user_id"`
+}
+
+// FlairTemplate is a post or user flair a subreddit's moderators defined. Posts keep a copy
+// of the template they were flaired with; users refer to theirs by ID.
+type FlairTemplate struct {
+	ID      int    `json:"id"`
+	Type    string `json:"type"` // FlairPost or FlairUser
+	Text    string `json:"text"`
+	Color   string `json:"color,omitempty"` // "#rrggbb"
+	ModOnly bool   `json:"mod_only"`        // only moderators may apply it
 }
 
 type Post struct {
-	ID        int
-	UserID    int
-	Subreddit string
-	Content   string
-	Upvotes   int
-	Downvotes int
-	Comments  []*Comment
+	ID          int
+	UserID      int
+	Subreddit   string
+	Title       string
+	Kind        string // PostText, PostLink or PostImage; empty for posts made before kinds existed
+	URL         string // normalized link or image URL
+	Domain      string
+	Content     string // markdown source
+	ContentHTML string // Content rendered by RenderMarkdown
+	Media       []Media
+	Flair       *FlairTemplate
+	CrosspostOf *CrosspostParent
+	Crossposts  int // live crossposts of this post
+	Upvotes     int
+	Downvotes   int
+	Comments    []*Comment
+	Deleted     bool
+	Filtered    bool // held by AutoModerator until a moderator approves it
+	Locked      bool // no new comments
 }
 
 type Comment struct {
-	ID        int
-	PostID    int
-	ParentID  int
-	UserID    int
-	Content   string
-	Upvotes   int
-	Downvotes int
-	Replies   []*Comment
+	ID          int
+	PostID      int
+	ParentID    int
+	UserID      int
+	Content     string
+	ContentHTML string
+	Upvotes     int
+	Downvotes   int
+	Replies     []*Comment
+	Deleted     bool
+	Filtered    bool
+}
+
+type Notification struct {
+	ID         int       `json:"id"`
+	Type       string    `json:"type"` // see the Notification* constants
+	FromUserID int       `json:"from_user_id"`
+	PostID     int       `json:"post_id,omitempty"`
+	CommentID  int       `json:"comment_id,omitempty"`
+	Subreddit  string    `json:"subreddit,omitempty"`
+	Excerpt    string    `json:"excerpt"`
+	Read       bool      `json:"read"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Media is an uploaded file. Its bytes are kept in the BlobStore under ID and, for images,
+// a thumbnail under ID+ThumbnailSuffix.
+type Media struct {
+	ID          string    `json:"id"`
+	UserID      int       `json:"user_id"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Width       int       `json:"width,omitempty"`
+	Height      int       `json:"height,omitempty"`
+	Thumbnail   bool      `json:"thumbnail"`
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// Webhook is an endpoint a subreddit moderator registered for some of the subreddit's events
+type Webhook struct {
+	ID        int       `json:"id"`
+	Subreddit string    `json:"subreddit"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"` // see the Webhook* constants
+	Secret    string    `json:"secret,omitempty"`
+	CreatedBy int       `json:"created_by"`
+	Disabled  bool      `json:"disabled"`
+	Failures  int       `json:"consecutive_failures"`
+	CreatedAt time.Time `json:"created_at"`
 }
@@ -0,0 +1,157 @@
+package engine
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// Notification types
+const (
+	NotificationPostReply    = "post_reply"
+	NotificationCommentReply = "comment_reply"
+	NotificationMention      = "mention"
+	NotificationModAction    = "mod_action"
+)
+
+const (
+	DefaultNotificationPageSize = 25
+	MaxNotificationPageSize     = 100
+	excerptLength               = 140
+
+	// MaxInboxNotifications bounds each user's inbox; older notifications are dropped, read ones first
+	MaxInboxNotifications = 1000
+)
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w/])u/([A-Za-z0-9_-]+)`)
+
+// mentions returns the distinct usernames written as u/name in content.
+func mentions(content string) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		if key := strings.ToLower(match[1]); !seen[key] {
+			seen[key] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+func excerpt(content string) string {
+	runes := []rune(content)
+	if len(runes) <= excerptLength {
+		return content
+	}
+	return string(runes[:excerptLength]) + "…"
+}
+
+// NotificationActor keeps every user's notifications, newest last
+type NotificationActor struct {
+	inbox     map[int][]*Notification
+	userActor *actor.PID
+	timeout   time.Duration
+	store     Store
+	logger    *slog.Logger
+	mu        sync.Mutex
+}
+
+func (n *NotificationActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		n.mu.Lock()
+		n.inbox = n.store.Notifications()
+		n.logger.Debug("state restored", "inboxes", len(n.inbox))
+		n.mu.Unlock()
+
+	case *Notify:
+		if msg.RecipientName == "" {
+			n.deliver(ctx, msg.RecipientID, msg.Notification)
+			return
+		}
+		// Resolve the mentioned username without blocking the mailbox
+		future := ctx.RequestFuture(n.userActor, &GetUser{Username: msg.RecipientName}, n.timeout)
+		ctx.ReenterAfter(future, func(res interface{}, err error) {
+			user, ok := res.(*User)
+			if err != nil || !ok || user == nil {
+				n.logger.Debug("mentioned user does not exist", "username", msg.RecipientName)
+				return
+			}
+			if user.ID != msg.Notification.FromUserID {
+				n.deliver(ctx, user.ID, msg.Notification)
+			}
+		})
+
+	case *GetNotifications:
+		n.mu.Lock()
+		page := NotificationPage{Notifications: []Notification{}}
+		list := n.inbox[msg.UserID]
+		var selected []Notification
+		for i := len(list) - 1; i >= 0; i-- {
+			if !list[i].Read {
+				page.Unread++
+			}
+			if !msg.UnreadOnly || !list[i].Read {
+				selected = append(selected, *list[i])
+			}
+		}
+		n.mu.Unlock()
+		page.Notifications = paginate(selected, msg.Page, msg.PageSize, DefaultNotificationPageSize, MaxNotificationPageSize)
+		ctx.Respond(page)
+
+	case *MarkNotificationsRead:
+		n.mu.Lock()
+		ids := map[int]bool{}
+		for _, id := range msg.IDs {
+			ids[id] = true
+		}
+		marked := 0
+		for _, notification := range n.inbox[msg.UserID] {
+			if !notification.Read && (len(ids) == 0 || ids[notification.ID]) {
+				notification.Read = true
+				marked++
+			}
+		}
+		n.store.SaveNotifications(msg.UserID, n.inbox[msg.UserID])
+		n.logger.Info("notifications marked read", "request_id", msg.RequestID, "user_id", msg.UserID, "count", marked)
+		n.mu.Unlock()
+	}
+}
+
+func (n *NotificationActor) deliver(ctx actor.Context, userID int, notification Notification) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	// IDs keep counting up after old notifications are trimmed
+	notification.ID = 1
+	if list := n.inbox[userID]; len(list) > 0 {
+		notification.ID = list[len(list)-1].ID + 1
+	}
+	notification.CreatedAt = time.Now()
+	n.inbox[userID] = trimInbox(append(n.inbox[userID], &notification))
+	n.store.SaveNotifications(userID, n.inbox[userID])
+	sent := notification
+	publish(ctx, LiveEvent{Type: EventNotification, RecipientID: userID, Notification: &sent})
+	n.logger.Info("notification delivered", "user_id", userID, "type", notification.Type, "notification_id", notification.ID)
+}
+
+// trimInbox keeps an inbox, oldest first, within MaxInboxNotifications: the oldest read
+// notifications go first, then the oldest unread ones if that is not enough.
+func trimInbox(list []*Notification) []*Notification {
+	over := len(list) - MaxInboxNotifications
+	if over <= 0 {
+		return list
+	}
+	kept := make([]*Notification, 0, len(list)-over)
+	for _, notification := range list {
+		if over > 0 && notification.Read {
+			over--
+			continue
+		}
+		kept = append(kept, notification)
+	}
+	return kept[over:]
+}
@@ -0,0 +1,25 @@
+package engine
+
+import "testing"
+
+func TestTrimInbox(t *testing.T) {
+	inbox := func(n int, read func(id int) bool) []*Notification {
+		list := make([]*Notification, n)
+		for i := range list {
+			list[i] = &Notification{ID: i + 1, Read: read(i + 1)}
+		}
+		return list
+	}
+
+	// Read notifications go before older unread ones
+	list := trimInbox(inbox(MaxInboxNotifications+2, func(id int) bool { return id == 3 || id == 5 }))
+	if len(list) != MaxInboxNotifications || list[0].ID != 1 || list[2].ID != 4 || list[3].ID != 6 {
+		t.Fatalf("kept %d notifications starting %d, %d, %d, %d; want 1, 2, 4, 6", len(list), list[0].ID, list[1].ID, list[2].ID, list[3].ID)
+	}
+
+	// With nothing read, the oldest go
+	list = trimInbox(inbox(MaxInboxNotifications+2, func(int) bool { return false }))
+	if len(list) != MaxInboxNotifications || list[0].ID != 3 || list[len(list)-1].ID != MaxInboxNotifications+2 {
+		t.Fatalf("kept %d notifications from %d to %d, want 3 to %d", len(list), list[0].ID, list[len(list)-1].ID, MaxInboxNotifications+2)
+	}
+}
@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// idleAfter is how long an untouched bucket is kept; with the configured rates it has refilled by then.
+const idleAfter = 10 * time.Minute
+
+// Limiter holds one token bucket per key. A bucket refills continuously at the rate passed
+// to Allow and holds at most 1+burst tokens.
+type Limiter struct {
+	buckets map[string]*bucket
+	calls   int
+	now     func() time.Time
+	mu      sync.Mutex
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func New() *Limiter {
+	return &Limiter{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// Allow takes a token from the bucket for key. When the bucket is empty it reports false
+// and how long the caller has to wait for the next token.
+func (l *Limiter) Allow(key string, perMinute, burst int) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	capacity := float64(1 + burst)
+	ratePerSecond := float64(perMinute) / 60
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &bucket{tokens: capacity, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.last).Seconds()*ratePerSecond)
+	b.last = now
+
+	l.calls++
+	if l.calls%1024 == 0 {
+		l.sweep(now)
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / ratePerSecond * float64(time.Second))
+	return false, wait
+}
+
+func (l *Limiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > idleAfter {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns a limiter whose clock only moves when the returned advance is called
+func newTestLimiter() (*Limiter, func(time.Duration)) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := New()
+	l.now = func() time.Time { return now }
+	return l, func(d time.Duration) { now = now.Add(d) }
+}
+
+func TestAllowBurstCapacity(t *testing.T) {
+	l, _ := newTestLimiter()
+	for i := 0; i < 4; i++ {
+		if ok, _ := l.Allow("ip:post:1", 60, 3); !ok {
+			t.Fatalf("request %d refused, want 1+burst = 4 allowed at once", i+1)
+		}
+	}
+	ok, wait := l.Allow("ip:post:1", 60, 3)
+	if ok || wait != time.Second {
+		t.Fatalf("fifth request: allowed %v, wait %v; want refused with 1s to wait", ok, wait)
+	}
+	if ok, _ := l.Allow("ip:post:2", 60, 3); !ok {
+		t.Fatal("another key shares the exhausted bucket")
+	}
+}
+
+func TestAllowRefill(t *testing.T) {
+	l, advance := newTestLimiter()
+	for i := 0; i < 2; i++ {
+		l.Allow("user:vote:1", 6, 1)
+	}
+	if ok, wait := l.Allow("user:vote:1", 6, 1); ok || wait != 10*time.Second {
+		t.Fatalf("empty bucket: allowed %v, wait %v; want refused with 10s to wait", ok, wait)
+	}
+
+	advance(5 * time.Second)
+	if ok, wait := l.Allow("user:vote:1", 6, 1); ok || wait != 5*time.Second {
+		t.Fatalf("half a token: allowed %v, wait %v; want refused with 5s to wait", ok, wait)
+	}
+	advance(5 * time.Second)
+	if ok, _ := l.Allow("user:vote:1", 6, 1); !ok {
+		t.Fatal("refused after a token refilled")
+	}
+
+	// A long pause refills no more than the capacity
+	advance(time.Hour)
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow("user:vote:1", 6, 1); !ok {
+			t.Fatalf("request %d after a pause refused", i+1)
+		}
+	}
+	if ok, _ := l.Allow("user:vote:1", 6, 1); ok {
+		t.Fatal("bucket refilled past 1+burst tokens")
+	}
+}
+
+func TestSweepDropsIdleBuckets(t *testing.T) {
+	l, advance := newTestLimiter()
+	l.Allow("idle", 60, 0)
+	advance(idleAfter + time.Second)
+	for i := 0; i < 1023; i++ {
+		l.Allow("busy", 60, 1<<20)
+	}
+	if _, kept := l.buckets["idle"]; kept {
+		t.Fatal("idle bucket kept after the sweep")
+	}
+	if _, kept := l.buckets["busy"]; !kept {
+		t.Fatal("busy bucket swept")
+	}
+}
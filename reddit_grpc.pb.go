@@ -0,0 +1,781 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: reddit.proto
+
+package redditpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Reddit_RegisterUser_FullMethodName            = "/reddit.v1.Reddit/RegisterUser"
+	Reddit_GetUser_FullMethodName                 = "/reddit.v1.Reddit/GetUser"
+	Reddit_ListUsers_FullMethodName               = "/reddit.v1.Reddit/ListUsers"
+	Reddit_CreateSubreddit_FullMethodName         = "/reddit.v1.Reddit/CreateSubreddit"
+	Reddit_JoinSubreddit_FullMethodName           = "/reddit.v1.Reddit/JoinSubreddit"
+	Reddit_LeaveSubreddit_FullMethodName          = "/reddit.v1.Reddit/LeaveSubreddit"
+	Reddit_ListSubreddits_FullMethodName          = "/reddit.v1.Reddit/ListSubreddits"
+	Reddit_CreatePost_FullMethodName              = "/reddit.v1.Reddit/CreatePost"
+	Reddit_EditPost_FullMethodName                = "/reddit.v1.Reddit/EditPost"
+	Reddit_DeletePost_FullMethodName              = "/reddit.v1.Reddit/DeletePost"
+	Reddit_Crosspost_FullMethodName               = "/reddit.v1.Reddit/Crosspost"
+	Reddit_AddComment_FullMethodName              = "/reddit.v1.Reddit/AddComment"
+	Reddit_EditComment_FullMethodName             = "/reddit.v1.Reddit/EditComment"
+	Reddit_DeleteComment_FullMethodName           = "/reddit.v1.Reddit/DeleteComment"
+	Reddit_Vote_FullMethodName                    = "/reddit.v1.Reddit/Vote"
+	Reddit_GetFeed_FullMethodName                 = "/reddit.v1.Reddit/GetFeed"
+	Reddit_ListDomainPosts_FullMethodName         = "/reddit.v1.Reddit/ListDomainPosts"
+	Reddit_StreamSubredditActivity_FullMethodName = "/reddit.v1.Reddit/StreamSubredditActivity"
+)
+
+// RedditClient is the client API for Reddit service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RedditClient interface {
+	RegisterUser(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	CreateSubreddit(ctx context.Context, in *CreateSubredditRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	JoinSubreddit(ctx context.Context, in *MembershipRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	LeaveSubreddit(ctx context.Context, in *MembershipRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListSubreddits(ctx context.Context, in *ListSubredditsRequest, opts ...grpc.CallOption) (*ListSubredditsResponse, error)
+	CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	EditPost(ctx context.Context, in *EditPostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Crosspost shares a post into another subreddit the user is a member of, and returns the
+	// new post. Unlike the writes above it answers once the post exists.
+	Crosspost(ctx context.Context, in *CrosspostRequest, opts ...grpc.CallOption) (*Post, error)
+	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	EditComment(ctx context.Context, in *EditCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// GetFeed lists posts from the given subreddits, or from those the user joined, newest first.
+	// Posts the user hid are left out.
+	GetFeed(ctx context.Context, in *GetFeedRequest, opts ...grpc.CallOption) (*GetFeedResponse, error)
+	// ListDomainPosts lists link and image posts pointing at a domain, newest first.
+	ListDomainPosts(ctx context.Context, in *ListDomainPostsRequest, opts ...grpc.CallOption) (*ListDomainPostsResponse, error)
+	// StreamSubredditActivity sends every post, comment and score change in a subreddit (or in
+	// one post) as it happens. A client that falls too far behind gets RESOURCE_EXHAUSTED.
+	StreamSubredditActivity(ctx context.Context, in *StreamSubredditActivityRequest, opts ...grpc.CallOption) (Reddit_StreamSubredditActivityClient, error)
+}
+
+type redditClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRedditClient(cc grpc.ClientConnInterface) RedditClient {
+	return &redditClient{cc}
+}
+
+func (c *redditClient) RegisterUser(ctx context.Context, in *RegisterUserRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Reddit_RegisterUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, Reddit_GetUser_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, Reddit_ListUsers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) CreateSubreddit(ctx context.Context, in *CreateSubredditRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Reddit_CreateSubreddit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) JoinSubreddit(ctx context.Context, in *MembershipRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Reddit_JoinSubreddit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) LeaveSubreddit(ctx context.Context, in *MembershipRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Reddit_LeaveSubreddit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) ListSubreddits(ctx context.Context, in *ListSubredditsRequest, opts ...grpc.CallOption) (*ListSubredditsResponse, error) {
+	out := new(ListSubredditsResponse)
+	err := c.cc.Invoke(ctx, Reddit_ListSubreddits_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) CreatePost(ctx context.Context, in *CreatePostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Reddit_CreatePost_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) EditPost(ctx context.Context, in *EditPostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Reddit_EditPost_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) DeletePost(ctx context.Context, in *DeletePostRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Reddit_DeletePost_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) Crosspost(ctx context.Context, in *CrosspostRequest, opts ...grpc.CallOption) (*Post, error) {
+	out := new(Post)
+	err := c.cc.Invoke(ctx, Reddit_Crosspost_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Reddit_AddComment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) EditComment(ctx context.Context, in *EditCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Reddit_EditComment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Reddit_DeleteComment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Reddit_Vote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) GetFeed(ctx context.Context, in *GetFeedRequest, opts ...grpc.CallOption) (*GetFeedResponse, error) {
+	out := new(GetFeedResponse)
+	err := c.cc.Invoke(ctx, Reddit_GetFeed_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) ListDomainPosts(ctx context.Context, in *ListDomainPostsRequest, opts ...grpc.CallOption) (*ListDomainPostsResponse, error) {
+	out := new(ListDomainPostsResponse)
+	err := c.cc.Invoke(ctx, Reddit_ListDomainPosts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *redditClient) StreamSubredditActivity(ctx context.Context, in *StreamSubredditActivityRequest, opts ...grpc.CallOption) (Reddit_StreamSubredditActivityClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Reddit_ServiceDesc.Streams[0], Reddit_StreamSubredditActivity_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &redditStreamSubredditActivityClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Reddit_StreamSubredditActivityClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type redditStreamSubredditActivityClient struct {
+	grpc.ClientStream
+}
+
+func (x *redditStreamSubredditActivityClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RedditServer is the server API for Reddit service.
+// All implementations must embed UnimplementedRedditServer
+// for forward compatibility
+type RedditServer interface {
+	RegisterUser(context.Context, *RegisterUserRequest) (*emptypb.Empty, error)
+	GetUser(context.Context, *GetUserRequest) (*User, error)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	CreateSubreddit(context.Context, *CreateSubredditRequest) (*emptypb.Empty, error)
+	JoinSubreddit(context.Context, *MembershipRequest) (*emptypb.Empty, error)
+	LeaveSubreddit(context.Context, *MembershipRequest) (*emptypb.Empty, error)
+	ListSubreddits(context.Context, *ListSubredditsRequest) (*ListSubredditsResponse, error)
+	CreatePost(context.Context, *CreatePostRequest) (*emptypb.Empty, error)
+	EditPost(context.Context, *EditPostRequest) (*emptypb.Empty, error)
+	DeletePost(context.Context, *DeletePostRequest) (*emptypb.Empty, error)
+	// Crosspost shares a post into another subreddit the user is a member of, and returns the
+	// new post. Unlike the writes above it answers once the post exists.
+	Crosspost(context.Context, *CrosspostRequest) (*Post, error)
+	AddComment(context.Context, *AddCommentRequest) (*emptypb.Empty, error)
+	EditComment(context.Context, *EditCommentRequest) (*emptypb.Empty, error)
+	DeleteComment(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error)
+	Vote(context.Context, *VoteRequest) (*emptypb.Empty, error)
+	// GetFeed lists posts from the given subreddits, or from those the user joined, newest first.
+	// Posts the user hid are left out.
+	GetFeed(context.Context, *GetFeedRequest) (*GetFeedResponse, error)
+	// ListDomainPosts lists link and image posts pointing at a domain, newest first.
+	ListDomainPosts(context.Context, *ListDomainPostsRequest) (*ListDomainPostsResponse, error)
+	// StreamSubredditActivity sends every post, comment and score change in a subreddit (or in
+	// one post) as it happens. A client that falls too far behind gets RESOURCE_EXHAUSTED.
+	StreamSubredditActivity(*StreamSubredditActivityRequest, Reddit_StreamSubredditActivityServer) error
+	mustEmbedUnimplementedRedditServer()
+}
+
+// UnimplementedRedditServer must be embedded to have forward compatible implementations.
+type UnimplementedRedditServer struct {
+}
+
+func (UnimplementedRedditServer) RegisterUser(context.Context, *RegisterUserRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterUser not implemented")
+}
+func (UnimplementedRedditServer) GetUser(context.Context, *GetUserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUser not implemented")
+}
+func (UnimplementedRedditServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedRedditServer) CreateSubreddit(context.Context, *CreateSubredditRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSubreddit not implemented")
+}
+func (UnimplementedRedditServer) JoinSubreddit(context.Context, *MembershipRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinSubreddit not implemented")
+}
+func (UnimplementedRedditServer) LeaveSubreddit(context.Context, *MembershipRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveSubreddit not implemented")
+}
+func (UnimplementedRedditServer) ListSubreddits(context.Context, *ListSubredditsRequest) (*ListSubredditsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSubreddits not implemented")
+}
+func (UnimplementedRedditServer) CreatePost(context.Context, *CreatePostRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePost not implemented")
+}
+func (UnimplementedRedditServer) EditPost(context.Context, *EditPostRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditPost not implemented")
+}
+func (UnimplementedRedditServer) DeletePost(context.Context, *DeletePostRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePost not implemented")
+}
+func (UnimplementedRedditServer) Crosspost(context.Context, *CrosspostRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Crosspost not implemented")
+}
+func (UnimplementedRedditServer) AddComment(context.Context, *AddCommentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddComment not implemented")
+}
+func (UnimplementedRedditServer) EditComment(context.Context, *EditCommentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditComment not implemented")
+}
+func (UnimplementedRedditServer) DeleteComment(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (UnimplementedRedditServer) Vote(context.Context, *VoteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Vote not implemented")
+}
+func (UnimplementedRedditServer) GetFeed(context.Context, *GetFeedRequest) (*GetFeedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeed not implemented")
+}
+func (UnimplementedRedditServer) ListDomainPosts(context.Context, *ListDomainPostsRequest) (*ListDomainPostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDomainPosts not implemented")
+}
+func (UnimplementedRedditServer) StreamSubredditActivity(*StreamSubredditActivityRequest, Reddit_StreamSubredditActivityServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamSubredditActivity not implemented")
+}
+func (UnimplementedRedditServer) mustEmbedUnimplementedRedditServer() {}
+
+// UnsafeRedditServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RedditServer will
+// result in compilation errors.
+type UnsafeRedditServer interface {
+	mustEmbedUnimplementedRedditServer()
+}
+
+func RegisterRedditServer(s grpc.ServiceRegistrar, srv RedditServer) {
+	s.RegisterService(&Reddit_ServiceDesc, srv)
+}
+
+func _Reddit_RegisterUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).RegisterUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_RegisterUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).RegisterUser(ctx, req.(*RegisterUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_GetUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).GetUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_GetUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).GetUser(ctx, req.(*GetUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_CreateSubreddit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSubredditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).CreateSubreddit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_CreateSubreddit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).CreateSubreddit(ctx, req.(*CreateSubredditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_JoinSubreddit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MembershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).JoinSubreddit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_JoinSubreddit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).JoinSubreddit(ctx, req.(*MembershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_LeaveSubreddit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MembershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).LeaveSubreddit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_LeaveSubreddit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).LeaveSubreddit(ctx, req.(*MembershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_ListSubreddits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSubredditsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).ListSubreddits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_ListSubreddits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).ListSubreddits(ctx, req.(*ListSubredditsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_CreatePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).CreatePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_CreatePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).CreatePost(ctx, req.(*CreatePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_EditPost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditPostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).EditPost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_EditPost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).EditPost(ctx, req.(*EditPostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_DeletePost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).DeletePost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_DeletePost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).DeletePost(ctx, req.(*DeletePostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_Crosspost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrosspostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).Crosspost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_Crosspost_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).Crosspost(ctx, req.(*CrosspostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).AddComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_AddComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).AddComment(ctx, req.(*AddCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_EditComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).EditComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_EditComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).EditComment(ctx, req.(*EditCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_DeleteComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_Vote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).Vote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_Vote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).Vote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_GetFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).GetFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_GetFeed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).GetFeed(ctx, req.(*GetFeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_ListDomainPosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDomainPostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RedditServer).ListDomainPosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Reddit_ListDomainPosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RedditServer).ListDomainPosts(ctx, req.(*ListDomainPostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Reddit_StreamSubredditActivity_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamSubredditActivityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RedditServer).StreamSubredditActivity(m, &redditStreamSubredditActivityServer{stream})
+}
+
+type Reddit_StreamSubredditActivityServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type redditStreamSubredditActivityServer struct {
+	grpc.ServerStream
+}
+
+func (x *redditStreamSubredditActivityServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Reddit_ServiceDesc is the grpc.ServiceDesc for Reddit service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Reddit_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "reddit.v1.Reddit",
+	HandlerType: (*RedditServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterUser",
+			Handler:    _Reddit_RegisterUser_Handler,
+		},
+		{
+			MethodName: "GetUser",
+			Handler:    _Reddit_GetUser_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _Reddit_ListUsers_Handler,
+		},
+		{
+			MethodName: "CreateSubreddit",
+			Handler:    _Reddit_CreateSubreddit_Handler,
+		},
+		{
+			MethodName: "JoinSubreddit",
+			Handler:    _Reddit_JoinSubreddit_Handler,
+		},
+		{
+			MethodName: "LeaveSubreddit",
+			Handler:    _Reddit_LeaveSubreddit_Handler,
+		},
+		{
+			MethodName: "ListSubreddits",
+			Handler:    _Reddit_ListSubreddits_Handler,
+		},
+		{
+			MethodName: "CreatePost",
+			Handler:    _Reddit_CreatePost_Handler,
+		},
+		{
+			MethodName: "EditPost",
+			Handler:    _Reddit_EditPost_Handler,
+		},
+		{
+			MethodName: "DeletePost",
+			Handler:    _Reddit_DeletePost_Handler,
+		},
+		{
+			MethodName: "Crosspost",
+			Handler:    _Reddit_Crosspost_Handler,
+		},
+		{
+			MethodName: "AddComment",
+			Handler:    _Reddit_AddComment_Handler,
+		},
+		{
+			MethodName: "EditComment",
+			Handler:    _Reddit_EditComment_Handler,
+		},
+		{
+			MethodName: "DeleteComment",
+			Handler:    _Reddit_DeleteComment_Handler,
+		},
+		{
+			MethodName: "Vote",
+			Handler:    _Reddit_Vote_Handler,
+		},
+		{
+			MethodName: "GetFeed",
+			Handler:    _Reddit_GetFeed_Handler,
+		},
+		{
+			MethodName: "ListDomainPosts",
+			Handler:    _Reddit_ListDomainPosts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamSubredditActivity",
+			Handler:       _Reddit_StreamSubredditActivity_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "reddit.proto",
+}
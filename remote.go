@@ -0,0 +1,307 @@
+package engine
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative engine.proto
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"reddit_clone2/enginepb"
+	"strconv"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/remote"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Remote kinds the engine registers. Spawning one on the engine node gives a PID that accepts
+// the enginepb messages for that part of the engine.
+const (
+	KindUser      = "user"
+	KindSubreddit = "subreddit"
+	KindPost      = "post"
+)
+
+// StartRemote serves the engine over protoactor remote on addr ("host:port"). Call it after
+// SetupActors.
+func (as *ActorSystem) StartRemote(addr string) error {
+	host, port, err := splitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	kinds := []*remote.Kind{
+		remote.NewKind(KindUser, as.adapterProps(KindUser)),
+		remote.NewKind(KindSubreddit, as.adapterProps(KindSubreddit)),
+		remote.NewKind(KindPost, as.adapterProps(KindPost)),
+	}
+	r := remote.NewRemote(as.RootContext.ActorSystem(), remote.Configure(host, port, remote.WithKinds(kinds...)))
+	if err := startRemote(r, addr); err != nil {
+		return err
+	}
+	as.remote = r
+	as.Logger.Info("engine remote started", "addr", as.RootContext.ActorSystem().Address(), "kinds", []string{KindUser, KindSubreddit, KindPost})
+	return nil
+}
+
+func (as *ActorSystem) adapterProps(kind string) *actor.Props {
+	return actor.PropsFromProducer(func() actor.Actor {
+		return &remoteAdapter{kind: kind, system: as}
+	}, tracingMiddleware("RemoteAdapter")...)
+}
+
+// remoteAdapter translates enginepb messages from remote callers into engine messages for the
+// local actors, and their answers back into enginepb. The client hands it a PID to watch and
+// it stops when that PID terminates, so a client that exits without Close, or whose node
+// becomes unreachable, does not leave adapters behind.
+type remoteAdapter struct {
+	kind   string
+	system *ActorSystem
+}
+
+func (a *remoteAdapter) Receive(ctx actor.Context) {
+	as := a.system
+	switch msg := ctx.Message().(type) {
+	case *actor.PID:
+		ctx.Watch(msg)
+		ctx.Respond(msg)
+	case *actor.Terminated:
+		as.Logger.Info("remote client gone, stopping adapter", "kind", a.kind, "client", msg.Who.String(), "reason", msg.Why.String())
+		ctx.Stop(ctx.Self())
+
+	case *enginepb.RegisterUser:
+		ctx.Send(as.UserActor, &RegisterUser{Username: msg.Username, Password: msg.Password, RequestID: msg.RequestId})
+	case *enginepb.GetAllUsers:
+		a.forward(ctx, as.UserActor, &GetAllUsers{RequestID: msg.RequestId}, func(res interface{}) interface{} {
+			users, _ := res.(map[int]*User)
+			out := &enginepb.Users{Users: make(map[int64]*enginepb.User, len(users))}
+			for id, user := range users {
+				out.Users[int64(id)] = userToProto(user)
+			}
+			return out
+		})
+	case *enginepb.GetUser:
+		a.forward(ctx, as.UserActor, &GetUser{UserID: int(msg.UserId), Username: msg.Username, RequestID: msg.RequestId}, func(res interface{}) interface{} {
+			user, _ := res.(*User)
+			if user == nil {
+				return &enginepb.UserResult{}
+			}
+			return &enginepb.UserResult{User: userToProto(user), Found: true}
+		})
+
+	case *enginepb.CreateSubreddit:
+		ctx.Send(as.SubredditActor, &CreateSubreddit{Name: msg.Name, CreatorID: int(msg.CreatorId), RequestID: msg.RequestId})
+	case *enginepb.JoinSubreddit:
+		ctx.Send(as.SubredditActor, &JoinSubreddit{UserID: int(msg.UserId), Name: msg.Name, RequestID: msg.RequestId})
+	case *enginepb.LeaveSubreddit:
+		ctx.Send(as.SubredditActor, &LeaveSubreddit{UserID: int(msg.UserId), Name: msg.Name, RequestID: msg.RequestId})
+
+	case *enginepb.PostMessage:
+		ctx.Send(as.PostActor, &PostMessage{UserID: int(msg.UserId), Subreddit: msg.Subreddit, Title: msg.Title, Kind: msg.Kind, URL: msg.Url, Content: msg.Content, RequestID: msg.RequestId})
+	case *enginepb.EditPost:
+		ctx.Send(as.PostActor, &EditPost{UserID: int(msg.UserId), PostID: int(msg.PostId), Content: msg.Content, RequestID: msg.RequestId})
+	case *enginepb.DeletePost:
+		ctx.Send(as.PostActor, &DeletePost{UserID: int(msg.UserId), PostID: int(msg.PostId), RequestID: msg.RequestId})
+	case *enginepb.CommentMessage:
+		ctx.Send(as.PostActor, &CommentMessage{UserID: int(msg.UserId), PostID: int(msg.PostId), ParentID: int(msg.ParentId), Content: msg.Content, RequestID: msg.RequestId})
+	case *enginepb.EditComment:
+		ctx.Send(as.PostActor, &EditComment{UserID: int(msg.UserId), CommentID: int(msg.CommentId), Content: msg.Content, RequestID: msg.RequestId})
+	case *enginepb.DeleteComment:
+		ctx.Send(as.PostActor, &DeleteComment{UserID: int(msg.UserId), CommentID: int(msg.CommentId), RequestID: msg.RequestId})
+	case *enginepb.Vote:
+		ctx.Send(as.PostActor, &Vote{UserID: int(msg.UserId), Target: msg.Target, ID: int(msg.Id), Type: msg.Type, RequestID: msg.RequestId, Fingerprint: msg.Fingerprint})
+	}
+}
+
+// forward asks pid and answers the remote caller with convert(answer), without blocking the mailbox.
+func (a *remoteAdapter) forward(ctx actor.Context, pid *actor.PID, msg interface{}, convert func(interface{}) interface{}) {
+	future := ctx.RequestFuture(pid, msg, a.system.RequestTimeout)
+	ctx.ReenterAfter(future, func(res interface{}, err error) {
+		if err != nil {
+			a.system.Logger.Error("remote request failed", "kind", a.kind, "message", fmt.Sprintf("%T", msg), "error", err)
+			return
+		}
+		ctx.Respond(convert(res))
+	})
+}
+
+func userToProto(user *User) *enginepb.User {
+	return &enginepb.User{
+		Id:           int64(user.ID),
+		Username:     user.Username,
+		Karma:        int64(user.Karma),
+		PostKarma:    int64(user.PostKarma),
+		CommentKarma: int64(user.CommentKarma),
+		CreatedAt:    timestamppb.New(user.CreatedAt),
+	}
+}
+
+func userFromProto(user *enginepb.User) *User {
+	return &User{
+		ID:           int(user.Id),
+		Username:     user.Username,
+		Karma:        int(user.Karma),
+		PostKarma:    int(user.PostKarma),
+		CommentKarma: int(user.CommentKarma),
+		CreatedAt:    user.CreatedAt.AsTime(),
+	}
+}
+
+// RemoteClient drives an engine in another process over protoactor remote. Its methods match
+// the ActorSystem facade, so the simulator can use either.
+type RemoteClient struct {
+	root           *actor.RootContext
+	remote         *remote.Remote
+	userActor      *actor.PID
+	subredditActor *actor.PID
+	postActor      *actor.PID
+	lifeline       *actor.PID // watched by the adapters, which stop when it does
+	RequestTimeout time.Duration
+}
+
+// DialRemote starts a client node on localAddr (port 0 picks a free one) and spawns an
+// adapter of each engine kind on the engine node at engineAddr. The adapters watch an actor
+// on the client node and stop when it or the connection to it goes away.
+func DialRemote(localAddr, engineAddr string, timeout time.Duration, logger *slog.Logger) (*RemoteClient, error) {
+	host, port, err := splitHostPort(localAddr)
+	if err != nil {
+		return nil, err
+	}
+	system := newProtoActorSystem(logger)
+	client := &RemoteClient{root: system.Root, RequestTimeout: timeout}
+	client.remote = remote.NewRemote(system, remote.Configure(host, port))
+	if err := startRemote(client.remote, localAddr); err != nil {
+		return nil, err
+	}
+
+	name := "client-" + strconv.FormatInt(time.Now().UnixNano(), 36)
+	client.lifeline, err = system.Root.SpawnNamed(actor.PropsFromFunc(func(actor.Context) {}), name)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	for kind, pid := range map[string]**actor.PID{KindUser: &client.userActor, KindSubreddit: &client.subredditActor, KindPost: &client.postActor} {
+		resp, err := client.remote.SpawnNamed(engineAddr, name+"-"+kind, kind, timeout)
+		if err != nil {
+			client.Close()
+			return nil, fmt.Errorf("spawning %s on %s: %w", kind, engineAddr, err)
+		}
+		*pid = resp.Pid
+		// Wait for the adapter to watch the lifeline, so it stops even if the client dies next
+		if _, err := client.root.RequestFuture(resp.Pid, client.lifeline, timeout).Result(); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("attaching %s on %s: %w", kind, engineAddr, err)
+		}
+	}
+	return client, nil
+}
+
+// Close stops the adapters spawned on the engine and shuts the client node down.
+func (c *RemoteClient) Close() {
+	for _, pid := range []*actor.PID{c.userActor, c.subredditActor, c.postActor, c.lifeline} {
+		if pid != nil {
+			c.root.Stop(pid)
+		}
+	}
+	c.remote.Shutdown(true)
+}
+
+func (c *RemoteClient) send(ctx context.Context, pid *actor.PID, msg interface{}) {
+	c.root.Send(pid, tracedEnvelope(ctx, msg, nil))
+}
+
+func (c *RemoteClient) request(ctx context.Context, pid *actor.PID, msg interface{}) (interface{}, error) {
+	future := actor.NewFuture(c.root.ActorSystem(), c.RequestTimeout)
+	c.root.Send(pid, tracedEnvelope(ctx, msg, future.PID()))
+	return future.Result()
+}
+
+func (c *RemoteClient) RegisterUser(ctx context.Context, msg RegisterUser) {
+	c.send(ctx, c.userActor, &enginepb.RegisterUser{Username: msg.Username, Password: msg.Password, RequestId: msg.RequestID})
+}
+
+func (c *RemoteClient) GetAllUsers(ctx context.Context, msg GetAllUsers) map[int]*User {
+	result, err := c.request(ctx, c.userActor, &enginepb.GetAllUsers{RequestId: msg.RequestID})
+	users, ok := result.(*enginepb.Users)
+	if err != nil || !ok {
+		return nil
+	}
+	out := make(map[int]*User, len(users.Users))
+	for id, user := range users.Users {
+		out[int(id)] = userFromProto(user)
+	}
+	return out
+}
+
+func (c *RemoteClient) GetUser(ctx context.Context, msg GetUser) (*User, bool) {
+	result, err := c.request(ctx, c.userActor, &enginepb.GetUser{UserId: int64(msg.UserID), Username: msg.Username, RequestId: msg.RequestID})
+	found, ok := result.(*enginepb.UserResult)
+	if err != nil || !ok || !found.Found {
+		return nil, false
+	}
+	return userFromProto(found.User), true
+}
+
+func (c *RemoteClient) CreateSubreddit(ctx context.Context, msg CreateSubreddit) {
+	c.send(ctx, c.subredditActor, &enginepb.CreateSubreddit{Name: msg.Name, CreatorId: int64(msg.CreatorID), RequestId: msg.RequestID})
+}
+
+func (c *RemoteClient) JoinSubreddit(ctx context.Context, msg JoinSubreddit) {
+	c.send(ctx, c.subredditActor, &enginepb.JoinSubreddit{UserId: int64(msg.UserID), Name: msg.Name, RequestId: msg.RequestID})
+}
+
+func (c *RemoteClient) LeaveSubreddit(ctx context.Context, msg LeaveSubreddit) {
+	c.send(ctx, c.subredditActor, &enginepb.LeaveSubreddit{UserId: int64(msg.UserID), Name: msg.Name, RequestId: msg.RequestID})
+}
+
+func (c *RemoteClient) CreatePost(ctx context.Context, msg PostMessage) {
+	c.send(ctx, c.postActor, &enginepb.PostMessage{UserId: int64(msg.UserID), Subreddit: msg.Subreddit, Title: msg.Title, Kind: msg.Kind, Url: msg.URL, Content: msg.Content, RequestId: msg.RequestID})
+}
+
+func (c *RemoteClient) EditPost(ctx context.Context, msg EditPost) {
+	c.send(ctx, c.postActor, &enginepb.EditPost{UserId: int64(msg.UserID), PostId: int64(msg.PostID), Content: msg.Content, RequestId: msg.RequestID})
+}
+
+func (c *RemoteClient) DeletePost(ctx context.Context, msg DeletePost) {
+	c.send(ctx, c.postActor, &enginepb.DeletePost{UserId: int64(msg.UserID), PostId: int64(msg.PostID), RequestId: msg.RequestID})
+}
+
+func (c *RemoteClient) AddComment(ctx context.Context, msg CommentMessage) {
+	c.send(ctx, c.postActor, &enginepb.CommentMessage{UserId: int64(msg.UserID), PostId: int64(msg.PostID), ParentId: int64(msg.ParentID), Content: msg.Content, RequestId: msg.RequestID})
+}
+
+func (c *RemoteClient) EditComment(ctx context.Context, msg EditComment) {
+	c.send(ctx, c.postActor, &enginepb.EditComment{UserId: int64(msg.UserID), CommentId: int64(msg.CommentID), Content: msg.Content, RequestId: msg.RequestID})
+}
+
+func (c *RemoteClient) DeleteComment(ctx context.Context, msg DeleteComment) {
+	c.send(ctx, c.postActor, &enginepb.DeleteComment{UserId: int64(msg.UserID), CommentId: int64(msg.CommentID), RequestId: msg.RequestID})
+}
+
+func (c *RemoteClient) VotePost(ctx context.Context, msg Vote) {
+	c.send(ctx, c.postActor, &enginepb.Vote{UserId: int64(msg.UserID), Target: msg.Target, Id: int64(msg.ID), Type: msg.Type, RequestId: msg.RequestID, Fingerprint: msg.Fingerprint})
+}
+
+// startRemote starts r, turning the panic Remote.Start raises when it can't listen into an error.
+func startRemote(r *remote.Remote, addr string) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("starting remote on %s: %v", addr, p)
+		}
+	}()
+	r.Start()
+	return nil
+}
+
+func splitHostPort(addr string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", 0, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("port %q: %w", portStr, err)
+	}
+	return host, port, nil
+}
@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// dialTestRemote serves as over remote on a free local port and dials it from a second node
+func dialTestRemote(t *testing.T, as *ActorSystem) *RemoteClient {
+	t.Helper()
+	if err := as.StartRemote("127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	client, err := DialRemote("127.0.0.1:0", as.RootContext.ActorSystem().Address(), time.Second, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client
+}
+
+func TestRemoteRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	as := newTestActorSystem(t, NewMemoryStore())
+	client := dialTestRemote(t, as)
+	defer client.Close()
+
+	client.RegisterUser(ctx, RegisterUser{Username: "alice", Password: "secret"})
+	var alice *User
+	eventually(t, "alice over remote", func() bool {
+		user, found := client.GetUser(ctx, GetUser{Username: "alice"})
+		alice = user
+		return found
+	})
+	if alice.ID != 1 || alice.Username != "alice" {
+		t.Fatalf("remote GetUser = %+v, want user 1 alice", alice)
+	}
+
+	client.CreateSubreddit(ctx, CreateSubreddit{Name: "golang", CreatorID: alice.ID})
+	client.JoinSubreddit(ctx, JoinSubreddit{Name: "golang", UserID: alice.ID})
+	client.CreatePost(ctx, PostMessage{UserID: alice.ID, Subreddit: "golang", Title: "hello", Content: "from another node"})
+	var post *Post
+	eventually(t, "the remote post", func() bool {
+		p, err := as.livePost(ctx, 1, "")
+		post = p
+		return err == nil
+	})
+	if post.UserID != alice.ID || post.Subreddit != "golang" || post.Content != "from another node" {
+		t.Fatalf("post = %+v, want alice's post in r/golang", post)
+	}
+	if subreddit, err := as.subreddit(ctx, "golang", ""); err != nil || !subreddit.Members[alice.ID] {
+		t.Fatalf("r/golang = %+v (%v), want alice a member", subreddit, err)
+	}
+
+	client.VotePost(ctx, Vote{UserID: 2, Target: "post", ID: 1, Type: "upvote"})
+	eventually(t, "the remote vote", func() bool {
+		p, err := as.livePost(ctx, 1, "")
+		return err == nil && p.Upvotes == 1
+	})
+}
+
+func TestRemoteAdaptersStopWithTheClient(t *testing.T) {
+	as := newTestActorSystem(t, NewMemoryStore())
+	client := dialTestRemote(t, as)
+	adapters := []*actor.PID{client.userActor, client.subredditActor, client.postActor}
+	running := func() int {
+		n := 0
+		for _, pid := range adapters {
+			if _, ok := as.RootContext.ActorSystem().ProcessRegistry.GetLocal(pid.Id); ok {
+				n++
+			}
+		}
+		return n
+	}
+	if n := running(); n != len(adapters) {
+		t.Fatalf("%d of %d adapters running after dialing", n, len(adapters))
+	}
+
+	// The client node goes away without stopping its adapters
+	client.remote.Shutdown(false)
+	eventually(t, "the adapters to stop", func() bool { return running() == 0 })
+}
@@ -0,0 +1,362 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// Report targets
+const (
+	ReportPost    = "post"
+	ReportComment = "comment"
+)
+
+// Moderator actions on a reported item
+const (
+	ReportApprove = "approve" // keep the item and resolve its reports
+	ReportRemove  = "remove"  // remove the item and resolve its reports
+	ReportIgnore  = "ignore"  // keep the item and drop any further reports of it
+)
+
+const (
+	MaxReportReasons      = 15
+	MaxReportReasonLength = 100
+)
+
+// DefaultReportReasons apply to subreddits whose moderators haven't defined their own
+var DefaultReportReasons = []string{"Spam", "Harassment", "Misinformation", "Breaks the subreddit's rules"}
+
+var (
+	ErrInvalidReport = errors.New("invalid report")
+	ErrNoSuchReport  = errors.New("no open reports for the item")
+)
+
+// Report aggregates the open reports of one post or comment. Post or Comment is filled in
+// when the moderator queue is listed.
+type Report struct {
+	Subreddit       string          `json:"subreddit"`
+	Target          string          `json:"target"` // ReportPost or ReportComment
+	ID              int             `json:"id"`
+	Count           int             `json:"reports"`
+	Reasons         map[string]int  `json:"reasons"`             // reports per reason
+	Reporters       map[int]bool    `json:"reporters,omitempty"` // each user reports an item once
+	Ignored         bool            `json:"ignored,omitempty"`
+	FirstReportedAt time.Time       `json:"first_reported_at"`
+	LastReportedAt  time.Time       `json:"last_reported_at"`
+	Post            *PostSummary    `json:"post,omitempty"`
+	Comment         *CommentSummary `json:"comment,omitempty"`
+}
+
+func (r *Report) clone() *Report {
+	copied := *r
+	copied.Reasons = make(map[string]int, len(r.Reasons))
+	for reason, count := range r.Reasons {
+		copied.Reasons[reason] = count
+	}
+	copied.Reporters = make(map[int]bool, len(r.Reporters))
+	for id := range r.Reporters {
+		copied.Reporters[id] = true
+	}
+	return &copied
+}
+
+func reportKey(target string, id int) string {
+	return fmt.Sprintf("%s:%d", target, id)
+}
+
+// reportReasons returns the reasons users may pick when reporting in the subreddit
+func (s *Subreddit) reportReasons() []string {
+	if len(s.ReportReasons) > 0 {
+		return s.ReportReasons
+	}
+	return DefaultReportReasons
+}
+
+// validateReportReasons trims the reasons in place and drops duplicates, or says what is
+// wrong with them. No reasons restores DefaultReportReasons.
+func validateReportReasons(msg *SetReportReasons) error {
+	var reasons []string
+	seen := map[string]bool{}
+	for _, reason := range msg.Reasons {
+		reason = strings.TrimSpace(reason)
+		switch {
+		case reason == "":
+			return fmt.Errorf("%w: reasons can't be empty", ErrInvalidReport)
+		case utf8.RuneCountInString(reason) > MaxReportReasonLength:
+			return fmt.Errorf("%w: reason is longer than %d characters", ErrInvalidReport, MaxReportReasonLength)
+		}
+		if key := strings.ToLower(reason); !seen[key] {
+			seen[key] = true
+			reasons = append(reasons, reason)
+		}
+	}
+	if len(reasons) > MaxReportReasons {
+		return fmt.Errorf("%w: at most %d reasons", ErrInvalidReport, MaxReportReasons)
+	}
+	msg.Reasons = reasons
+	return nil
+}
+
+// resolveReported asks PostActor to fill in the Post or Comment of each report; answered with []*Report
+type resolveReported struct {
+	reports []*Report
+}
+
+// ReportActor keeps the reports of every subreddit until moderators act on them
+type ReportActor struct {
+	reports map[string]*Report // by reportKey
+	store   Store
+	logger  *slog.Logger
+	mu      sync.Mutex
+}
+
+func (r *ReportActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		r.mu.Lock()
+		r.reports = r.store.Reports()
+		r.logger.Debug("state restored", "reports", len(r.reports))
+		r.mu.Unlock()
+
+	case *ReportContent:
+		r.mu.Lock()
+		key := reportKey(msg.Target, msg.ID)
+		report, exists := r.reports[key]
+		if !exists {
+			report = &Report{Subreddit: msg.Subreddit, Target: msg.Target, ID: msg.ID, Reasons: map[string]int{}, Reporters: map[int]bool{}, FirstReportedAt: time.Now()}
+			r.reports[key] = report
+		}
+		switch {
+		case report.Ignored:
+			r.logger.Debug("report ignored by moderators", "request_id", msg.RequestID, "target", msg.Target, "id", msg.ID)
+		case report.Reporters[msg.UserID]:
+			r.logger.Debug("item already reported by user", "request_id", msg.RequestID, "target", msg.Target, "id", msg.ID, "user_id", msg.UserID)
+		default:
+			report.Count++
+			report.Reasons[msg.Reason]++
+			report.Reporters[msg.UserID] = true
+			report.LastReportedAt = time.Now()
+			r.store.SaveReport(key, report)
+			r.logger.Info("content reported", "request_id", msg.RequestID, "subreddit", report.Subreddit, "target", msg.Target, "id", msg.ID, "user_id", msg.UserID, "reports", report.Count)
+		}
+		r.mu.Unlock()
+
+	case *GetModQueue:
+		r.mu.Lock()
+		queue := []*Report{}
+		for _, report := range r.reports {
+			if report.Subreddit == msg.Subreddit && !report.Ignored && report.Count > 0 {
+				listed := report.clone()
+				listed.Reporters = nil
+				queue = append(queue, listed)
+			}
+		}
+		r.mu.Unlock()
+		// Most reported first, then the longest waiting
+		sort.Slice(queue, func(i, j int) bool {
+			if queue[i].Count != queue[j].Count {
+				return queue[i].Count > queue[j].Count
+			}
+			return queue[i].FirstReportedAt.Before(queue[j].FirstReportedAt)
+		})
+		ctx.Respond(paginate(queue, msg.Page, msg.PageSize, DefaultFeedPageSize, MaxFeedPageSize))
+
+	case *ResolveReports:
+		r.mu.Lock()
+		key := reportKey(msg.Target, msg.ID)
+		report, exists := r.reports[key]
+		if !exists || report.Subreddit != msg.Subreddit || report.Ignored || report.Count == 0 {
+			r.mu.Unlock()
+			ctx.Respond(ErrNoSuchReport)
+			return
+		}
+		if msg.Action == ReportIgnore {
+			report.Ignored = true
+			report.Count = 0
+			report.Reasons = map[string]int{}
+			r.store.SaveReport(key, report)
+		} else {
+			delete(r.reports, key)
+			r.store.DeleteReport(key)
+		}
+		r.logger.Info("reports resolved", "request_id", msg.RequestID, "subreddit", msg.Subreddit, "target", msg.Target, "id", msg.ID, "moderator_id", msg.UserID, "action", msg.Action)
+		r.mu.Unlock()
+		ctx.Respond(report.clone())
+	}
+}
+
+// SetReportReasons replaces the reasons users may report content in a subreddit for.
+func (as *ActorSystem) SetReportReasons(ctx context.Context, msg SetReportReasons) error {
+	if err := validateReportReasons(&msg); err != nil {
+		return err
+	}
+	if _, err := as.moderatedSubreddit(ctx, msg.Subreddit, msg.UserID, msg.RequestID); err != nil {
+		return err
+	}
+	as.send(ctx, as.SubredditActor, &msg)
+	return nil
+}
+
+// ReportReasons returns the reasons users may report content in a subreddit for.
+func (as *ActorSystem) ReportReasons(ctx context.Context, subreddit, requestID string) ([]string, error) {
+	found, err := as.subreddit(ctx, subreddit, requestID)
+	if err != nil {
+		return nil, err
+	}
+	return found.reportReasons(), nil
+}
+
+// ReportContent files a user's report of a live post or comment, for one of the reasons its
+// subreddit allows.
+func (as *ActorSystem) ReportContent(ctx context.Context, msg ReportContent) error {
+	switch msg.Target {
+	case ReportPost:
+		post, err := as.livePost(ctx, msg.ID, msg.RequestID)
+		if err != nil {
+			return err
+		}
+		msg.Subreddit = post.Subreddit
+	case ReportComment:
+		result, err := as.requestFuture(ctx, as.PostActor, &GetComment{CommentID: msg.ID, RequestID: msg.RequestID}, as.RequestTimeout).Result()
+		if err != nil {
+			return err
+		}
+		comment, _ := result.(*Comment)
+		if comment == nil || comment.Deleted {
+			return ErrNoSuchComment
+		}
+		post, err := as.livePost(ctx, comment.PostID, msg.RequestID)
+		if err != nil {
+			return err
+		}
+		msg.Subreddit = post.Subreddit
+	default:
+		return fmt.Errorf("%w: target must be %q or %q", ErrInvalidReport, ReportPost, ReportComment)
+	}
+
+	subreddit, err := as.subreddit(ctx, msg.Subreddit, msg.RequestID)
+	if err != nil {
+		return err
+	}
+	reasons := subreddit.reportReasons()
+	i := slices.IndexFunc(reasons, func(reason string) bool { return strings.EqualFold(reason, strings.TrimSpace(msg.Reason)) })
+	if i < 0 {
+		return fmt.Errorf("%w: r/%s takes reports for %s", ErrInvalidReport, msg.Subreddit, strings.Join(reasons, ", "))
+	}
+	msg.Reason = reasons[i]
+	as.send(ctx, as.ReportActor, &msg)
+	return nil
+}
+
+// ModQueue returns a page of a subreddit's reported items, most reported first, to one of
+// its moderators.
+func (as *ActorSystem) ModQueue(ctx context.Context, msg GetModQueue) ([]*Report, error) {
+	if _, err := as.moderatedSubreddit(ctx, msg.Subreddit, msg.UserID, msg.RequestID); err != nil {
+		return nil, err
+	}
+	result, err := as.requestFuture(ctx, as.ReportActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching moderator queue", "request_id", msg.RequestID, "subreddit", msg.Subreddit, "error", err)
+		return nil, err
+	}
+	queue, ok := result.([]*Report)
+	if !ok {
+		return nil, fmt.Errorf("unexpected moderator queue %T", result)
+	}
+	if len(queue) == 0 {
+		return queue, nil
+	}
+	result, err = as.requestFuture(ctx, as.PostActor, &resolveReported{reports: queue}, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error resolving reported items", "request_id", msg.RequestID, "subreddit", msg.Subreddit, "error", err)
+		return nil, err
+	}
+	if queue, ok = result.([]*Report); !ok {
+		return nil, fmt.Errorf("unexpected moderator queue %T", result)
+	}
+	return queue, nil
+}
+
+// ResolveReports applies a moderator's decision to a reported item and resolves its reports.
+// Removing the item notifies its author; approving or ignoring an item AutoModerator filtered
+// lets it go live.
+func (as *ActorSystem) ResolveReports(ctx context.Context, msg ResolveReports) error {
+	switch msg.Action {
+	case ReportApprove, ReportRemove, ReportIgnore:
+	default:
+		return fmt.Errorf("%w: action must be %q, %q or %q", ErrInvalidReport, ReportApprove, ReportRemove, ReportIgnore)
+	}
+	if _, err := as.moderatedSubreddit(ctx, msg.Subreddit, msg.UserID, msg.RequestID); err != nil {
+		return err
+	}
+	result, err := as.requestFuture(ctx, as.ReportActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error resolving reports", "request_id", msg.RequestID, "subreddit", msg.Subreddit, "error", err)
+		return err
+	}
+	if err, ok := result.(error); ok {
+		return err
+	}
+	if msg.Action == ReportRemove {
+		as.send(ctx, as.PostActor, &RemoveContent{Target: msg.Target, ID: msg.ID, ModeratorID: msg.UserID, RequestID: msg.RequestID})
+	} else {
+		as.send(ctx, as.PostActor, &ApproveContent{Target: msg.Target, ID: msg.ID, ModeratorID: msg.UserID, Ignored: msg.Action == ReportIgnore, RequestID: msg.RequestID})
+	}
+	return nil
+}
+
+// subreddit looks a subreddit up by name
+func (as *ActorSystem) subreddit(ctx context.Context, name, requestID string) (*Subreddit, error) {
+	result, err := as.requestFuture(ctx, as.SubredditActor, &GetSubreddit{Name: name, RequestID: requestID}, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching subreddit", "request_id", requestID, "subreddit", name, "error", err)
+		return nil, err
+	}
+	found, ok := result.(*Subreddit)
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("unexpected subreddit %T", result)
+	case found == nil:
+		return nil, ErrNoSuchSubreddit
+	}
+	return found, nil
+}
+
+// moderatedSubreddit looks a subreddit up, failing with ErrNotModerator unless userID moderates it
+func (as *ActorSystem) moderatedSubreddit(ctx context.Context, name string, userID int, requestID string) (*Subreddit, error) {
+	found, err := as.subreddit(ctx, name, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if !found.Moderators[userID] {
+		return nil, ErrNotModerator
+	}
+	return found, nil
+}
+
+// livePost looks a post up, failing with ErrNoSuchPost when it doesn't exist or was deleted
+func (as *ActorSystem) livePost(ctx context.Context, id int, requestID string) (*Post, error) {
+	result, err := as.requestFuture(ctx, as.PostActor, &GetPost{PostID: id, RequestID: requestID}, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching post", "request_id", requestID, "post_id", id, "error", err)
+		return nil, err
+	}
+	post, ok := result.(*Post)
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("unexpected post %T", result)
+	case post == nil || post.Deleted:
+		return nil, ErrNoSuchPost
+	}
+	return post, nil
+}
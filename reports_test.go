@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+)
+
+func TestReportsAggregateIntoTheModQueue(t *testing.T) {
+	ctx := context.Background()
+	as := newTestActorSystem(t, NewMemoryStore())
+	as.CreateSubreddit(ctx, CreateSubreddit{Name: "golang", CreatorID: 1}) // user 1 moderates r/golang
+	as.CreatePost(ctx, PostMessage{UserID: 2, Subreddit: "golang", Title: "buy now"})
+	as.CreatePost(ctx, PostMessage{UserID: 2, Subreddit: "golang", Title: "hot take"})
+	as.AddComment(ctx, CommentMessage{UserID: 2, PostID: 2, Content: "rude"})
+	eventually(t, "the posts and comment", func() bool {
+		post, err := as.livePost(ctx, 2, "")
+		_, err2 := as.livePost(ctx, 1, "")
+		return err == nil && err2 == nil && len(post.Comments) == 1
+	})
+	report := func(userID int, target string, id int, reason string) {
+		t.Helper()
+		if err := as.ReportContent(ctx, ReportContent{UserID: userID, Target: target, ID: id, Reason: reason}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	queue := func() []*Report {
+		t.Helper()
+		queue, err := as.ModQueue(ctx, GetModQueue{Subreddit: "golang", UserID: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return queue
+	}
+
+	report(3, ReportPost, 1, "Spam")
+	report(4, ReportPost, 1, " spam ")
+	report(5, ReportPost, 1, "Harassment")
+	report(3, ReportPost, 1, "Harassment") // each user counts once
+	report(3, ReportComment, 1, "Harassment")
+	report(4, ReportComment, 1, "Harassment")
+	report(3, ReportPost, 2, "Misinformation")
+	eventually(t, "the reports", func() bool {
+		queue := queue()
+		return len(queue) == 3 && queue[2].Count == 1
+	})
+
+	items := queue()
+	if got := items[0]; got.Target != ReportPost || got.ID != 1 || got.Count != 3 || !maps.Equal(got.Reasons, map[string]int{"Spam": 2, "Harassment": 1}) {
+		t.Errorf("most reported = %+v, want post 1 with 2 spam and 1 harassment reports", got)
+	}
+	if got := items[1]; got.Target != ReportComment || got.ID != 1 || got.Count != 2 || got.Comment == nil || got.Comment.Content != "rude" {
+		t.Errorf("second = %+v, want comment 1 with 2 reports", got)
+	}
+	if got := items[2]; got.Target != ReportPost || got.ID != 2 || got.Post == nil || got.Post.Title != "hot take" {
+		t.Errorf("third = %+v, want post 2", got)
+	}
+	for _, item := range items {
+		if item.Reporters != nil {
+			t.Errorf("queue shows who reported %s %d", item.Target, item.ID)
+		}
+	}
+
+	for _, tt := range []struct {
+		name string
+		msg  ReportContent
+		want error
+	}{
+		{"reason the subreddit doesn't take", ReportContent{UserID: 6, Target: ReportPost, ID: 1, Reason: "Boring"}, ErrInvalidReport},
+		{"unknown target", ReportContent{UserID: 6, Target: "user", ID: 2, Reason: "Spam"}, ErrInvalidReport},
+		{"missing post", ReportContent{UserID: 6, Target: ReportPost, ID: 9, Reason: "Spam"}, ErrNoSuchPost},
+		{"missing comment", ReportContent{UserID: 6, Target: ReportComment, ID: 9, Reason: "Spam"}, ErrNoSuchComment},
+	} {
+		if err := as.ReportContent(ctx, tt.msg); !errors.Is(err, tt.want) {
+			t.Errorf("%s: %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if _, err := as.ModQueue(ctx, GetModQueue{Subreddit: "golang", UserID: 3}); !errors.Is(err, ErrNotModerator) {
+		t.Errorf("queue for a member: %v, want ErrNotModerator", err)
+	}
+
+	// Ignored items stay out of the queue even when reported again
+	if err := as.ResolveReports(ctx, ResolveReports{Subreddit: "golang", UserID: 1, Target: ReportPost, ID: 2, Action: ReportIgnore}); err != nil {
+		t.Fatal(err)
+	}
+	report(4, ReportPost, 2, "Spam")
+	if err := as.ResolveReports(ctx, ResolveReports{Subreddit: "golang", UserID: 1, Target: ReportComment, ID: 1, Action: ReportApprove}); err != nil {
+		t.Fatal(err)
+	}
+	if err := as.ResolveReports(ctx, ResolveReports{Subreddit: "golang", UserID: 1, Target: ReportComment, ID: 1, Action: ReportApprove}); !errors.Is(err, ErrNoSuchReport) {
+		t.Fatalf("resolving twice: %v, want ErrNoSuchReport", err)
+	}
+	eventually(t, "the queue to shrink", func() bool {
+		queue := queue()
+		return len(queue) == 1 && queue[0].Target == ReportPost && queue[0].ID == 1
+	})
+
+	// An approved item can be reported afresh
+	report(5, ReportComment, 1, "Spam")
+	eventually(t, "the new report", func() bool {
+		queue := queue()
+		return len(queue) == 2 && queue[1].Target == ReportComment && queue[1].Count == 1
+	})
+}
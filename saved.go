@@ -0,0 +1,243 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// Saved item types
+const (
+	SavedPost    = "post"
+	SavedComment = "comment"
+)
+
+var ErrNoSuchComment = errors.New("comment does not exist")
+
+// SavedItem is a post or comment a user saved. Post or Comment is filled in when listing.
+type SavedItem struct {
+	Type    string          `json:"type"` // SavedPost or SavedComment
+	ID      int             `json:"id"`
+	SavedAt time.Time       `json:"saved_at"`
+	Post    *PostSummary    `json:"post,omitempty"`
+	Comment *CommentSummary `json:"comment,omitempty"`
+}
+
+// SavedLists holds what one user saved, oldest first, and the IDs of the posts they hid
+type SavedLists struct {
+	Saved  []SavedItem  `json:"saved"`
+	Hidden map[int]bool `json:"hidden"`
+}
+
+func (l *SavedLists) clone() *SavedLists {
+	copied := &SavedLists{Saved: append([]SavedItem(nil), l.Saved...), Hidden: make(map[int]bool, len(l.Hidden))}
+	for id := range l.Hidden {
+		copied.Hidden[id] = true
+	}
+	return copied
+}
+
+// resolveSaved asks PostActor to fill in the Post or Comment of each item; answered with []SavedItem
+type resolveSaved struct {
+	items []SavedItem
+}
+
+// SavedActor keeps each user's saved posts and comments and hidden posts
+type SavedActor struct {
+	lists  map[int]*SavedLists
+	store  Store
+	logger *slog.Logger
+	mu     sync.Mutex
+}
+
+func (s *SavedActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		s.mu.Lock()
+		s.lists = s.store.SavedLists()
+		s.logger.Debug("state restored", "users", len(s.lists))
+		s.mu.Unlock()
+
+	case *SaveItem:
+		s.mu.Lock()
+		lists := s.userLists(msg.UserID)
+		if !slices.ContainsFunc(lists.Saved, func(item SavedItem) bool { return item.Type == msg.Type && item.ID == msg.ID }) {
+			lists.Saved = append(lists.Saved, SavedItem{Type: msg.Type, ID: msg.ID, SavedAt: time.Now()})
+			s.store.SaveSavedLists(msg.UserID, lists)
+			s.logger.Info("item saved", "request_id", msg.RequestID, "user_id", msg.UserID, "type", msg.Type, "id", msg.ID)
+		}
+		s.mu.Unlock()
+
+	case *UnsaveItem:
+		s.mu.Lock()
+		lists := s.userLists(msg.UserID)
+		kept := slices.DeleteFunc(lists.Saved, func(item SavedItem) bool { return item.Type == msg.Type && item.ID == msg.ID })
+		if len(kept) != len(lists.Saved) {
+			lists.Saved = kept
+			s.store.SaveSavedLists(msg.UserID, lists)
+			s.logger.Info("item unsaved", "request_id", msg.RequestID, "user_id", msg.UserID, "type", msg.Type, "id", msg.ID)
+		}
+		s.mu.Unlock()
+
+	case *HidePost:
+		s.mu.Lock()
+		lists := s.userLists(msg.UserID)
+		if !lists.Hidden[msg.PostID] {
+			lists.Hidden[msg.PostID] = true
+			s.store.SaveSavedLists(msg.UserID, lists)
+			s.logger.Info("post hidden", "request_id", msg.RequestID, "user_id", msg.UserID, "post_id", msg.PostID)
+		}
+		s.mu.Unlock()
+
+	case *UnhidePost:
+		s.mu.Lock()
+		lists := s.userLists(msg.UserID)
+		if lists.Hidden[msg.PostID] {
+			delete(lists.Hidden, msg.PostID)
+			s.store.SaveSavedLists(msg.UserID, lists)
+			s.logger.Info("post unhidden", "request_id", msg.RequestID, "user_id", msg.UserID, "post_id", msg.PostID)
+		}
+		s.mu.Unlock()
+
+	case *GetSaved:
+		s.mu.Lock()
+		var saved []SavedItem
+		if lists, exists := s.lists[msg.UserID]; exists {
+			for i := len(lists.Saved) - 1; i >= 0; i-- {
+				if msg.Type == "" || lists.Saved[i].Type == msg.Type {
+					saved = append(saved, lists.Saved[i])
+				}
+			}
+		}
+		s.mu.Unlock()
+		ctx.Respond(paginate(saved, msg.Page, msg.PageSize, DefaultFeedPageSize, MaxFeedPageSize))
+
+	case *GetHidden:
+		s.mu.Lock()
+		hidden := map[int]bool{}
+		if lists, exists := s.lists[msg.UserID]; exists {
+			for id := range lists.Hidden {
+				hidden[id] = true
+			}
+		}
+		s.mu.Unlock()
+		ctx.Respond(hidden)
+	}
+}
+
+// userLists returns the user's lists, creating them on first use; s.mu must be held.
+func (s *SavedActor) userLists(userID int) *SavedLists {
+	lists, exists := s.lists[userID]
+	if !exists {
+		lists = &SavedLists{Hidden: make(map[int]bool)}
+		s.lists[userID] = lists
+	} else if lists.Hidden == nil {
+		lists.Hidden = make(map[int]bool)
+	}
+	return lists
+}
+
+// withoutHidden drops the posts in hidden from a listing
+func withoutHidden(posts []PostSummary, hidden map[int]bool) []PostSummary {
+	if len(hidden) == 0 {
+		return posts
+	}
+	return slices.DeleteFunc(posts, func(post PostSummary) bool { return hidden[post.ID] })
+}
+
+// SaveItem saves a live post or comment for msg.UserID.
+func (as *ActorSystem) SaveItem(ctx context.Context, msg SaveItem) error {
+	if err := as.checkSaveable(ctx, msg.Type, msg.ID, msg.RequestID); err != nil {
+		return err
+	}
+	as.send(ctx, as.SavedActor, &msg)
+	return nil
+}
+
+func (as *ActorSystem) UnsaveItem(ctx context.Context, msg UnsaveItem) {
+	as.send(ctx, as.SavedActor, &msg)
+}
+
+// HidePost keeps a live post out of msg.UserID's feeds and listings.
+func (as *ActorSystem) HidePost(ctx context.Context, msg HidePost) error {
+	if err := as.checkSaveable(ctx, SavedPost, msg.PostID, msg.RequestID); err != nil {
+		return err
+	}
+	as.send(ctx, as.SavedActor, &msg)
+	return nil
+}
+
+func (as *ActorSystem) UnhidePost(ctx context.Context, msg UnhidePost) {
+	as.send(ctx, as.SavedActor, &msg)
+}
+
+// Saved returns a page of what msg.UserID saved, most recently saved first, with the
+// current state of each post and comment.
+func (as *ActorSystem) Saved(ctx context.Context, msg GetSaved) ([]SavedItem, error) {
+	result, err := as.requestFuture(ctx, as.SavedActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching saved items", "request_id", msg.RequestID, "user_id", msg.UserID, "error", err)
+		return nil, err
+	}
+	items, ok := result.([]SavedItem)
+	if !ok {
+		return nil, fmt.Errorf("unexpected saved items %T", result)
+	}
+	if len(items) == 0 {
+		return []SavedItem{}, nil
+	}
+	result, err = as.requestFuture(ctx, as.PostActor, &resolveSaved{items: items}, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error resolving saved items", "request_id", msg.RequestID, "user_id", msg.UserID, "error", err)
+		return nil, err
+	}
+	if items, ok = result.([]SavedItem); !ok {
+		return nil, fmt.Errorf("unexpected saved items %T", result)
+	}
+	return items, nil
+}
+
+// hiddenPosts returns the IDs of the posts userID hid
+func (as *ActorSystem) hiddenPosts(ctx context.Context, userID int, requestID string) (map[int]bool, error) {
+	result, err := as.requestFuture(ctx, as.SavedActor, &GetHidden{UserID: userID, RequestID: requestID}, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching hidden posts", "request_id", requestID, "user_id", userID, "error", err)
+		return nil, err
+	}
+	hidden, ok := result.(map[int]bool)
+	if !ok {
+		return nil, fmt.Errorf("unexpected hidden posts %T", result)
+	}
+	return hidden, nil
+}
+
+// checkSaveable reports whether the post or comment id exists and is live
+func (as *ActorSystem) checkSaveable(ctx context.Context, itemType string, id int, requestID string) error {
+	switch itemType {
+	case SavedPost:
+		result, err := as.requestFuture(ctx, as.PostActor, &GetPost{PostID: id, RequestID: requestID}, as.RequestTimeout).Result()
+		if err != nil {
+			return err
+		}
+		if post, _ := result.(*Post); post == nil || post.Deleted {
+			return ErrNoSuchPost
+		}
+	case SavedComment:
+		result, err := as.requestFuture(ctx, as.PostActor, &GetComment{CommentID: id, RequestID: requestID}, as.RequestTimeout).Result()
+		if err != nil {
+			return err
+		}
+		if comment, _ := result.(*Comment); comment == nil || comment.Deleted {
+			return ErrNoSuchComment
+		}
+	default:
+		return fmt.Errorf("unknown saved item type %q", itemType)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSaveAndHideAreIdempotent(t *testing.T) {
+	ctx := context.Background()
+	as := newTestActorSystem(t, NewMemoryStore())
+	as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "first"})
+	as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "second"})
+	as.AddComment(ctx, CommentMessage{UserID: 2, PostID: 1, Content: "nice"})
+	eventually(t, "the posts and comment", func() bool {
+		post, err := as.livePost(ctx, 1, "")
+		_, err2 := as.livePost(ctx, 2, "")
+		return err == nil && err2 == nil && len(post.Comments) == 1
+	})
+	saved := func() []SavedItem {
+		t.Helper()
+		items, err := as.Saved(ctx, GetSaved{UserID: 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return items
+	}
+	mustSave := func(msg SaveItem) {
+		t.Helper()
+		if err := as.SaveItem(ctx, msg); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mustSave(SaveItem{UserID: 3, Type: SavedPost, ID: 1})
+	eventually(t, "the saved post", func() bool { return len(saved()) == 1 })
+	first := saved()[0]
+	mustSave(SaveItem{UserID: 3, Type: SavedComment, ID: 1})
+	mustSave(SaveItem{UserID: 3, Type: SavedPost, ID: 1}) // again
+	eventually(t, "the saved comment", func() bool { return len(saved()) == 2 })
+	items := saved()
+	if items[0].Type != SavedComment || items[0].Comment == nil || items[0].Comment.Content != "nice" {
+		t.Fatalf("most recently saved = %+v, want the comment", items[0])
+	}
+	if items[1].Type != SavedPost || items[1].Post == nil || items[1].Post.Title != "first" || !items[1].SavedAt.Equal(first.SavedAt) {
+		t.Fatalf("saving post 1 again changed it to %+v, want it as first saved", items[1])
+	}
+
+	as.UnsaveItem(ctx, UnsaveItem{UserID: 3, Type: SavedPost, ID: 1})
+	as.UnsaveItem(ctx, UnsaveItem{UserID: 3, Type: SavedPost, ID: 1})
+	as.UnsaveItem(ctx, UnsaveItem{UserID: 3, Type: SavedPost, ID: 2}) // never saved
+	eventually(t, "the unsave", func() bool {
+		items := saved()
+		return len(items) == 1 && items[0].Type == SavedComment
+	})
+
+	if err := as.SaveItem(ctx, SaveItem{UserID: 3, Type: SavedPost, ID: 99}); !errors.Is(err, ErrNoSuchPost) {
+		t.Fatalf("saving a missing post: %v, want ErrNoSuchPost", err)
+	}
+
+	feed := func() []PostSummary {
+		t.Helper()
+		posts, err := as.Feed(ctx, GetFeed{UserID: 3, Subreddits: []string{"golang"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return posts
+	}
+	for i := 0; i < 2; i++ {
+		if err := as.HidePost(ctx, HidePost{UserID: 3, PostID: 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	eventually(t, "post 1 hidden", func() bool {
+		posts := feed()
+		return len(posts) == 1 && posts[0].ID == 2
+	})
+	as.UnhidePost(ctx, UnhidePost{UserID: 3, PostID: 1})
+	as.UnhidePost(ctx, UnhidePost{UserID: 3, PostID: 1})
+	eventually(t, "post 1 back", func() bool { return len(feed()) == 2 })
+}
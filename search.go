@@ -0,0 +1,237 @@
+package engine
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// BM25 parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+const (
+	DefaultSearchPageSize = 25
+	MaxSearchPageSize     = 100
+)
+
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true, "by": true,
+	"for": true, "from": true, "in": true, "is": true, "it": true, "of": true, "on": true, "or": true,
+	"that": true, "the": true, "this": true, "to": true, "was": true, "with": true,
+}
+
+// SearchQuery is a parsed search string. Free text goes to Terms; subreddit:, author: and
+// type:post|comment become filters.
+type SearchQuery struct {
+	Terms     []string
+	Subreddit string
+	Author    string
+	Type      string
+}
+
+// ParseSearchQuery splits q into search terms and filters.
+func ParseSearchQuery(q string) SearchQuery {
+	var query SearchQuery
+	var text []string
+	for _, field := range strings.Fields(q) {
+		key, value, ok := strings.Cut(field, ":")
+		switch {
+		case ok && strings.EqualFold(key, "subreddit"):
+			query.Subreddit = strings.TrimPrefix(value, "r/")
+		case ok && strings.EqualFold(key, "author"):
+			query.Author = strings.TrimPrefix(value, "u/")
+		case ok && strings.EqualFold(key, "type"):
+			query.Type = strings.ToLower(value)
+		default:
+			text = append(text, field)
+		}
+	}
+	query.Terms = tokenize(strings.Join(text, " "))
+	return query
+}
+
+// SearchHit is one ranked post or comment
+type SearchHit struct {
+	Type        string  `json:"type"` // "post" or "comment"
+	PostID      int     `json:"post_id"`
+	CommentID   int     `json:"comment_id,omitempty"`
+	Subreddit   string  `json:"subreddit"`
+	UserID      int     `json:"user_id"`
+	Title       string  `json:"title,omitempty"`
+	Content     string  `json:"content"`
+	ContentHTML string  `json:"content_html"`
+	Score       float64 `json:"score"`
+}
+
+type SearchResults struct {
+	Total    int         `json:"total"`
+	Page     int         `json:"page"`
+	PageSize int         `json:"page_size"`
+	Hits     []SearchHit `json:"hits"`
+}
+
+// searchIndex is an inverted index over post and comment content, owned by PostActor.
+type searchIndex struct {
+	docs        map[string]*indexedDoc
+	postings    map[string]map[string]int // term -> doc key -> term frequency
+	totalLength int
+}
+
+type indexedDoc struct {
+	hit    SearchHit
+	terms  map[string]int
+	length int
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{docs: make(map[string]*indexedDoc), postings: make(map[string]map[string]int)}
+}
+
+func postDocKey(postID int) string { return "post:" + strconv.Itoa(postID) }
+
+func commentDocKey(commentID int) string { return "comment:" + strconv.Itoa(commentID) }
+
+func (idx *searchIndex) indexPost(post *Post) {
+	idx.put(postDocKey(post.ID), SearchHit{Type: "post", PostID: post.ID, Subreddit: post.Subreddit, UserID: post.UserID, Title: post.Title, Content: post.Content, ContentHTML: post.ContentHTML})
+}
+
+func (idx *searchIndex) indexComment(post *Post, comment *Comment) {
+	idx.put(commentDocKey(comment.ID), SearchHit{Type: "comment", PostID: post.ID, CommentID: comment.ID, Subreddit: post.Subreddit, UserID: comment.UserID, Content: comment.Content, ContentHTML: comment.ContentHTML})
+}
+
+// put adds or replaces a document.
+func (idx *searchIndex) put(key string, hit SearchHit) {
+	idx.remove(key)
+	terms := map[string]int{}
+	tokens := tokenize(hit.Title + " " + hit.Content)
+	for _, term := range tokens {
+		terms[term]++
+	}
+	for term, freq := range terms {
+		if idx.postings[term] == nil {
+			idx.postings[term] = map[string]int{}
+		}
+		idx.postings[term][key] = freq
+	}
+	idx.docs[key] = &indexedDoc{hit: hit, terms: terms, length: len(tokens)}
+	idx.totalLength += len(tokens)
+}
+
+func (idx *searchIndex) remove(key string) {
+	doc, exists := idx.docs[key]
+	if !exists {
+		return
+	}
+	for term := range doc.terms {
+		delete(idx.postings[term], key)
+		if len(idx.postings[term]) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+	idx.totalLength -= doc.length
+	delete(idx.docs, key)
+}
+
+// search ranks the documents matching every term with BM25 and returns the requested page.
+// authorID 0 means no author filter.
+func (idx *searchIndex) search(query SearchQuery, authorID, page, pageSize int) SearchResults {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = DefaultSearchPageSize
+	}
+	pageSize = min(pageSize, MaxSearchPageSize)
+
+	matches := idx.candidates(query.Terms)
+	hits := []SearchHit{}
+	for key := range matches {
+		doc := idx.docs[key]
+		if query.Subreddit != "" && !strings.EqualFold(doc.hit.Subreddit, query.Subreddit) {
+			continue
+		}
+		if query.Type != "" && doc.hit.Type != query.Type {
+			continue
+		}
+		if authorID != 0 && doc.hit.UserID != authorID {
+			continue
+		}
+		hit := doc.hit
+		hit.Score = idx.score(doc, query.Terms)
+		hits = append(hits, hit)
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		// Newer content first among equal scores
+		if hits[i].PostID != hits[j].PostID {
+			return hits[i].PostID > hits[j].PostID
+		}
+		return hits[i].CommentID > hits[j].CommentID
+	})
+
+	results := SearchResults{Total: len(hits), Page: page, PageSize: pageSize, Hits: []SearchHit{}}
+	// Compared before multiplying, so a huge page cannot overflow into a negative start
+	if page-1 <= len(hits)/pageSize {
+		if start := (page - 1) * pageSize; start < len(hits) {
+			results.Hits = hits[start:min(start+pageSize, len(hits))]
+		}
+	}
+	return results
+}
+
+// candidates returns the documents containing every term, or all documents when there are no terms.
+func (idx *searchIndex) candidates(terms []string) map[string]bool {
+	matches := map[string]bool{}
+	if len(terms) == 0 {
+		for key := range idx.docs {
+			matches[key] = true
+		}
+		return matches
+	}
+	for key := range idx.postings[terms[0]] {
+		matches[key] = true
+	}
+	for _, term := range terms[1:] {
+		for key := range matches {
+			if _, ok := idx.postings[term][key]; !ok {
+				delete(matches, key)
+			}
+		}
+	}
+	return matches
+}
+
+func (idx *searchIndex) score(doc *indexedDoc, terms []string) float64 {
+	n := float64(len(idx.docs))
+	avgLength := float64(idx.totalLength) / math.Max(n, 1)
+	score := 0.0
+	for _, term := range terms {
+		freq := float64(doc.terms[term])
+		df := float64(len(idx.postings[term]))
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+		score += idf * freq * (bm25K1 + 1) / (freq + bm25K1*(1-bm25B+bm25B*float64(doc.length)/math.Max(avgLength, 1)))
+	}
+	return score
+}
+
+// tokenize lower-cases text and splits it into words, dropping stop words.
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := words[:0]
+	for _, word := range words {
+		if !stopWords[word] {
+			tokens = append(tokens, word)
+		}
+	}
+	return tokens
+}
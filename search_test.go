@@ -0,0 +1,21 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSearchHugePage(t *testing.T) {
+	idx := newSearchIndex()
+	for id := 1; id <= 3; id++ {
+		idx.indexPost(&Post{ID: id, Subreddit: "golang", Title: "gopher news", Content: "channels"})
+	}
+
+	results := idx.search(ParseSearchQuery("gopher"), 0, math.MaxInt64/10, MaxSearchPageSize)
+	if results.Total != 3 || len(results.Hits) != 0 {
+		t.Fatalf("got %d of %d hits on a page past the end, want 0 of 3", len(results.Hits), results.Total)
+	}
+	if results := idx.search(ParseSearchQuery("gopher"), 0, 2, 2); len(results.Hits) != 1 || results.Hits[0].PostID != 1 {
+		t.Fatalf("page 2 = %+v, want post 1", results.Hits)
+	}
+}
@@ -0,0 +1,140 @@
+package simulator
+
+import (
+	"context"
+	"fmt"
+	"reddit_clone2/engine"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ClusterWorkload sizes the traffic SimulateCluster sends
+type ClusterWorkload struct {
+	Subreddits        int
+	PostsPerSubreddit int
+	CommentsPerPost   int
+	VotesPerPost      int
+}
+
+func DefaultClusterWorkload() ClusterWorkload {
+	return ClusterWorkload{Subreddits: 10, PostsPerSubreddit: 20, CommentsPerPost: 5, VotesPerPost: 10}
+}
+
+// ClusterReport sums up a SimulateCluster run
+type ClusterReport struct {
+	Requests int64
+	Failures int64
+	PerNode  []int64 // requests that entered the cluster at each node
+	Elapsed  time.Duration
+	Errors   []string // the first few failures
+}
+
+func (r ClusterReport) String() string {
+	rate := float64(r.Requests) / r.Elapsed.Seconds()
+	return fmt.Sprintf("%d requests (%d failed) in %v, %.0f req/s, per node %v", r.Requests, r.Failures, r.Elapsed.Round(time.Millisecond), rate, r.PerNode)
+}
+
+// SimulateCluster creates subreddits, posts, comments and votes, handing each request to the
+// next node in turn so most of them enter the cluster away from the grain that serves them.
+// It then reads every post back through yet another node and counts any post whose comments
+// or votes went missing as a failure.
+func SimulateCluster(ctx context.Context, nodes []*engine.ClusterNode, workload ClusterWorkload) ClusterReport {
+	sim := &clusterSim{nodes: nodes, report: ClusterReport{PerNode: make([]int64, len(nodes))}}
+	start := time.Now()
+	prefix := fmt.Sprintf("sim%d", start.Unix())
+
+	for i := 0; i < workload.Subreddits; i++ {
+		name := fmt.Sprintf("%s_%d", prefix, i)
+		sim.do(func(node *engine.ClusterNode) error {
+			return node.CreateSubreddit(ctx, engine.CreateSubreddit{Name: name, CreatorID: i + 1})
+		})
+	}
+
+	type postRef struct {
+		subreddit string
+		id        int
+	}
+	var (
+		mu    sync.Mutex
+		posts []postRef
+		wg    sync.WaitGroup
+	)
+	for i := 0; i < workload.Subreddits; i++ {
+		name := fmt.Sprintf("%s_%d", prefix, i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p := 0; p < workload.PostsPerSubreddit; p++ {
+				var id int
+				ok := sim.do(func(node *engine.ClusterNode) (err error) {
+					id, err = node.CreatePost(ctx, engine.PostMessage{UserID: p%5 + 1, Subreddit: name, Content: fmt.Sprintf("post %d in %s", p, name)})
+					return err
+				})
+				if !ok {
+					continue
+				}
+				mu.Lock()
+				posts = append(posts, postRef{name, id})
+				mu.Unlock()
+
+				for c := 0; c < workload.CommentsPerPost; c++ {
+					sim.do(func(node *engine.ClusterNode) error {
+						_, err := node.AddComment(ctx, name, engine.CommentMessage{UserID: c + 1, PostID: id, Content: fmt.Sprintf("comment %d", c)})
+						return err
+					})
+				}
+				for v := 0; v < workload.VotesPerPost; v++ {
+					sim.do(func(node *engine.ClusterNode) error {
+						return node.VotePost(ctx, name, engine.Vote{UserID: v + 1, Target: "post", ID: id, Type: "upvote"})
+					})
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for _, ref := range posts {
+		sim.do(func(node *engine.ClusterNode) error {
+			post, found, err := node.GetPost(ctx, ref.subreddit, ref.id)
+			switch {
+			case err != nil:
+				return err
+			case !found:
+				return fmt.Errorf("post %s/%d: %w", ref.subreddit, ref.id, engine.ErrNoSuchPost)
+			case len(post.Comments) != workload.CommentsPerPost || post.Upvotes != workload.VotesPerPost:
+				return fmt.Errorf("post %s/%d has %d comments and %d upvotes, want %d and %d",
+					ref.subreddit, ref.id, len(post.Comments), post.Upvotes, workload.CommentsPerPost, workload.VotesPerPost)
+			}
+			return nil
+		})
+	}
+
+	sim.report.Elapsed = time.Since(start)
+	return sim.report
+}
+
+type clusterSim struct {
+	nodes  []*engine.ClusterNode
+	next   atomic.Int64
+	mu     sync.Mutex
+	report ClusterReport
+}
+
+// do runs request on the next node and records the outcome
+func (s *clusterSim) do(request func(node *engine.ClusterNode) error) bool {
+	i := int(s.next.Add(1)-1) % len(s.nodes)
+	err := request(s.nodes[i])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.report.Requests++
+	s.report.PerNode[i]++
+	if err != nil {
+		s.report.Failures++
+		if len(s.report.Errors) < 5 {
+			s.report.Errors = append(s.report.Errors, err.Error())
+		}
+	}
+	return err == nil
+}
@@ -493,16 +493,30 @@ package simulator
 
 import (
 	"bufio"
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/http"
 	"os"
+	"reddit_clone2/apiclient"
 	"reddit_clone2/engine"
 	"strconv"
+	"time"
 )
 
-func SimulateUsers(actorSystem *engine.ActorSystem) {
+// Engine is what the simulator drives: the in-process *engine.ActorSystem, or an
+// *engine.RemoteClient talking to an engine in another process.
+type Engine interface {
+	RegisterUser(ctx context.Context, msg engine.RegisterUser)
+	CreateSubreddit(ctx context.Context, msg engine.CreateSubreddit)
+	CreatePost(ctx context.Context, msg engine.PostMessage)
+	AddComment(ctx context.Context, msg engine.CommentMessage)
+	VotePost(ctx context.Context, msg engine.Vote)
+	GetAllUsers(ctx context.Context, msg engine.GetAllUsers) map[int]*engine.User
+}
+
+// SimulateUsers runs the interactive menu. apiBaseURL is where the REST API server listens,
+// e.g. "http://localhost:8080", and is used by the API endpoint tests.
+func SimulateUsers(actorSystem Engine, apiBaseURL string) {
 	reader := bufio.NewReader(os.Stdin)
 
 	for {
@@ -533,7 +547,7 @@ func SimulateUsers(actorSystem *engine.ActorSystem) {
 		case "6":
 			upvoteOrDownvoteCLI(actorSystem, reader)
 		case "7":
-			runAPITests(reader)
+			runAPITests(reader, apiBaseURL)
 		case "8":
 			fmt.Println("Exiting simulation...")
 			return
@@ -544,7 +558,7 @@ func SimulateUsers(actorSystem *engine.ActorSystem) {
 }
 
 // --- CLI Interaction Functions ---
-func registerUserCLI(actorSystem *engine.ActorSystem, reader *bufio.Reader) {
+func registerUserCLI(actorSystem Engine, reader *bufio.Reader) {
 	fmt.Print("Enter username: ")
 	username, _ := reader.ReadString('\n')
 	username = username[:len(username)-1]
@@ -553,18 +567,18 @@ func registerUserCLI(actorSystem *engine.ActorSystem, reader *bufio.Reader) {
 	password, _ := reader.ReadString('\n')
 	password = password[:len(password)-1]
 
-	actorSystem.RegisterUser(engine.RegisterUser{Username: username, Password: password})
+	actorSystem.RegisterUser(context.Background(), engine.RegisterUser{Username: username, Password: password})
 }
 
-func createSubredditCLI(actorSystem *engine.ActorSystem, reader *bufio.Reader) {
+func createSubredditCLI(actorSystem Engine, reader *bufio.Reader) {
 	fmt.Print("Enter subreddit name: ")
 	subredditName, _ := reader.ReadString('\n')
 	subredditName = subredditName[:len(subredditName)-1]
 
-	actorSystem.CreateSubreddit(engine.CreateSubreddit{Name: subredditName})
+	actorSystem.CreateSubreddit(context.Background(), engine.CreateSubreddit{Name: subredditName})
 }
 
-func createPostCLI(actorSystem *engine.ActorSystem, reader *bufio.Reader) {
+func createPostCLI(actorSystem Engine, reader *bufio.Reader) {
 	fmt.Print("Enter user ID: ")
 	userIDStr, _ := reader.ReadString('\n')
 	userID, _ := strconv.Atoi(userIDStr[:len(userIDStr)-1])
@@ -573,18 +587,36 @@ func createPostCLI(actorSystem *engine.ActorSystem, reader *bufio.Reader) {
 	subredditName, _ := reader.ReadString('\n')
 	subredditName = subredditName[:len(subredditName)-1]
 
+	fmt.Print("Enter post title: ")
+	title, _ := reader.ReadString('\n')
+	title = title[:len(title)-1]
+
+	fmt.Print("Enter link URL (empty for a text post): ")
+	link, _ := reader.ReadString('\n')
+	link = link[:len(link)-1]
+
 	fmt.Print("Enter post content: ")
 	content, _ := reader.ReadString('\n')
 	content = content[:len(content)-1]
 
-	actorSystem.CreatePost(engine.PostMessage{
+	post := engine.PostMessage{
 		UserID:    userID,
 		Subreddit: subredditName,
+		Title:     title,
+		Kind:      engine.PostText,
 		Content:   content,
-	})
+	}
+	if link != "" {
+		post.Kind, post.URL = engine.PostLink, link
+	}
+	if err := engine.ValidatePost(&post); err != nil {
+		fmt.Println(err)
+		return
+	}
+	actorSystem.CreatePost(context.Background(), post)
 }
 
-func addCommentCLI(actorSystem *engine.ActorSystem, reader *bufio.Reader) {
+func addCommentCLI(actorSystem Engine, reader *bufio.Reader) {
 	fmt.Print("Enter user ID: ")
 	userIDStr, _ := reader.ReadString('\n')
 	userID, _ := strconv.Atoi(userIDStr[:len(userIDStr)-1])
@@ -597,22 +629,22 @@ func addCommentCLI(actorSystem *engine.ActorSystem, reader *bufio.Reader) {
 	content, _ := reader.ReadString('\n')
 	content = content[:len(content)-1]
 
-	actorSystem.AddComment(engine.CommentMessage{
+	actorSystem.AddComment(context.Background(), engine.CommentMessage{
 		UserID:  userID,
 		PostID:  postID,
 		Content: content,
 	})
 }
 
-func displayKarmaCLI(actorSystem *engine.ActorSystem) {
-	users := actorSystem.GetAllUsers()
+func displayKarmaCLI(actorSystem Engine) {
+	users := actorSystem.GetAllUsers(context.Background(), engine.GetAllUsers{})
 	fmt.Println("Current User Karma:")
 	for id, user := range users {
 		fmt.Printf("User ID %d (%s): Karma: %d\n", id, user.Username, user.Karma)
 	}
 }
 
-func upvoteOrDownvoteCLI(actorSystem *engine.ActorSystem, reader *bufio.Reader) {
+func upvoteOrDownvoteCLI(actorSystem Engine, reader *bufio.Reader) {
 	fmt.Print("Enter user ID: ")
 	userIDStr, _ := reader.ReadString('\n')
 	userID, _ := strconv.Atoi(userIDStr[:len(userIDStr)-1])
@@ -629,7 +661,7 @@ func upvoteOrDownvoteCLI(actorSystem *engine.ActorSystem, reader *bufio.Reader)
 	voteType, _ := reader.ReadString('\n')
 	voteType = voteType[:len(voteType)-1]
 
-	actorSystem.VotePost(engine.Vote{
+	actorSystem.VotePost(context.Background(), engine.Vote{
 		UserID: userID,
 		Target: target,
 		ID:     targetID,
@@ -638,8 +670,14 @@ func upvoteOrDownvoteCLI(actorSystem *engine.ActorSystem, reader *bufio.Reader)
 }
 
 // --- API Test Functions ---
-func runAPITests(reader *bufio.Reader) {
+func runAPITests(reader *bufio.Reader, apiBaseURL string) {
 	fmt.Println("\n--- Running API Endpoint Tests ---")
+	api, err := apiclient.New(apiBaseURL, &http.Client{Timeout: 10 * time.Second})
+	if err != nil {
+		fmt.Printf("Invalid API base URL %s: %v\n", apiBaseURL, err)
+		return
+	}
+	ctx := context.Background()
 
 	// Register a User
 	fmt.Print("Enter username: ")
@@ -649,69 +687,84 @@ func runAPITests(reader *bufio.Reader) {
 	fmt.Print("Enter password: ")
 	password, _ := reader.ReadString('\n')
 	password = password[:len(password)-1]
-	registerPayload := map[string]string{"Username": username, "Password": password}
-	sendPostRequest("http://localhost:8080/api/users", registerPayload)
+	registered, err := api.RegisterUserWithResponse(ctx, apiclient.RegisterUserRequest{Username: username, Password: password})
+	printAPIResult("Register user", registered, err)
 
 	// Create a Subreddit
 	fmt.Print("Enter subreddit name: ")
 	subredditName, _ := reader.ReadString('\n')
 	subredditName = subredditName[:len(subredditName)-1]
-	subredditPayload := map[string]string{"Name": subredditName}
-	sendPostRequest("http://localhost:8080/api/subreddits", subredditPayload)
+	created, err := api.CreateSubredditWithResponse(ctx, apiclient.CreateSubredditRequest{Name: subredditName})
+	printAPIResult("Create subreddit", created, err)
 
 	// Create a Post
 	fmt.Print("Enter post content: ")
 	postContent, _ := reader.ReadString('\n')
 	postContent = postContent[:len(postContent)-1]
-	postPayload := map[string]interface{}{
-		"UserID":    1, // Can make dynamic
-		"Subreddit": subredditName,
-		"Content":   postContent,
+	posted, err := api.CreatePostWithResponse(ctx, apiclient.CreatePostRequest{
+		UserID:    1, // Can make dynamic
+		Subreddit: subredditName,
+		Content:   postContent,
+	})
+	printAPIResult("Create post", posted, err)
+
+	// Submit a Link, then list what was submitted from its domain
+	fmt.Print("Enter link URL: ")
+	link, _ := reader.ReadString('\n')
+	link = link[:len(link)-1]
+	title, kind := "Link from the API tests", apiclient.CreatePostRequestKindLink
+	linked, err := api.CreatePostWithResponse(ctx, apiclient.CreatePostRequest{
+		UserID:    1,
+		Subreddit: subredditName,
+		Title:     &title,
+		Kind:      &kind,
+		URL:       &link,
+	})
+	printAPIResult("Submit link", linked, err)
+	if _, domain, err := engine.NormalizeLink(link); err == nil {
+		listed, err := api.ListDomainPostsWithResponse(ctx, domain, &apiclient.ListDomainPostsParams{})
+		printAPIResult("List domain posts", listed, err)
+		if err == nil && listed.JSON200 != nil {
+			for _, post := range *listed.JSON200 {
+				fmt.Printf("  Post %d in %s: %s\n", post.Id, post.Subreddit, *post.Url)
+			}
+		}
 	}
-	sendPostRequest("http://localhost:8080/api/posts", postPayload)
 
 	// Fetch All Users’ Karma
-	sendGetRequest("http://localhost:8080/api/users/karma")
+	users, err := api.GetAllUsersWithResponse(ctx)
+	printAPIResult("Fetch karma", users, err)
+	if err == nil && users.JSON200 != nil {
+		for id, user := range *users.JSON200 {
+			fmt.Printf("  User ID %s (%s): Karma: %d\n", id, user.Username, user.Karma)
+		}
+	}
 
 	// Add a Comment
 	fmt.Print("Enter comment content: ")
 	commentContent, _ := reader.ReadString('\n')
 	commentContent = commentContent[:len(commentContent)-1]
-	commentPayload := map[string]interface{}{
-		"UserID":  1, // Example
-		"PostID":  1, // Example Post ID
-		"Content": commentContent,
-	}
-	sendPostRequest("http://localhost:8080/api/comments", commentPayload)
+	commented, err := api.AddCommentWithResponse(ctx, apiclient.AddCommentRequest{
+		UserID:  1, // Example
+		PostID:  1, // Example Post ID
+		Content: commentContent,
+	})
+	printAPIResult("Add comment", commented, err)
 
 	// Upvote a Post
-	votePayload := map[string]interface{}{
-		"UserID": 1, // Example
-		"Target": "post",
-		"ID":     1, // Example Post ID
-		"Type":   "upvote",
-	}
-	sendPostRequest("http://localhost:8080/api/votes", votePayload)
-}
-
-func sendPostRequest(url string, payload interface{}) {
-	body, _ := json.Marshal(payload)
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(body))
-
-	if err != nil {
-		fmt.Printf("Failed POST to %s: %v\n", url, err)
-		return
-	}
-	defer resp.Body.Close()
-	fmt.Printf("POST to %s - Status: %s\n", url, resp.Status)
+	voted, err := api.VoteWithResponse(ctx, apiclient.VoteRequest{
+		UserID: 1, // Example
+		Target: apiclient.VoteRequestTargetPost,
+		ID:     1, // Example Post ID
+		Type:   apiclient.Upvote,
+	})
+	printAPIResult("Upvote post", voted, err)
 }
 
-func sendGetRequest(url string) {
-	resp, err := http.Get(url)
+func printAPIResult(action string, resp interface{ Status() string }, err error) {
 	if err != nil {
-		fmt.Printf("Failed GET from %s: %v\n", url, err)
+		fmt.Printf("%s failed: %v\n", action, err)
 		return
 	}
-	defer resp.Body.Close()
-	fmt.Printf("GET from %s - Status: %s\n", url, resp.Status)
+	fmt.Printf("%s - Status: %s\n", action, resp.Status())
 }
@@ -0,0 +1,276 @@
+package engine
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// SpamOptions tunes the spam filter PostActor runs on new posts and comments.
+type SpamOptions struct {
+	Threshold   float64 // items scoring this or more are held for moderators; 0 disables the filter
+	MinExamples int     // the model scores nothing until it has seen this many spam and this many ham examples
+	MaxExamples int     // moderator decisions kept for export; older ones are dropped, but the model keeps what it learned
+}
+
+func DefaultSpamOptions() SpamOptions {
+	return SpamOptions{Threshold: 0.95, MinExamples: 10, MaxExamples: 10000}
+}
+
+// SpamExample is one labelled text the spam model learns from: a moderator's decision on a
+// post or comment, or a line of an offline dataset, where only Text and Spam are required.
+type SpamExample struct {
+	Text      string    `json:"text"`
+	Spam      bool      `json:"spam"`
+	Target    string    `json:"target,omitempty"` // ReportPost or ReportComment
+	ID        int       `json:"id,omitempty"`
+	Subreddit string    `json:"subreddit,omitempty"`
+	At        time.Time `json:"at"`
+}
+
+// SpamModel is a naive Bayes classifier over the words of posts and comments. Each class
+// counts the documents it was trained on and, per token, how many of them contained it.
+type SpamModel struct {
+	Spam       SpamClass `json:"spam"`
+	Ham        SpamClass `json:"ham"`
+	Vocabulary int       `json:"vocabulary"` // distinct tokens across both classes
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type SpamClass struct {
+	Documents int            `json:"documents"`
+	Tokens    int            `json:"tokens"` // sum of Counts
+	Counts    map[string]int `json:"counts"`
+}
+
+// SpamStats describes the spam filter without its token counts
+type SpamStats struct {
+	Enabled       bool      `json:"enabled"`
+	Ready         bool      `json:"ready"` // trained on enough examples of both classes to score
+	Threshold     float64   `json:"threshold"`
+	MinExamples   int       `json:"min_examples"`
+	SpamDocuments int       `json:"spam_documents"`
+	HamDocuments  int       `json:"ham_documents"`
+	Vocabulary    int       `json:"vocabulary"`
+	Examples      int       `json:"examples"` // moderator decisions kept for export
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func NewSpamModel() *SpamModel {
+	return &SpamModel{Spam: SpamClass{Counts: map[string]int{}}, Ham: SpamClass{Counts: map[string]int{}}}
+}
+
+// TrainSpamModel builds a model from scratch out of a dataset.
+func TrainSpamModel(examples []SpamExample) *SpamModel {
+	model := NewSpamModel()
+	for _, example := range examples {
+		model.Train(example.Text, example.Spam)
+	}
+	return model
+}
+
+func (m *SpamModel) clone() *SpamModel {
+	copied := *m
+	copied.Spam.Counts = make(map[string]int, len(m.Spam.Counts))
+	for token, count := range m.Spam.Counts {
+		copied.Spam.Counts[token] = count
+	}
+	copied.Ham.Counts = make(map[string]int, len(m.Ham.Counts))
+	for token, count := range m.Ham.Counts {
+		copied.Ham.Counts[token] = count
+	}
+	return &copied
+}
+
+func (m *SpamModel) class(spam bool) *SpamClass {
+	if spam {
+		return &m.Spam
+	}
+	return &m.Ham
+}
+
+// Train adds one labelled text to the model.
+func (m *SpamModel) Train(text string, spam bool) {
+	class := m.class(spam)
+	if class.Counts == nil {
+		class.Counts = map[string]int{}
+	}
+	for token := range spamTokens(text) {
+		if m.Spam.Counts[token] == 0 && m.Ham.Counts[token] == 0 {
+			m.Vocabulary++
+		}
+		class.Counts[token]++
+		class.Tokens++
+	}
+	class.Documents++
+	m.UpdatedAt = time.Now()
+}
+
+// Untrain takes back an earlier Train with the same text and label, for a moderator who
+// changed their mind.
+func (m *SpamModel) Untrain(text string, spam bool) {
+	class := m.class(spam)
+	if class.Documents == 0 {
+		return
+	}
+	for token := range spamTokens(text) {
+		if class.Counts[token] == 0 {
+			continue
+		}
+		class.Counts[token]--
+		class.Tokens--
+		if class.Counts[token] == 0 {
+			delete(class.Counts, token)
+			if m.Spam.Counts[token] == 0 && m.Ham.Counts[token] == 0 {
+				m.Vocabulary--
+			}
+		}
+	}
+	class.Documents--
+	m.UpdatedAt = time.Now()
+}
+
+// Ready reports whether the model has seen minExamples texts of each class; a model trained on
+// a handful of decisions would flag far too much.
+func (m *SpamModel) Ready(minExamples int) bool {
+	return m.Spam.Documents >= max(minExamples, 1) && m.Ham.Documents >= max(minExamples, 1)
+}
+
+// Score returns the probability that text is spam, from 0 to 1. The model must be Ready.
+func (m *SpamModel) Score(text string) float64 {
+	logOdds := math.Log(float64(m.Spam.Documents) / float64(m.Ham.Documents))
+	// Laplace smoothing, with one extra slot for tokens neither class has seen
+	vocabulary := float64(m.Vocabulary + 1)
+	for token := range spamTokens(text) {
+		logOdds += math.Log((float64(m.Spam.Counts[token])+1)/(float64(m.Spam.Tokens)+vocabulary)) -
+			math.Log((float64(m.Ham.Counts[token])+1)/(float64(m.Ham.Tokens)+vocabulary))
+	}
+	return 1 / (1 + math.Exp(-logOdds))
+}
+
+// spamTokens splits text into the distinct lowercase words the model counts. Links split into
+// their scheme, host labels and path words, so a spammer's domain becomes a token of its own.
+func spamTokens(text string) map[string]bool {
+	tokens := map[string]bool{}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '$'
+	})
+	for _, word := range words {
+		if n := utf8.RuneCountInString(word); n >= 2 && n <= 32 {
+			tokens[word] = true
+		}
+	}
+	return tokens
+}
+
+// postSpamText is the text of a post the spam model sees
+func postSpamText(post *Post) string {
+	return strings.Join(slices.DeleteFunc([]string{post.Title, post.Content, post.URL}, func(s string) bool { return s == "" }), "\n")
+}
+
+// scoreSpam scores a new post or comment, reporting whether it should be held for moderators;
+// p.mu must be held.
+func (p *PostActor) scoreSpam(text string) (float64, bool) {
+	if p.spamOptions.Threshold <= 0 || !p.spam.Ready(p.spamOptions.MinExamples) {
+		return 0, false
+	}
+	score := p.spam.Score(text)
+	return score, score >= p.spamOptions.Threshold
+}
+
+// holdSpam files a post or comment the spam filter held in the subreddit's moderator queue,
+// reported by AutoModerator; p.mu must be held.
+func (p *PostActor) holdSpam(ctx actor.Context, target string, id int, subreddit string, score float64, requestID string) {
+	p.logger.Info("spam filter held content", "request_id", requestID, "subreddit", subreddit, "target", target, "id", id, "score", score)
+	if p.reportActor == nil {
+		p.logger.Error("no ReportActor assigned, held item not queued", "request_id", requestID, "target", target, "id", id)
+		return
+	}
+	ctx.Send(p.reportActor, &ReportContent{UserID: AutoModeratorID, Target: target, ID: id, Reason: fmt.Sprintf("Spam filter: score %.2f", score), Subreddit: subreddit, RequestID: requestID})
+}
+
+// learnSpam trains the model on a moderator's decision and keeps it for export, replacing an
+// earlier decision on the same item; p.mu must be held.
+func (p *PostActor) learnSpam(example SpamExample, requestID string) {
+	same := func(kept SpamExample) bool { return kept.Target == example.Target && kept.ID == example.ID }
+	if i := slices.IndexFunc(p.spamExamples, same); i >= 0 {
+		p.spam.Untrain(p.spamExamples[i].Text, p.spamExamples[i].Spam)
+		p.spamExamples = slices.Delete(p.spamExamples, i, i+1)
+	}
+	p.spam.Train(example.Text, example.Spam)
+	p.spamExamples = append(p.spamExamples, example)
+	if over := len(p.spamExamples) - p.spamOptions.MaxExamples; over > 0 {
+		p.spamExamples = slices.Delete(p.spamExamples, 0, over)
+	}
+	p.store.SaveSpamModel(p.spam)
+	p.store.SaveSpamExamples(p.spamExamples)
+	p.logger.Info("spam model trained", "request_id", requestID, "target", example.Target, "id", example.ID, "spam", example.Spam, "spam_documents", p.spam.Spam.Documents, "ham_documents", p.spam.Ham.Documents)
+}
+
+// spamStats describes the filter; p.mu must be held.
+func (p *PostActor) spamStats() SpamStats {
+	return SpamStats{
+		Enabled:       p.spamOptions.Threshold > 0,
+		Ready:         p.spam.Ready(p.spamOptions.MinExamples),
+		Threshold:     p.spamOptions.Threshold,
+		MinExamples:   p.spamOptions.MinExamples,
+		SpamDocuments: p.spam.Spam.Documents,
+		HamDocuments:  p.spam.Ham.Documents,
+		Vocabulary:    p.spam.Vocabulary,
+		Examples:      len(p.spamExamples),
+		UpdatedAt:     p.spam.UpdatedAt,
+	}
+}
+
+// ReadSpamExamples reads a dataset written by WriteSpamExamples: one JSON SpamExample per line.
+func ReadSpamExamples(r io.Reader) ([]SpamExample, error) {
+	var examples []SpamExample
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var example SpamExample
+		if err := json.Unmarshal(scanner.Bytes(), &example); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		examples = append(examples, example)
+	}
+	return examples, scanner.Err()
+}
+
+// WriteSpamExamples writes a dataset as JSON Lines.
+func WriteSpamExamples(w io.Writer, examples []SpamExample) error {
+	encoder := json.NewEncoder(w)
+	for _, example := range examples {
+		if err := encoder.Encode(example); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SpamStats describes the spam filter and how much it has learned.
+func (as *ActorSystem) SpamStats(ctx context.Context, requestID string) (SpamStats, error) {
+	result, err := as.requestFuture(ctx, as.PostActor, &GetSpamStats{RequestID: requestID}, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching spam filter stats", "request_id", requestID, "error", err)
+		return SpamStats{}, err
+	}
+	stats, ok := result.(SpamStats)
+	if !ok {
+		return SpamStats{}, fmt.Errorf("unexpected spam stats %T", result)
+	}
+	return stats, nil
+}
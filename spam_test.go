@@ -0,0 +1,114 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+var (
+	testSpam = []string{
+		"Buy cheap pills now at pills.example",
+		"Cheap watches, buy now, free shipping",
+		"Free money!!! Click now to claim your prize",
+	}
+	testHam = []string{
+		"Go 1.24 released with generic type aliases",
+		"How do you structure tests in a Go module?",
+		"Benchmarking maps against slices for small sets",
+	}
+)
+
+func TestSpamModelScoresAfterTraining(t *testing.T) {
+	var examples []SpamExample
+	for _, text := range testSpam {
+		examples = append(examples, SpamExample{Text: text, Spam: true})
+	}
+	for _, text := range testHam {
+		examples = append(examples, SpamExample{Text: text})
+	}
+	model := TrainSpamModel(examples)
+
+	if !model.Ready(3) || model.Ready(4) {
+		t.Errorf("Ready after 3 examples of each class: Ready(3) = %v, Ready(4) = %v", model.Ready(3), model.Ready(4))
+	}
+	if NewSpamModel().Ready(0) {
+		t.Error("an untrained model is ready")
+	}
+	if score := model.Score("buy cheap pills now"); score < 0.95 {
+		t.Errorf("spam scored %.3f, want at least 0.95", score)
+	}
+	if score := model.Score("Go tests for generic type aliases"); score > 0.05 {
+		t.Errorf("ham scored %.3f, want at most 0.05", score)
+	}
+	if score := model.Score("zebra"); score < 0.4 || score > 0.6 {
+		t.Errorf("text of unseen words scored %.3f, want about 0.5", score)
+	}
+
+	vocabulary := model.Vocabulary
+	model.Train("Synergy webinar tomorrow", true)
+	model.Untrain("Synergy webinar tomorrow", true)
+	if model.Spam.Documents != 3 || model.Vocabulary != vocabulary || model.Spam.Counts["synergy"] != 0 {
+		t.Errorf("Untrain left %d spam documents and %d tokens, want 3 and %d", model.Spam.Documents, model.Vocabulary, vocabulary)
+	}
+	model.Untrain("anything", false)
+	if model.Ham.Documents != 2 || model.Ready(3) {
+		t.Errorf("Untrain of a ham text left %d ham documents, want 2", model.Ham.Documents)
+	}
+}
+
+func TestSpamFilterLearnsFromModerators(t *testing.T) {
+	ctx := context.Background()
+	as := newTestActorSystem(t, NewMemoryStore(), func(as *ActorSystem) {
+		as.Spam = SpamOptions{Threshold: 0.9, MinExamples: len(testSpam), MaxExamples: 100}
+	})
+	as.CreateSubreddit(ctx, CreateSubreddit{Name: "golang", CreatorID: 1}) // user 1 moderates r/golang
+	for _, text := range append(testSpam, testHam...) {
+		as.CreatePost(ctx, PostMessage{UserID: 2, Subreddit: "golang", Title: text})
+	}
+	eventually(t, "the posts", func() bool {
+		_, err := as.livePost(ctx, len(testSpam)+len(testHam), "")
+		return err == nil
+	})
+
+	// Moderators remove the spam and approve the rest
+	for id := 1; id <= len(testSpam)+len(testHam); id++ {
+		action := ReportApprove
+		if id <= len(testSpam) {
+			action = ReportRemove
+		}
+		if err := as.ReportContent(ctx, ReportContent{UserID: 3, Target: ReportPost, ID: id, Reason: "Spam"}); err != nil {
+			t.Fatal(err)
+		}
+		eventually(t, "the report", func() bool {
+			return as.ResolveReports(ctx, ResolveReports{Subreddit: "golang", UserID: 1, Target: ReportPost, ID: id, Action: action}) == nil
+		})
+	}
+	eventually(t, "the model to learn", func() bool {
+		stats, err := as.SpamStats(ctx, "")
+		return err == nil && stats.Ready && stats.Examples == len(testSpam)+len(testHam)
+	})
+
+	as.CreatePost(ctx, PostMessage{UserID: 2, Subreddit: "golang", Title: "Buy cheap pills now, free shipping"})
+	as.CreatePost(ctx, PostMessage{UserID: 2, Subreddit: "golang", Title: "Structure tests in a Go module"})
+	eventually(t, "the new posts", func() bool {
+		_, err := as.livePost(ctx, 8, "")
+		return err == nil
+	})
+	if spam, err := as.livePost(ctx, 7, ""); err != nil || !spam.Filtered {
+		t.Errorf("spam after training is not held for moderators (%v)", err)
+	}
+	if ham, err := as.livePost(ctx, 8, ""); err != nil || ham.Filtered {
+		t.Errorf("ham after training is held for moderators (%v)", err)
+	}
+	eventually(t, "the held post in the mod queue", func() bool {
+		queue, err := as.ModQueue(ctx, GetModQueue{Subreddit: "golang", UserID: 1})
+		if err != nil || len(queue) != 1 || queue[0].ID != 7 {
+			return false
+		}
+		for reason := range queue[0].Reasons {
+			return strings.HasPrefix(reason, "Spam filter")
+		}
+		return false
+	})
+}
@@ -0,0 +1,456 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store keeps engine state outside the actors so a restarted actor can pick up where the
+// failed one left off. Actors write every change through and reload from it on Started.
+type Store interface {
+	Users() map[int]*User
+	Subreddits() map[string]*Subreddit
+	Posts() map[int]*Post
+	Subreddit(name string) *Subreddit // nil when there is none; for cluster grains, which own one each
+	Post(id int) *Post
+	Notifications() map[int][]*Notification
+	Webhooks() map[int]*Webhook
+	Media() map[string]*Media
+	SavedLists() map[int]*SavedLists
+	Reports() map[string]*Report
+	SpamModel() *SpamModel // nil until the spam filter first learns
+	SpamExamples() []SpamExample
+	VoteAudit() *VoteAudit // nil until vote analysis first flags a vote
+	PostSequence() int     // the highest post ID a cluster handed out
+	SaveUser(user *User)
+	SaveSubreddit(subreddit *Subreddit)
+	// SavePost replaces the whole post, comments included, so every vote or comment costs a copy
+	// of its thread. That keeps actors and the store from sharing memory and is cheap at the
+	// sizes the file backend is meant for; a database-backed Store would write the changed rows.
+	SavePost(post *Post)
+	SaveNotifications(userID int, notifications []*Notification)
+	SaveWebhook(webhook *Webhook)
+	DeleteWebhook(id int)
+	SaveMedia(media *Media)
+	DeleteMedia(id string)
+	SaveSavedLists(userID int, lists *SavedLists)
+	SaveReport(key string, report *Report)
+	DeleteReport(key string)
+	SaveSpamModel(model *SpamModel)
+	SaveSpamExamples(examples []SpamExample)
+	SaveVoteAudit(audit *VoteAudit)
+	SavePostSequence(id int)
+	Flush() error
+}
+
+// MemoryStore survives actor restarts but not the process
+type MemoryStore struct {
+	state   snapshot
+	changes int // writes since the last flush
+	mu      sync.Mutex
+}
+
+type snapshot struct {
+	Users         map[int]*User           `json:"users"`
+	Subreddits    map[string]*Subreddit   `json:"subreddits"`
+	Posts         map[int]*Post           `json:"posts"`
+	Notifications map[int][]*Notification `json:"notifications"`
+	Webhooks      map[int]*Webhook        `json:"webhooks"`
+	Media         map[string]*Media       `json:"media"`
+	Saved         map[int]*SavedLists     `json:"saved"`
+	Reports       map[string]*Report      `json:"reports"`
+	SpamModel     *SpamModel              `json:"spam_model,omitempty"`
+	SpamExamples  []SpamExample           `json:"spam_examples"`
+	VoteAudit     *VoteAudit              `json:"vote_audit,omitempty"`
+	PostSequence  int                     `json:"post_sequence,omitempty"`
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{state: snapshot{
+		Users:         make(map[int]*User),
+		Subreddits:    make(map[string]*Subreddit),
+		Posts:         make(map[int]*Post),
+		Notifications: make(map[int][]*Notification),
+		Webhooks:      make(map[int]*Webhook),
+		Media:         make(map[string]*Media),
+		Saved:         make(map[int]*SavedLists),
+		Reports:       make(map[string]*Report),
+	}}
+}
+
+// Users returns a copy of every stored user; the same goes for Subreddits and Posts.
+func (m *MemoryStore) Users() map[int]*User {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	users := make(map[int]*User, len(m.state.Users))
+	for id, user := range m.state.Users {
+		users[id] = user.clone()
+	}
+	return users
+}
+
+func (m *MemoryStore) Subreddits() map[string]*Subreddit {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	subreddits := make(map[string]*Subreddit, len(m.state.Subreddits))
+	for name, subreddit := range m.state.Subreddits {
+		subreddits[name] = subreddit.clone()
+	}
+	return subreddits
+}
+
+func (m *MemoryStore) Posts() map[int]*Post {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	posts := make(map[int]*Post, len(m.state.Posts))
+	for id, post := range m.state.Posts {
+		posts[id] = post.clone()
+	}
+	return posts
+}
+
+func (m *MemoryStore) Subreddit(name string) *Subreddit {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if subreddit := m.state.Subreddits[name]; subreddit != nil {
+		return subreddit.clone()
+	}
+	return nil
+}
+
+func (m *MemoryStore) Post(id int) *Post {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if post := m.state.Posts[id]; post != nil {
+		return post.clone()
+	}
+	return nil
+}
+
+func (m *MemoryStore) Notifications() map[int][]*Notification {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	notifications := make(map[int][]*Notification, len(m.state.Notifications))
+	for userID, list := range m.state.Notifications {
+		notifications[userID] = cloneNotifications(list)
+	}
+	return notifications
+}
+
+func (m *MemoryStore) Webhooks() map[int]*Webhook {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	webhooks := make(map[int]*Webhook, len(m.state.Webhooks))
+	for id, webhook := range m.state.Webhooks {
+		webhooks[id] = webhook.clone()
+	}
+	return webhooks
+}
+
+func (m *MemoryStore) Media() map[string]*Media {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	media := make(map[string]*Media, len(m.state.Media))
+	for id, record := range m.state.Media {
+		copied := *record
+		media[id] = &copied
+	}
+	return media
+}
+
+func (m *MemoryStore) SavedLists() map[int]*SavedLists {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	saved := make(map[int]*SavedLists, len(m.state.Saved))
+	for userID, lists := range m.state.Saved {
+		saved[userID] = lists.clone()
+	}
+	return saved
+}
+
+func (m *MemoryStore) Reports() map[string]*Report {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	reports := make(map[string]*Report, len(m.state.Reports))
+	for key, report := range m.state.Reports {
+		reports[key] = report.clone()
+	}
+	return reports
+}
+
+func (m *MemoryStore) SpamModel() *SpamModel {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state.SpamModel == nil {
+		return nil
+	}
+	return m.state.SpamModel.clone()
+}
+
+func (m *MemoryStore) SpamExamples() []SpamExample {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]SpamExample(nil), m.state.SpamExamples...)
+}
+
+func (m *MemoryStore) VoteAudit() *VoteAudit {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state.VoteAudit == nil {
+		return nil
+	}
+	return m.state.VoteAudit.clone()
+}
+
+func (m *MemoryStore) PostSequence() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state.PostSequence
+}
+
+func (m *MemoryStore) SaveUser(user *User) {
+	m.mu.Lock()
+	m.state.Users[user.ID] = user.clone()
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) SaveSubreddit(subreddit *Subreddit) {
+	m.mu.Lock()
+	m.state.Subreddits[subreddit.Name] = subreddit.clone()
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) SavePost(post *Post) {
+	m.mu.Lock()
+	m.state.Posts[post.ID] = post.clone()
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) SaveNotifications(userID int, notifications []*Notification) {
+	m.mu.Lock()
+	m.state.Notifications[userID] = cloneNotifications(notifications)
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) SaveWebhook(webhook *Webhook) {
+	m.mu.Lock()
+	m.state.Webhooks[webhook.ID] = webhook.clone()
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) DeleteWebhook(id int) {
+	m.mu.Lock()
+	delete(m.state.Webhooks, id)
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) SaveMedia(media *Media) {
+	m.mu.Lock()
+	copied := *media
+	m.state.Media[media.ID] = &copied
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) DeleteMedia(id string) {
+	m.mu.Lock()
+	delete(m.state.Media, id)
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) SaveSavedLists(userID int, lists *SavedLists) {
+	m.mu.Lock()
+	m.state.Saved[userID] = lists.clone()
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) SaveReport(key string, report *Report) {
+	m.mu.Lock()
+	m.state.Reports[key] = report.clone()
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) DeleteReport(key string) {
+	m.mu.Lock()
+	delete(m.state.Reports, key)
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) SaveSpamModel(model *SpamModel) {
+	m.mu.Lock()
+	m.state.SpamModel = model.clone()
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) SaveSpamExamples(examples []SpamExample) {
+	m.mu.Lock()
+	m.state.SpamExamples = append([]SpamExample(nil), examples...)
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) SaveVoteAudit(audit *VoteAudit) {
+	m.mu.Lock()
+	m.state.VoteAudit = audit.clone()
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) SavePostSequence(id int) {
+	m.mu.Lock()
+	m.state.PostSequence = id
+	m.changes++
+	m.mu.Unlock()
+}
+
+func (m *MemoryStore) Flush() error { return nil }
+
+// FileStore is a MemoryStore that is loaded from and flushed to a JSON file
+type FileStore struct {
+	*MemoryStore
+	path string
+}
+
+// NewFileStore opens the store at path, loading its contents when the file exists.
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{MemoryStore: NewMemoryStore(), path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &store.state); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// Pending returns how many writes the next Flush would save.
+func (f *FileStore) Pending() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.changes
+}
+
+// Flush writes the current state to a temporary file and renames it over the store file.
+// Writes made while the file is written count towards the next flush.
+func (f *FileStore) Flush() (err error) {
+	f.mu.Lock()
+	data, err := json.Marshal(f.state)
+	flushed := f.changes
+	f.changes = 0
+	f.mu.Unlock()
+	defer func() {
+		if err != nil {
+			f.mu.Lock()
+			f.changes += flushed
+			f.mu.Unlock()
+		}
+	}()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
+
+func (u *User) clone() *User {
+	copied := *u
+	return &copied
+}
+
+func (s *Subreddit) clone() *Subreddit {
+	copied := *s
+	copied.Members = make(map[int]bool, len(s.Members))
+	for id, member := range s.Members {
+		copied.Members[id] = member
+	}
+	copied.Moderators = make(map[int]bool, len(s.Moderators))
+	for id, moderator := range s.Moderators {
+		copied.Moderators[id] = moderator
+	}
+	copied.Banned = make(map[int]bool, len(s.Banned))
+	for id, banned := range s.Banned {
+		copied.Banned[id] = banned
+	}
+	copied.Posts = append([]int(nil), s.Posts...)
+	copied.Flairs = append([]FlairTemplate(nil), s.Flairs...)
+	copied.ReportReasons = append([]string(nil), s.ReportReasons...)
+	copied.AutoModRules = append([]AutoModRule(nil), s.AutoModRules...)
+	copied.UserFlairs = make(map[int]int, len(s.UserFlairs))
+	for id, flair := range s.UserFlairs {
+		copied.UserFlairs[id] = flair
+	}
+	return &copied
+}
+
+func (p *Post) clone() *Post {
+	copied := *p
+	copied.Comments = cloneComments(p.Comments)
+	copied.Media = append([]Media(nil), p.Media...)
+	if p.Flair != nil {
+		flair := *p.Flair
+		copied.Flair = &flair
+	}
+	if p.CrosspostOf != nil {
+		parent := *p.CrosspostOf
+		copied.CrosspostOf = &parent
+	}
+	return &copied
+}
+
+func (w *Webhook) clone() *Webhook {
+	copied := *w
+	copied.Events = append([]string(nil), w.Events...)
+	return &copied
+}
+
+func cloneComments(comments []*Comment) []*Comment {
+	if comments == nil {
+		return nil
+	}
+	copied := make([]*Comment, len(comments))
+	for i, comment := range comments {
+		c := *comment
+		c.Replies = cloneComments(comment.Replies)
+		copied[i] = &c
+	}
+	return copied
+}
+
+func cloneNotifications(notifications []*Notification) []*Notification {
+	copied := make([]*Notification, len(notifications))
+	for i, notification := range notifications {
+		n := *notification
+		copied[i] = &n
+	}
+	return copied
+}
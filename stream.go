@@ -0,0 +1,139 @@
+package engine
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/eventstream"
+)
+
+// Live event types
+const (
+	EventPostCreated    = "post_created"
+	EventPostEdited     = "post_edited"
+	EventPostDeleted    = "post_deleted"
+	EventCommentCreated = "comment_created"
+	EventCommentEdited  = "comment_edited"
+	EventCommentDeleted = "comment_deleted"
+	EventPostScore      = "post_score"
+	EventKarma          = "karma"
+	EventNotification   = "notification"
+)
+
+// DefaultStreamBuffer is how many events a subscriber may fall behind by before it is dropped.
+const DefaultStreamBuffer = 64
+
+// LiveEvent is published on the actor system's EventStream whenever the engine changes state.
+// Inbox events (karma, notification) carry the RecipientID they belong to.
+type LiveEvent struct {
+	Type         string        `json:"type"`
+	Subreddit    string        `json:"subreddit,omitempty"`
+	PostID       int           `json:"post_id,omitempty"`
+	CommentID    int           `json:"comment_id,omitempty"`
+	UserID       int           `json:"user_id,omitempty"`
+	RecipientID  int           `json:"recipient_id,omitempty"`
+	Content      string        `json:"content,omitempty"`
+	ContentHTML  string        `json:"content_html,omitempty"`
+	Score        int           `json:"score,omitempty"`
+	Karma        int           `json:"karma,omitempty"`
+	Notification *Notification `json:"notification,omitempty"`
+	Removed      bool          `json:"removed,omitempty"` // deleted by a moderator or AutoModerator, not the author
+	At           time.Time     `json:"at"`
+}
+
+// StreamFilter selects the events a subscriber receives; every field that is set must match.
+// Filtering by UserID follows that user's inbox.
+type StreamFilter struct {
+	Subreddit string
+	PostID    int
+	UserID    int
+}
+
+func (f StreamFilter) matches(event *LiveEvent) bool {
+	if f.Subreddit != "" && !strings.EqualFold(event.Subreddit, f.Subreddit) {
+		return false
+	}
+	if f.PostID != 0 && event.PostID != f.PostID {
+		return false
+	}
+	if f.UserID != 0 && event.RecipientID != f.UserID {
+		return false
+	}
+	return true
+}
+
+// Subscription delivers matching events on Events until it is closed. The event stream calls
+// subscribers from the publishing actor, so a subscriber whose buffer fills up is closed
+// rather than allowed to stall the engine; Overflowed then reports true.
+type Subscription struct {
+	Events <-chan LiveEvent
+
+	events     chan LiveEvent
+	stream     *eventstream.EventStream
+	sub        *eventstream.Subscription
+	closed     bool
+	overflowed bool
+	mu         sync.Mutex
+}
+
+// Subscribe starts delivering the live events matching filter, buffering up to buffer of them.
+func (as *ActorSystem) Subscribe(filter StreamFilter, buffer int) *Subscription {
+	if buffer < 1 {
+		buffer = DefaultStreamBuffer
+	}
+	events := make(chan LiveEvent, buffer)
+	s := &Subscription{Events: events, events: events, stream: as.RootContext.ActorSystem().EventStream}
+	// Filtered in the handler: SubscribeWithPredicate sets its predicate only after the
+	// subscription is live, so an event published meanwhile would skip it
+	s.sub = s.stream.Subscribe(func(evt interface{}) {
+		if event, ok := evt.(*LiveEvent); ok && filter.matches(event) {
+			s.deliver(*event)
+		}
+	})
+	return s
+}
+
+func (s *Subscription) deliver(event LiveEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	select {
+	case s.events <- event:
+	default:
+		s.overflowed = true
+		s.closeLocked()
+	}
+}
+
+// Close stops delivery and closes Events.
+func (s *Subscription) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeLocked()
+}
+
+func (s *Subscription) closeLocked() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.stream.Unsubscribe(s.sub)
+	close(s.events)
+}
+
+// Overflowed reports whether the subscription was closed because the consumer fell behind.
+func (s *Subscription) Overflowed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.overflowed
+}
+
+// publish stamps event and puts it on the actor system's event stream.
+func publish(ctx actor.Context, event LiveEvent) {
+	event.At = time.Now()
+	ctx.ActorSystem().EventStream.Publish(&event)
+}
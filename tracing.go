@@ -0,0 +1,153 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Trace exporters accepted by SetupTracing
+const (
+	TraceExporterNone   = "none"
+	TraceExporterStdout = "stdout"
+	TraceExporterOTLP   = "otlp"
+)
+
+const tracerName = "reddit_clone2/engine"
+
+// SetupTracing installs the global tracer provider and W3C trace-context propagator.
+// endpoint and headers are only used by the OTLP exporter (host:port of an OTLP/HTTP collector).
+// The returned function flushes pending spans and must be called before exiting.
+func SetupTracing(ctx context.Context, exporter, endpoint string, headers map[string]string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	var spanExporter sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case "", TraceExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case TraceExporterStdout:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case TraceExporterOTLP:
+		spanExporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(endpoint), otlptracehttp.WithHeaders(headers), otlptracehttp.WithInsecure())
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName("reddit_clone2"))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// envelopeCarrier lets the propagator read and write trace context on actor message headers.
+type envelopeCarrier struct {
+	envelope *actor.MessageEnvelope
+}
+
+func (c envelopeCarrier) Get(key string) string { return c.envelope.GetHeader(key) }
+
+func (c envelopeCarrier) Set(key, value string) { c.envelope.SetHeader(key, value) }
+
+func (c envelopeCarrier) Keys() []string { return c.envelope.Header.Keys() }
+
+// headerCarrier is the read-only counterpart used for the header of the message being processed.
+type headerCarrier struct {
+	header actor.ReadonlyMessageHeader
+}
+
+func (c headerCarrier) Get(key string) string {
+	if c.header == nil {
+		return ""
+	}
+	return c.header.Get(key)
+}
+
+func (c headerCarrier) Set(string, string) {}
+
+func (c headerCarrier) Keys() []string {
+	if c.header == nil {
+		return nil
+	}
+	return c.header.Keys()
+}
+
+// tracedEnvelope wraps msg in an envelope carrying the trace context of ctx.
+func tracedEnvelope(ctx context.Context, msg interface{}, sender *actor.PID) *actor.MessageEnvelope {
+	envelope := &actor.MessageEnvelope{Message: msg, Sender: sender}
+	otel.GetTextMapPropagator().Inject(ctx, envelopeCarrier{envelope})
+	return envelope
+}
+
+// send delivers msg to pid with the caller's trace context attached.
+func (as *ActorSystem) send(ctx context.Context, pid *actor.PID, msg interface{}) {
+	as.RootContext.Send(pid, tracedEnvelope(ctx, msg, nil))
+}
+
+// requestFuture is RequestFuture with the caller's trace context attached.
+func (as *ActorSystem) requestFuture(ctx context.Context, pid *actor.PID, msg interface{}, timeout time.Duration) *actor.Future {
+	future := actor.NewFuture(as.RootContext.ActorSystem(), timeout)
+	as.RootContext.Send(pid, tracedEnvelope(ctx, msg, future.PID()))
+	return future
+}
+
+// tracingReceiver starts a span around every user message an actor receives. The span's
+// context replaces the incoming trace headers, so tracingSender parents outgoing sends on it.
+func tracingReceiver(actorName string) actor.ReceiverMiddleware {
+	tracer := otel.Tracer(tracerName)
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			if _, ok := envelope.Message.(actor.SystemMessage); ok {
+				next(c, envelope)
+				return
+			}
+
+			parent := otel.GetTextMapPropagator().Extract(context.Background(), envelopeCarrier{envelope})
+			ctx, span := tracer.Start(parent, actorName+".Receive",
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(
+					attribute.String("actor.pid", c.Self().String()),
+					attribute.String("actor.message", fmt.Sprintf("%T", envelope.Message)),
+				),
+			)
+			defer span.End()
+
+			traced := &actor.MessageEnvelope{Header: envelope.Header.ToMap(), Message: envelope.Message, Sender: envelope.Sender}
+			otel.GetTextMapPropagator().Inject(ctx, envelopeCarrier{traced})
+			next(c, traced)
+		}
+	}
+}
+
+// tracingSender copies the trace context of the message being processed onto outgoing messages.
+func tracingSender(next actor.SenderFunc) actor.SenderFunc {
+	return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+		ctx := otel.GetTextMapPropagator().Extract(context.Background(), headerCarrier{c.MessageHeader()})
+		otel.GetTextMapPropagator().Inject(ctx, envelopeCarrier{envelope})
+		next(c, target, envelope)
+	}
+}
+
+// tracingMiddleware returns the props options that trace an engine actor's messages.
+func tracingMiddleware(actorName string) []actor.PropsOption {
+	return []actor.PropsOption{
+		actor.WithReceiverMiddleware(tracingReceiver(actorName)),
+		actor.WithSenderMiddleware(tracingSender),
+	}
+}
@@ -0,0 +1,58 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+)
+
+// nameTrie indexes subreddit names by lower-cased prefix for autocomplete.
+type nameTrie struct {
+	root *trieNode
+}
+
+type trieNode struct {
+	children map[rune]*trieNode
+	name     string // original spelling when a name ends here
+}
+
+func newNameTrie() *nameTrie {
+	return &nameTrie{root: &trieNode{children: make(map[rune]*trieNode)}}
+}
+
+func (t *nameTrie) insert(name string) {
+	node := t.root
+	for _, r := range strings.ToLower(name) {
+		child, exists := node.children[r]
+		if !exists {
+			child = &trieNode{children: make(map[rune]*trieNode)}
+			node.children[r] = child
+		}
+		node = child
+	}
+	node.name = name
+}
+
+// withPrefix returns every name starting with prefix, case-insensitively, in alphabetical order.
+func (t *nameTrie) withPrefix(prefix string) []string {
+	node := t.root
+	for _, r := range strings.ToLower(prefix) {
+		node = node.children[r]
+		if node == nil {
+			return nil
+		}
+	}
+
+	var names []string
+	var walk func(n *trieNode)
+	walk = func(n *trieNode) {
+		if n.name != "" {
+			names = append(names, n.name)
+		}
+		for _, child := range n.children {
+			walk(child)
+		}
+	}
+	walk(node)
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,613 @@
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// Reasons a vote is discounted
+const (
+	VoteLockstep    = "lockstep"    // the voter and another account keep voting on the same posts the same way
+	VoteBrigade     = "brigade"     // a burst of votes from another subreddit's members
+	VoteFingerprint = "fingerprint" // another account voted on the post from the same client
+)
+
+// MaxVoteReportEntries bounds each list of the vote report; older entries are dropped
+const MaxVoteReportEntries = 1000
+
+var ErrNotAdmin = errors.New("user is not an admin")
+
+// VoteAuditOptions tunes the analysis VoteAuditActor runs on every post vote.
+type VoteAuditOptions struct {
+	Enabled bool
+	Window  time.Duration // how long votes are remembered for analysis
+
+	// Fingerprint discounts a vote on a post another account voted on from the same client.
+	// It is off by default, as everyone behind one NAT or office proxy shares a fingerprint.
+	Fingerprint bool
+
+	// Two accounts vote in lockstep once they voted the same way on the same posts, each time
+	// within LockstepGap of each other, at least LockstepMinVotes times, and those co-votes are
+	// at least LockstepShare of the votes of the less active account.
+	LockstepGap      time.Duration
+	LockstepMinVotes int
+	LockstepShare    float64
+
+	// A subreddit brigades another when at least BrigadeMinVotes votes from its members, who
+	// are not members of the other, land there within BrigadeWindow and make up at least
+	// BrigadeShare of that subreddit's votes in the window.
+	BrigadeWindow   time.Duration
+	BrigadeMinVotes int
+	BrigadeShare    float64
+}
+
+func DefaultVoteAuditOptions() VoteAuditOptions {
+	return VoteAuditOptions{
+		Enabled:          true,
+		Window:           24 * time.Hour,
+		LockstepGap:      5 * time.Minute,
+		LockstepMinVotes: 5,
+		LockstepShare:    0.8,
+		BrigadeWindow:    15 * time.Minute,
+		BrigadeMinVotes:  10,
+		BrigadeShare:     0.5,
+	}
+}
+
+// ClientFingerprint identifies the client a vote came from without keeping its address.
+func ClientFingerprint(ip, userAgent string) string {
+	sum := sha256.Sum256([]byte(ip + "\x00" + userAgent))
+	return hex.EncodeToString(sum[:8])
+}
+
+// VoteAudit is what vote analysis found, kept in the Store; VoteReport lists it for admins.
+type VoteAudit struct {
+	Discounted   int                           `json:"discounted"`
+	Accounts     map[int]*FlaggedAccount       `json:"accounts"`
+	Lockstep     map[string]*LockstepPair      `json:"lockstep"`     // by "a:b", a < b
+	Brigades     map[string]*Brigade           `json:"brigades"`     // by "from>into"
+	Fingerprints map[string]*SharedFingerprint `json:"fingerprints"` // by fingerprint
+}
+
+// FlaggedAccount counts an account's discounted votes, per reason
+type FlaggedAccount struct {
+	UserID         int            `json:"user_id"`
+	Discounted     int            `json:"discounted"`
+	Reasons        map[string]int `json:"reasons"`
+	FirstFlaggedAt time.Time      `json:"first_flagged_at"`
+	LastFlaggedAt  time.Time      `json:"last_flagged_at"`
+}
+
+// LockstepPair is two accounts voting in lockstep
+type LockstepPair struct {
+	Users     [2]int    `json:"users"`
+	CoVotes   int       `json:"co_votes"`
+	FlaggedAt time.Time `json:"flagged_at"`
+	LastAt    time.Time `json:"last_at"`
+}
+
+// Brigade is a burst of votes from the members of From into Into
+type Brigade struct {
+	From       string    `json:"from"`
+	Into       string    `json:"into"`
+	Discounted int       `json:"discounted"`
+	StartedAt  time.Time `json:"started_at"`
+	LastAt     time.Time `json:"last_at"`
+}
+
+// SharedFingerprint is a client several accounts voted on the same posts from
+type SharedFingerprint struct {
+	Fingerprint string    `json:"fingerprint"`
+	Users       []int     `json:"users"`
+	Discounted  int       `json:"discounted"`
+	LastAt      time.Time `json:"last_at"`
+}
+
+// VoteReport lists what vote analysis found, most recent first
+type VoteReport struct {
+	Votes        int                 `json:"votes"` // audited since the engine started
+	Discounted   int                 `json:"discounted"`
+	Accounts     []FlaggedAccount    `json:"accounts"`
+	Lockstep     []LockstepPair      `json:"lockstep"`
+	Brigades     []Brigade           `json:"brigades"`
+	Fingerprints []SharedFingerprint `json:"fingerprints"`
+}
+
+func newVoteAudit() *VoteAudit {
+	return &VoteAudit{
+		Accounts:     map[int]*FlaggedAccount{},
+		Lockstep:     map[string]*LockstepPair{},
+		Brigades:     map[string]*Brigade{},
+		Fingerprints: map[string]*SharedFingerprint{},
+	}
+}
+
+func (a *VoteAudit) clone() *VoteAudit {
+	copied := newVoteAudit()
+	copied.Discounted = a.Discounted
+	for id, account := range a.Accounts {
+		flagged := *account
+		flagged.Reasons = make(map[string]int, len(account.Reasons))
+		for reason, count := range account.Reasons {
+			flagged.Reasons[reason] = count
+		}
+		copied.Accounts[id] = &flagged
+	}
+	for key, pair := range a.Lockstep {
+		copiedPair := *pair
+		copied.Lockstep[key] = &copiedPair
+	}
+	for key, brigade := range a.Brigades {
+		copiedBrigade := *brigade
+		copied.Brigades[key] = &copiedBrigade
+	}
+	for key, shared := range a.Fingerprints {
+		copiedShared := *shared
+		copiedShared.Users = slices.Clone(shared.Users)
+		copied.Fingerprints[key] = &copiedShared
+	}
+	return copied
+}
+
+// report lists the audit, most recent first
+func (a *VoteAudit) report(votes int) VoteReport {
+	report := VoteReport{Votes: votes, Discounted: a.Discounted, Accounts: []FlaggedAccount{}, Lockstep: []LockstepPair{}, Brigades: []Brigade{}, Fingerprints: []SharedFingerprint{}}
+	for _, account := range a.Accounts {
+		report.Accounts = append(report.Accounts, *account)
+	}
+	for _, pair := range a.Lockstep {
+		report.Lockstep = append(report.Lockstep, *pair)
+	}
+	for _, brigade := range a.Brigades {
+		report.Brigades = append(report.Brigades, *brigade)
+	}
+	for _, shared := range a.Fingerprints {
+		report.Fingerprints = append(report.Fingerprints, *shared)
+	}
+	slices.SortFunc(report.Accounts, func(x, y FlaggedAccount) int { return y.LastFlaggedAt.Compare(x.LastFlaggedAt) })
+	slices.SortFunc(report.Lockstep, func(x, y LockstepPair) int { return y.LastAt.Compare(x.LastAt) })
+	slices.SortFunc(report.Brigades, func(x, y Brigade) int { return y.LastAt.Compare(x.LastAt) })
+	slices.SortFunc(report.Fingerprints, func(x, y SharedFingerprint) int { return y.LastAt.Compare(x.LastAt) })
+	return report
+}
+
+// trim drops the oldest entries of every list beyond MaxVoteReportEntries
+func (a *VoteAudit) trim() {
+	trimMap(a.Accounts, func(account *FlaggedAccount) time.Time { return account.LastFlaggedAt })
+	trimMap(a.Lockstep, func(pair *LockstepPair) time.Time { return pair.LastAt })
+	trimMap(a.Brigades, func(brigade *Brigade) time.Time { return brigade.LastAt })
+	trimMap(a.Fingerprints, func(shared *SharedFingerprint) time.Time { return shared.LastAt })
+}
+
+func trimMap[K comparable, V any](entries map[K]V, last func(V) time.Time) {
+	over := len(entries) - MaxVoteReportEntries
+	if over <= 0 {
+		return
+	}
+	keys := make([]K, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(x, y K) int { return last(entries[x]).Compare(last(entries[y])) })
+	for _, key := range keys[:over] {
+		delete(entries, key)
+	}
+}
+
+// checkVote asks VoteAuditActor whether a post vote counts; answered with voteVerdict
+type checkVote struct {
+	vote      Vote
+	subreddit string // of the post
+	at        time.Time
+}
+
+// voteVerdict answers checkVote; a vote with reasons is discounted
+type voteVerdict struct {
+	reasons []string
+}
+
+// auditedVote is a vote VoteAuditActor remembers for the analysis window
+type auditedVote struct {
+	userID      int
+	postID      int
+	subreddit   string
+	up          bool
+	fingerprint string
+	from        []string // the voter's subreddits when they are not a member of subreddit
+	at          time.Time
+}
+
+// voterStats counts the votes of one account, or the co-votes of a pair, while it is active
+type voterStats struct {
+	votes int
+	last  time.Time
+}
+
+type cachedMemberships struct {
+	names []string
+	at    time.Time
+}
+
+// membershipTTL is how long VoteAuditActor trusts a voter's subreddits before asking again
+const membershipTTL = time.Minute
+
+// VoteAuditActor looks for vote manipulation in the post votes PostActor hands it: accounts
+// voting in lockstep, brigades from one subreddit into another and several accounts voting
+// from one client. It answers each vote with the reasons to discount it, if any.
+type VoteAuditActor struct {
+	subredditActor *actor.PID
+	options        VoteAuditOptions
+	timeout        time.Duration
+	audit          *VoteAudit
+	votes          int                    // audited since the actor started
+	recent         []*auditedVote         // votes within the window, oldest first
+	byPost         map[int][]*auditedVote // the same votes by post, oldest first
+	bySubreddit    map[string][]*auditedVote
+	voters         map[int]*voterStats
+	pairs          map[[2]int]*voterStats
+	memberships    map[int]cachedMemberships
+	store          Store
+	logger         *slog.Logger
+	mu             sync.Mutex
+}
+
+func (v *VoteAuditActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		v.mu.Lock()
+		v.restore()
+		v.logger.Debug("state restored", "discounted_votes", v.audit.Discounted, "flagged_accounts", len(v.audit.Accounts))
+		v.mu.Unlock()
+
+	case *checkVote:
+		v.mu.Lock()
+		cached, fresh := v.memberships[msg.vote.UserID]
+		fresh = fresh && msg.at.Sub(cached.at) < membershipTTL
+		v.mu.Unlock()
+		if fresh || v.subredditActor == nil {
+			v.respondVerdict(ctx, msg, cached.names)
+			return
+		}
+		// Leave PostActor time to count the vote should the lookup time out
+		future := ctx.RequestFuture(v.subredditActor, &GetMemberships{UserID: msg.vote.UserID, RequestID: msg.vote.RequestID}, v.timeout/2)
+		ctx.ReenterAfter(future, func(res interface{}, err error) {
+			names, ok := res.([]string)
+			if err != nil || !ok {
+				v.logger.Warn("voter's subreddits unknown, brigade check skipped", "request_id", msg.vote.RequestID, "user_id", msg.vote.UserID, "error", err)
+				v.respondVerdict(ctx, msg, nil)
+				return
+			}
+			v.mu.Lock()
+			v.memberships[msg.vote.UserID] = cachedMemberships{names: names, at: msg.at}
+			v.mu.Unlock()
+			v.respondVerdict(ctx, msg, names)
+		})
+
+	case *GetVoteReport:
+		v.mu.Lock()
+		report := v.audit.report(v.votes)
+		v.mu.Unlock()
+		ctx.Respond(report)
+	}
+}
+
+// restore loads the audit from the Store and starts the analysis window over; v.mu must be held.
+func (v *VoteAuditActor) restore() {
+	if v.audit = v.store.VoteAudit(); v.audit == nil {
+		v.audit = newVoteAudit()
+	}
+	v.recent = nil
+	v.byPost = map[int][]*auditedVote{}
+	v.bySubreddit = map[string][]*auditedVote{}
+	v.voters = map[int]*voterStats{}
+	v.pairs = map[[2]int]*voterStats{}
+	v.memberships = map[int]cachedMemberships{}
+}
+
+func (v *VoteAuditActor) respondVerdict(ctx actor.Context, msg *checkVote, memberships []string) {
+	v.mu.Lock()
+	reasons := v.analyze(msg, memberships)
+	v.mu.Unlock()
+	ctx.Respond(voteVerdict{reasons: reasons})
+}
+
+// analyze remembers a vote and returns the reasons to discount it; memberships is nil when
+// the voter's subreddits are unknown. v.mu must be held.
+func (v *VoteAuditActor) analyze(msg *checkVote, memberships []string) []string {
+	v.expire(msg.at)
+	vote := &auditedVote{userID: msg.vote.UserID, postID: msg.vote.ID, subreddit: msg.subreddit, up: msg.vote.Type == "upvote", fingerprint: msg.vote.Fingerprint, at: msg.at}
+	if memberships != nil && !slices.Contains(memberships, msg.subreddit) {
+		vote.from = memberships
+	}
+
+	var reasons []string
+	if partners := v.lockstep(vote); len(partners) > 0 {
+		reasons = append(reasons, VoteLockstep)
+		v.logger.Info("lockstep vote", "request_id", msg.vote.RequestID, "post_id", vote.postID, "user_id", vote.userID, "partners", partners)
+	}
+	if others := v.sharedFingerprint(vote); len(others) > 0 {
+		reasons = append(reasons, VoteFingerprint)
+		v.logger.Info("vote from a shared client", "request_id", msg.vote.RequestID, "post_id", vote.postID, "user_id", vote.userID, "other_users", others)
+	}
+	// Membership lookups finish in any order, so a vote may be older than ones already analysed
+	v.recent = insertByTime(v.recent, vote)
+	v.byPost[vote.postID] = insertByTime(v.byPost[vote.postID], vote)
+	v.bySubreddit[vote.subreddit] = insertByTime(v.bySubreddit[vote.subreddit], vote)
+	if from := v.brigade(vote); len(from) > 0 {
+		reasons = append(reasons, VoteBrigade)
+		v.logger.Info("brigade vote", "request_id", msg.vote.RequestID, "post_id", vote.postID, "user_id", vote.userID, "subreddit", vote.subreddit, "from", from)
+	}
+
+	v.votes++
+	if len(reasons) > 0 {
+		v.audit.Discounted++
+		account, exists := v.audit.Accounts[vote.userID]
+		if !exists {
+			account = &FlaggedAccount{UserID: vote.userID, Reasons: map[string]int{}, FirstFlaggedAt: vote.at}
+			v.audit.Accounts[vote.userID] = account
+		}
+		account.Discounted++
+		for _, reason := range reasons {
+			account.Reasons[reason]++
+		}
+		account.LastFlaggedAt = latest(account.LastFlaggedAt, vote.at)
+		v.audit.trim()
+		v.store.SaveVoteAudit(v.audit)
+	}
+	return reasons
+}
+
+// lockstep counts the vote towards every pair it forms with another vote on the post, and
+// returns the accounts among them the voter is in lockstep with; v.mu must be held.
+func (v *VoteAuditActor) lockstep(vote *auditedVote) []int {
+	earlier := v.byPost[vote.postID] // analysed before this vote, though not always cast before it
+	voter := v.voterStats(vote.userID, vote.at)
+	if slices.ContainsFunc(earlier, func(other *auditedVote) bool { return other.userID == vote.userID }) {
+		// A repeat vote on the same post forms no new pairs
+		return v.flaggedPartners(vote, earlier)
+	}
+	voter.votes++
+	seen := map[int]bool{}
+	for _, other := range earlier {
+		if other.userID == vote.userID || seen[other.userID] || other.up != vote.up || !within(vote.at, other.at, v.options.LockstepGap) {
+			continue
+		}
+		seen[other.userID] = true
+		key := pairKey(vote.userID, other.userID)
+		pair, exists := v.pairs[key]
+		if !exists {
+			pair = &voterStats{}
+			v.pairs[key] = pair
+		}
+		pair.votes++
+		pair.last = latest(pair.last, vote.at)
+		if v.inLockstep(key) {
+			flagged, exists := v.audit.Lockstep[lockstepKey(key)]
+			if !exists {
+				flagged = &LockstepPair{Users: key, FlaggedAt: vote.at}
+				v.audit.Lockstep[lockstepKey(key)] = flagged
+				v.logger.Warn("accounts voting in lockstep", "users", key, "co_votes", pair.votes)
+			}
+			flagged.CoVotes = max(flagged.CoVotes, pair.votes)
+			flagged.LastAt = latest(flagged.LastAt, vote.at)
+		}
+	}
+	return v.flaggedPartners(vote, earlier)
+}
+
+// flaggedPartners returns the accounts in lockstep with the voter that voted the same way on
+// the post within the lockstep gap; v.mu must be held.
+func (v *VoteAuditActor) flaggedPartners(vote *auditedVote, earlier []*auditedVote) []int {
+	var partners []int
+	for _, other := range earlier {
+		if other.userID == vote.userID || other.up != vote.up || !within(vote.at, other.at, v.options.LockstepGap) || slices.Contains(partners, other.userID) {
+			continue
+		}
+		if v.inLockstep(pairKey(vote.userID, other.userID)) {
+			partners = append(partners, other.userID)
+		}
+	}
+	return partners
+}
+
+// inLockstep reports whether the pair's co-votes are too many and too large a share of the
+// less active account's votes to be chance; v.mu must be held.
+func (v *VoteAuditActor) inLockstep(key [2]int) bool {
+	pair, exists := v.pairs[key]
+	if !exists || pair.votes < v.options.LockstepMinVotes {
+		return false
+	}
+	fewest := min(v.voterStats(key[0], pair.last).votes, v.voterStats(key[1], pair.last).votes)
+	return float64(pair.votes) >= v.options.LockstepShare*float64(fewest)
+}
+
+// sharedFingerprint returns the other accounts that voted on the post from the same client,
+// when that check is on; v.mu must be held.
+func (v *VoteAuditActor) sharedFingerprint(vote *auditedVote) []int {
+	if !v.options.Fingerprint || vote.fingerprint == "" {
+		return nil
+	}
+	var others []int
+	for _, other := range v.byPost[vote.postID] {
+		if other.fingerprint == vote.fingerprint && other.userID != vote.userID && !slices.Contains(others, other.userID) {
+			others = append(others, other.userID)
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	shared, exists := v.audit.Fingerprints[vote.fingerprint]
+	if !exists {
+		shared = &SharedFingerprint{Fingerprint: vote.fingerprint}
+		v.audit.Fingerprints[vote.fingerprint] = shared
+	}
+	for _, userID := range append(others, vote.userID) {
+		if !slices.Contains(shared.Users, userID) {
+			shared.Users = append(shared.Users, userID)
+		}
+	}
+	slices.Sort(shared.Users)
+	shared.Discounted++
+	shared.LastAt = latest(shared.LastAt, vote.at)
+	return others
+}
+
+// brigade returns the subreddits whose members, outsiders to the voted subreddit, are
+// brigading it with this vote; the vote must be recorded already. v.mu must be held.
+func (v *VoteAuditActor) brigade(vote *auditedVote) []string {
+	if len(vote.from) == 0 {
+		return nil
+	}
+	// Count the votes within the window on either side, as later votes may have been analysed first
+	total, outsiders := 0, map[string]int{}
+	votes := v.bySubreddit[vote.subreddit]
+	first, _ := slices.BinarySearchFunc(votes, vote.at.Add(-v.options.BrigadeWindow), func(other *auditedVote, at time.Time) int { return other.at.Compare(at) })
+	for _, other := range votes[first:] {
+		if other.at.Sub(vote.at) > v.options.BrigadeWindow {
+			break
+		}
+		total++
+		for _, from := range other.from {
+			outsiders[from]++
+		}
+	}
+	var brigading []string
+	for _, from := range vote.from {
+		count := outsiders[from]
+		if count < v.options.BrigadeMinVotes || float64(count) < v.options.BrigadeShare*float64(total) {
+			continue
+		}
+		brigading = append(brigading, from)
+		key := from + ">" + vote.subreddit
+		brigade, exists := v.audit.Brigades[key]
+		if !exists || vote.at.Sub(brigade.LastAt) > v.options.BrigadeWindow {
+			if exists {
+				delete(v.audit.Brigades, key) // an earlier burst; this is a new one
+			}
+			brigade = &Brigade{From: from, Into: vote.subreddit, StartedAt: vote.at}
+			v.audit.Brigades[key] = brigade
+			v.logger.Warn("brigade detected", "from", from, "into", vote.subreddit, "votes", count, "subreddit_votes", total)
+		}
+		brigade.Discounted++
+		brigade.LastAt = latest(brigade.LastAt, vote.at)
+	}
+	return brigading
+}
+
+// voterStats returns the vote count of an account, restarting it once the account has been
+// quiet for the whole window; v.mu must be held.
+func (v *VoteAuditActor) voterStats(userID int, now time.Time) *voterStats {
+	stats, exists := v.voters[userID]
+	if !exists || now.Sub(stats.last) > v.options.Window {
+		stats = &voterStats{}
+		v.voters[userID] = stats
+	}
+	if now.After(stats.last) {
+		stats.last = now
+	}
+	return stats
+}
+
+// expire forgets the votes, accounts and pairs that fell out of the window; v.mu must be held.
+func (v *VoteAuditActor) expire(now time.Time) {
+	cutoff := now.Add(-v.options.Window)
+	expired := 0
+	for expired < len(v.recent) && v.recent[expired].at.Before(cutoff) {
+		vote := v.recent[expired]
+		if v.byPost[vote.postID] = v.byPost[vote.postID][1:]; len(v.byPost[vote.postID]) == 0 {
+			delete(v.byPost, vote.postID)
+		}
+		if v.bySubreddit[vote.subreddit] = v.bySubreddit[vote.subreddit][1:]; len(v.bySubreddit[vote.subreddit]) == 0 {
+			delete(v.bySubreddit, vote.subreddit)
+		}
+		expired++
+	}
+	if expired == 0 {
+		return
+	}
+	v.recent = slices.Delete(v.recent, 0, expired)
+	for userID, stats := range v.voters {
+		if stats.last.Before(cutoff) {
+			delete(v.voters, userID)
+		}
+	}
+	for key, pair := range v.pairs {
+		if pair.last.Before(cutoff) {
+			delete(v.pairs, key)
+		}
+	}
+}
+
+// insertByTime adds vote to votes, which are sorted oldest first, after any cast at the same time
+func insertByTime(votes []*auditedVote, vote *auditedVote) []*auditedVote {
+	i := len(votes)
+	for i > 0 && votes[i-1].at.After(vote.at) {
+		i--
+	}
+	return slices.Insert(votes, i, vote)
+}
+
+// within reports whether a and b are at most gap apart, in either order
+func within(a, b time.Time, gap time.Duration) bool {
+	return a.Sub(b) <= gap && b.Sub(a) <= gap
+}
+
+// latest returns the later of a and b, so a vote analysed late doesn't move a time back
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func pairKey(a, b int) [2]int {
+	return [2]int{min(a, b), max(a, b)}
+}
+
+func lockstepKey(key [2]int) string {
+	return strconv.Itoa(key[0]) + ":" + strconv.Itoa(key[1])
+}
+
+// auditVote has VoteAuditActor check a vote without blocking the mailbox, then calls count
+// with the reasons to discount it. Votes count in full when analysis is off or fails.
+func (p *PostActor) auditVote(ctx actor.Context, msg *Vote, subreddit string, count func(reasons []string)) {
+	if p.voteAuditActor == nil {
+		count(nil)
+		return
+	}
+	future := ctx.RequestFuture(p.voteAuditActor, &checkVote{vote: *msg, subreddit: subreddit, at: time.Now()}, p.timeout)
+	ctx.ReenterAfter(future, func(res interface{}, err error) {
+		verdict, ok := res.(voteVerdict)
+		if err != nil || !ok {
+			p.logger.Warn("vote analysis failed, vote counted", "request_id", msg.RequestID, "post_id", msg.ID, "user_id", msg.UserID, "error", err)
+		}
+		count(verdict.reasons)
+	})
+}
+
+// VoteReport returns what vote analysis found to an admin.
+func (as *ActorSystem) VoteReport(ctx context.Context, msg GetVoteReport) (VoteReport, error) {
+	if !as.Admins[msg.UserID] {
+		return VoteReport{}, ErrNotAdmin
+	}
+	result, err := as.requestFuture(ctx, as.VoteAuditActor, &msg, as.RequestTimeout).Result()
+	if err != nil {
+		as.Logger.Error("error fetching vote report", "request_id", msg.RequestID, "error", err)
+		return VoteReport{}, err
+	}
+	report, ok := result.(VoteReport)
+	if !ok {
+		return VoteReport{}, fmt.Errorf("unexpected vote report %T", result)
+	}
+	return report, nil
+}
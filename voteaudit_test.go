@@ -0,0 +1,183 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestSharedFingerprintIsOptIn(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		fingerprint bool
+		want        int
+	}{
+		{"off by default", false, 3},
+		{"on", true, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			as := newTestActorSystem(t, NewMemoryStore(), func(as *ActorSystem) {
+				as.VoteAudit.Fingerprint = tc.fingerprint
+				as.Admins = map[int]bool{1: true}
+			})
+			as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "hello"})
+			eventually(t, "the post", func() bool {
+				_, err := as.livePost(ctx, 1, "")
+				return err == nil
+			})
+
+			// Three colleagues behind the same office proxy
+			office := ClientFingerprint("203.0.113.7", "Mozilla/5.0")
+			for userID := 2; userID <= 4; userID++ {
+				as.VotePost(ctx, Vote{UserID: userID, Target: "post", ID: 1, Type: "upvote", Fingerprint: office})
+			}
+			eventually(t, "the votes to be audited", func() bool {
+				report, err := as.VoteReport(ctx, GetVoteReport{UserID: 1})
+				return err == nil && report.Votes == 3
+			})
+			if post, err := as.livePost(ctx, 1, ""); err != nil || post.Upvotes != tc.want {
+				t.Fatalf("post has %v upvotes (%v), want %d", post.Upvotes, err, tc.want)
+			}
+		})
+	}
+}
+
+// auditedTestVote is a vote handed to VoteAuditActor.analyze, at an offset from a fixed start
+type auditedTestVote struct {
+	user, post  int
+	down        bool
+	at          time.Duration
+	memberships []string // nil when the voter's subreddits are unknown
+}
+
+func newTestVoteAuditActor(options VoteAuditOptions) *VoteAuditActor {
+	v := &VoteAuditActor{options: options, store: NewMemoryStore(), logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	v.restore()
+	return v
+}
+
+// analyzeVotes runs votes through analyze in the order given, into r/golang, and returns the
+// indexes of the votes it discounted for reason
+func analyzeVotes(v *VoteAuditActor, votes []auditedTestVote, reason string) []int {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	var discounted []int
+	for i, vote := range votes {
+		voteType := "upvote"
+		if vote.down {
+			voteType = "downvote"
+		}
+		msg := &checkVote{vote: Vote{UserID: vote.user, Target: "post", ID: vote.post, Type: voteType}, subreddit: "golang", at: start.Add(vote.at)}
+		if slices.Contains(v.analyze(msg, vote.memberships), reason) {
+			discounted = append(discounted, i)
+		}
+	}
+	return discounted
+}
+
+func TestLockstepVoters(t *testing.T) {
+	// pairs has users 1 and 2 vote on the same posts; with secondFirst the second vote of each
+	// pair finishes its membership lookup first and is analysed before the first
+	pairs := func(posts int, lag time.Duration, secondFirst, opposite bool) []auditedTestVote {
+		var votes []auditedTestVote
+		for post := 1; post <= posts; post++ {
+			at := time.Duration(post) * time.Hour
+			first := auditedTestVote{user: 1, post: post, at: at}
+			second := auditedTestVote{user: 2, post: post, down: opposite, at: at + lag}
+			if secondFirst {
+				first, second = second, first
+			}
+			votes = append(votes, first, second)
+		}
+		return votes
+	}
+	tests := []struct {
+		name  string
+		votes []auditedTestVote
+		want  []int
+	}{
+		{"in lockstep", pairs(5, 30*time.Second, false, false), []int{9}},
+		{"analysed out of order", pairs(5, 30*time.Second, true, false), []int{9}},
+		{"too few co-votes", pairs(4, 30*time.Second, false, false), nil},
+		{"too far apart", pairs(5, 10*time.Minute, false, false), nil},
+		{"too far apart, analysed out of order", pairs(5, 10*time.Minute, true, false), nil},
+		{"voting against each other", pairs(5, 30*time.Second, false, true), nil},
+		{
+			"mostly voting alone",
+			append(pairs(5, 30*time.Second, false, false),
+				auditedTestVote{user: 2, post: 11, at: 20 * time.Hour}, auditedTestVote{user: 2, post: 12, at: 21 * time.Hour},
+				auditedTestVote{user: 1, post: 13, at: 22 * time.Hour}, auditedTestVote{user: 1, post: 14, at: 22 * time.Hour}),
+			[]int{9},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := newTestVoteAuditActor(DefaultVoteAuditOptions())
+			if got := analyzeVotes(v, tt.votes, VoteLockstep); !slices.Equal(got, tt.want) {
+				t.Fatalf("discounted votes %v, want %v", got, tt.want)
+			}
+			if _, flagged := v.audit.Lockstep["1:2"]; flagged != (len(tt.want) > 0) {
+				t.Fatalf("pair 1:2 flagged = %v, want %v", flagged, len(tt.want) > 0)
+			}
+		})
+	}
+}
+
+func TestBrigadeFromOutsiders(t *testing.T) {
+	rust, golang := []string{"rust"}, []string{"golang"}
+	tests := []struct {
+		name  string
+		votes []auditedTestVote
+		want  []int
+	}{
+		{
+			"burst from outsiders",
+			[]auditedTestVote{{user: 1, post: 1, memberships: rust}, {user: 2, post: 2, at: time.Minute, memberships: rust}, {user: 3, post: 3, at: 2 * time.Minute, memberships: rust}},
+			[]int{2},
+		},
+		{
+			"analysed out of order",
+			[]auditedTestVote{{user: 3, post: 3, at: 2 * time.Minute, memberships: rust}, {user: 2, post: 2, at: time.Minute, memberships: rust}, {user: 1, post: 1, memberships: rust}},
+			[]int{2},
+		},
+		{
+			"late vote from before the window",
+			[]auditedTestVote{{user: 2, post: 2, at: 20 * time.Minute, memberships: rust}, {user: 3, post: 3, at: 21 * time.Minute, memberships: rust}, {user: 1, post: 1, memberships: rust}},
+			nil,
+		},
+		{
+			"spread out",
+			[]auditedTestVote{{user: 1, post: 1, memberships: rust}, {user: 2, post: 2, at: 20 * time.Minute, memberships: rust}, {user: 3, post: 3, at: 40 * time.Minute, memberships: rust}},
+			nil,
+		},
+		{
+			"members outvote them",
+			[]auditedTestVote{
+				{user: 4, post: 4, memberships: golang}, {user: 5, post: 5, memberships: golang}, {user: 6, post: 6, memberships: golang}, {user: 7, post: 7, memberships: golang},
+				{user: 1, post: 1, memberships: rust}, {user: 2, post: 2, memberships: rust}, {user: 3, post: 3, memberships: rust},
+			},
+			nil,
+		},
+		{
+			"memberships unknown",
+			[]auditedTestVote{{user: 1, post: 1}, {user: 2, post: 2}, {user: 3, post: 3}},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultVoteAuditOptions()
+			options.BrigadeMinVotes = 3
+			v := newTestVoteAuditActor(options)
+			if got := analyzeVotes(v, tt.votes, VoteBrigade); !slices.Equal(got, tt.want) {
+				t.Fatalf("discounted votes %v, want %v", got, tt.want)
+			}
+			if _, flagged := v.audit.Brigades["rust>golang"]; flagged != (len(tt.want) > 0) {
+				t.Fatalf("brigade rust>golang recorded = %v, want %v", flagged, len(tt.want) > 0)
+			}
+		})
+	}
+}
@@ -0,0 +1,405 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/eventstream"
+)
+
+// Webhook event types
+const (
+	WebhookPostCreated    = "post_created"
+	WebhookCommentCreated = "comment_created"
+	WebhookContentRemoved = "content_removed" // by a moderator, AutoModerator or the spam filter
+	WebhookContentDeleted = "content_deleted" // by its author
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+var (
+	ErrNoSuchSubreddit = errors.New("subreddit does not exist")
+	ErrNotModerator    = errors.New("user is not a moderator of the subreddit")
+	ErrNoSuchWebhook   = errors.New("webhook does not exist")
+	ErrInvalidWebhook  = errors.New("invalid webhook")
+)
+
+var webhookEvents = []string{WebhookPostCreated, WebhookCommentCreated, WebhookContentRemoved, WebhookContentDeleted}
+
+// WebhookOptions controls delivery. A failed delivery is retried up to MaxAttempts times,
+// waiting Backoff and then twice as long before each retry. A webhook is disabled after
+// DisableAfter deliveries in a row have failed. Each webhook's deliveries are made one at a
+// time; up to QueueSize more wait their turn and further ones are dropped.
+type WebhookOptions struct {
+	Client       *http.Client
+	MaxAttempts  int
+	Backoff      time.Duration
+	DisableAfter int
+	QueueSize    int
+}
+
+func DefaultWebhookOptions() WebhookOptions {
+	return WebhookOptions{
+		Client:       &http.Client{Timeout: 5 * time.Second},
+		MaxAttempts:  5,
+		Backoff:      time.Second,
+		DisableAfter: 3,
+		QueueSize:    100,
+	}
+}
+
+// WebhookPayload is the JSON body of a delivery
+type WebhookPayload struct {
+	DeliveryID  string    `json:"delivery_id"`
+	Event       string    `json:"event"`
+	WebhookID   int       `json:"webhook_id"`
+	Subreddit   string    `json:"subreddit"`
+	PostID      int       `json:"post_id"`
+	CommentID   int       `json:"comment_id,omitempty"`
+	UserID      int       `json:"user_id"`
+	Content     string    `json:"content,omitempty"`
+	ContentHTML string    `json:"content_html,omitempty"`
+	At          time.Time `json:"at"`
+}
+
+// SignWebhook returns the X-Webhook-Signature value for a body sent at timestamp (Unix
+// seconds): "sha256=" and the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the secret.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhook checks a delivery's signature the way a receiver should.
+func VerifyWebhook(secret, timestamp, signature string, body []byte) bool {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal([]byte(SignWebhook(secret, ts, body)), []byte(signature))
+}
+
+// webhookEvent maps an engine event to the webhook event it triggers, if any.
+func webhookEvent(event *LiveEvent) string {
+	switch event.Type {
+	case EventPostCreated:
+		return WebhookPostCreated
+	case EventCommentCreated:
+		return WebhookCommentCreated
+	case EventPostDeleted, EventCommentDeleted:
+		if event.Removed {
+			return WebhookContentRemoved
+		}
+		return WebhookContentDeleted
+	}
+	return ""
+}
+
+// webhookResponse answers RegisterWebhook, ListWebhooks and DeleteWebhook
+type webhookResponse struct {
+	webhooks []Webhook
+	err      error
+}
+
+// webhookResult reports how a delivery ended, after all its retries
+type webhookResult struct {
+	webhookID  int
+	deliveryID string
+	err        error
+}
+
+// webhookWorker delivers one webhook's events in order, so a slow receiver ties up a single
+// goroutine and at most QueueSize waiting deliveries.
+type webhookWorker struct {
+	queue  chan WebhookPayload
+	cancel context.CancelFunc
+}
+
+// WebhookActor keeps the registered webhooks and delivers the engine events they asked for,
+// which it follows on the actor system's event stream.
+type WebhookActor struct {
+	hooks          map[int]*Webhook
+	workers        map[int]*webhookWorker // by webhook ID, started on its first delivery
+	nextID         int
+	subredditActor *actor.PID
+	options        WebhookOptions
+	timeout        time.Duration
+	subscription   *eventstream.Subscription
+	deliveries     context.Context
+	cancel         context.CancelFunc
+	store          Store
+	logger         *slog.Logger
+	mu             sync.Mutex
+}
+
+func (w *WebhookActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		w.mu.Lock()
+		w.hooks = w.store.Webhooks()
+		w.workers = map[int]*webhookWorker{}
+		w.nextID = 1
+		for id := range w.hooks {
+			w.nextID = max(w.nextID, id+1)
+		}
+		w.logger.Debug("state restored", "webhooks", len(w.hooks))
+		w.mu.Unlock()
+
+		w.deliveries, w.cancel = context.WithCancel(context.Background())
+		// The stream calls us from the publishing actor, so hand events over to our own mailbox.
+		// Filtered here rather than with SubscribeWithPredicate, which sets its predicate only
+		// after the subscription is live.
+		self, root := ctx.Self(), ctx.ActorSystem().Root
+		w.subscription = ctx.ActorSystem().EventStream.Subscribe(func(evt interface{}) {
+			if event, ok := evt.(*LiveEvent); ok && webhookEvent(event) != "" {
+				root.Send(self, evt)
+			}
+		})
+
+	case *actor.Stopping, *actor.Restarting:
+		// Queued deliveries and retries still waiting are abandoned, which stops every worker
+		ctx.ActorSystem().EventStream.Unsubscribe(w.subscription)
+		w.cancel()
+
+	case *LiveEvent:
+		w.mu.Lock()
+		event := webhookEvent(msg)
+		for _, hook := range w.hooks {
+			if hook.Disabled || !strings.EqualFold(hook.Subreddit, msg.Subreddit) || !slices.Contains(hook.Events, event) {
+				continue
+			}
+			payload := WebhookPayload{
+				DeliveryID:  randomHex(8),
+				Event:       event,
+				WebhookID:   hook.ID,
+				Subreddit:   msg.Subreddit,
+				PostID:      msg.PostID,
+				CommentID:   msg.CommentID,
+				UserID:      msg.UserID,
+				Content:     msg.Content,
+				ContentHTML: msg.ContentHTML,
+				At:          msg.At,
+			}
+			select {
+			case w.worker(ctx, hook).queue <- payload:
+			default:
+				w.logger.Warn("webhook queue full, delivery dropped", "webhook_id", hook.ID, "delivery_id", payload.DeliveryID, "event", event)
+			}
+		}
+		w.mu.Unlock()
+
+	case *webhookResult:
+		w.mu.Lock()
+		if hook, exists := w.hooks[msg.webhookID]; exists {
+			if msg.err == nil {
+				hook.Failures = 0
+			} else {
+				hook.Failures++
+				w.logger.Warn("webhook delivery failed", "webhook_id", hook.ID, "delivery_id", msg.deliveryID, "failures", hook.Failures, "error", msg.err)
+				if hook.Failures >= w.options.DisableAfter && !hook.Disabled {
+					hook.Disabled = true
+					w.stopWorker(hook.ID)
+					w.logger.Warn("webhook disabled", "webhook_id", hook.ID, "subreddit", hook.Subreddit, "url", hook.URL)
+				}
+			}
+			w.store.SaveWebhook(hook)
+		}
+		w.mu.Unlock()
+
+	case *RegisterWebhook:
+		if err := validateWebhook(msg.URL, msg.Events); err != nil {
+			ctx.Respond(webhookResponse{err: err})
+			return
+		}
+		w.asModerator(ctx, msg.Subreddit, msg.UserID, func() webhookResponse {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			hook := &Webhook{
+				ID:        w.nextID,
+				Subreddit: msg.Subreddit,
+				URL:       msg.URL,
+				Events:    append([]string(nil), msg.Events...),
+				Secret:    randomHex(32),
+				CreatedBy: msg.UserID,
+				CreatedAt: time.Now(),
+			}
+			w.nextID++
+			w.hooks[hook.ID] = hook
+			w.store.SaveWebhook(hook)
+			w.logger.Info("webhook registered", "request_id", msg.RequestID, "webhook_id", hook.ID, "subreddit", hook.Subreddit, "events", hook.Events)
+			return webhookResponse{webhooks: []Webhook{*hook.clone()}}
+		})
+
+	case *ListWebhooks:
+		w.asModerator(ctx, msg.Subreddit, msg.UserID, func() webhookResponse {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			hooks := []Webhook{}
+			for _, hook := range w.hooks {
+				if hook.Subreddit == msg.Subreddit {
+					listed := *hook.clone()
+					listed.Secret = ""
+					hooks = append(hooks, listed)
+				}
+			}
+			sort.Slice(hooks, func(i, j int) bool { return hooks[i].ID < hooks[j].ID })
+			return webhookResponse{webhooks: hooks}
+		})
+
+	case *DeleteWebhook:
+		w.asModerator(ctx, msg.Subreddit, msg.UserID, func() webhookResponse {
+			w.mu.Lock()
+			defer w.mu.Unlock()
+			hook, exists := w.hooks[msg.WebhookID]
+			if !exists || hook.Subreddit != msg.Subreddit {
+				return webhookResponse{err: ErrNoSuchWebhook}
+			}
+			delete(w.hooks, hook.ID)
+			w.stopWorker(hook.ID)
+			w.store.DeleteWebhook(hook.ID)
+			w.logger.Info("webhook deleted", "request_id", msg.RequestID, "webhook_id", hook.ID, "subreddit", hook.Subreddit)
+			return webhookResponse{}
+		})
+	}
+}
+
+// asModerator answers the current request with then() when userID moderates subreddit, and
+// with the reason otherwise. The subreddit is looked up without blocking the mailbox.
+func (w *WebhookActor) asModerator(ctx actor.Context, subreddit string, userID int, then func() webhookResponse) {
+	future := ctx.RequestFuture(w.subredditActor, &GetSubreddit{Name: subreddit}, w.timeout)
+	ctx.ReenterAfter(future, func(res interface{}, err error) {
+		found, _ := res.(*Subreddit)
+		switch {
+		case err != nil:
+			ctx.Respond(webhookResponse{err: err})
+		case found == nil:
+			ctx.Respond(webhookResponse{err: ErrNoSuchSubreddit})
+		case !found.Moderators[userID]:
+			ctx.Respond(webhookResponse{err: ErrNotModerator})
+		default:
+			ctx.Respond(then())
+		}
+	})
+}
+
+// worker returns hook's delivery worker, starting it if needed; w.mu must be held.
+func (w *WebhookActor) worker(ctx actor.Context, hook *Webhook) *webhookWorker {
+	if worker, exists := w.workers[hook.ID]; exists {
+		return worker
+	}
+	deliveries, cancel := context.WithCancel(w.deliveries)
+	worker := &webhookWorker{queue: make(chan WebhookPayload, w.options.QueueSize), cancel: cancel}
+	w.workers[hook.ID] = worker
+	system, self := ctx.ActorSystem(), ctx.Self()
+	go func(hook Webhook) {
+		for {
+			select {
+			case <-deliveries.Done():
+				return
+			case payload := <-worker.queue:
+				w.deliver(deliveries, system, self, hook, payload)
+			}
+		}
+	}(*hook.clone())
+	return worker
+}
+
+// stopWorker abandons a webhook's queued deliveries; w.mu must be held.
+func (w *WebhookActor) stopWorker(id int) {
+	if worker, exists := w.workers[id]; exists {
+		worker.cancel()
+		delete(w.workers, id)
+	}
+}
+
+// deliver posts payload to hook, retrying with exponential backoff, and reports the outcome
+// to the actor. It runs on the webhook's worker so slow endpoints don't hold up the mailbox.
+func (w *WebhookActor) deliver(ctx context.Context, system *actor.ActorSystem, self *actor.PID, hook Webhook, payload WebhookPayload) {
+	body, _ := json.Marshal(payload) // a WebhookPayload always encodes
+	backoff := w.options.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = w.post(ctx, hook, payload, body); err == nil || attempt >= w.options.MaxAttempts {
+			break
+		}
+		w.logger.Debug("webhook attempt failed", "webhook_id", hook.ID, "delivery_id", payload.DeliveryID, "attempt", attempt, "error", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	system.Root.Send(self, &webhookResult{webhookID: hook.ID, deliveryID: payload.DeliveryID, err: err})
+}
+
+func (w *WebhookActor) post(ctx context.Context, hook Webhook, payload WebhookPayload, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, payload.Event)
+	req.Header.Set(WebhookDeliveryHeader, payload.DeliveryID)
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(hook.Secret, timestamp, body))
+
+	resp, err := w.options.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+	return nil
+}
+
+func validateWebhook(rawURL string, events []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: URL must be an absolute http or https URL", ErrInvalidWebhook)
+	}
+	if len(events) == 0 {
+		return fmt.Errorf("%w: no events given", ErrInvalidWebhook)
+	}
+	for _, event := range events {
+		if !slices.Contains(webhookEvents, event) {
+			return fmt.Errorf("%w: unknown event %q", ErrInvalidWebhook, event)
+		}
+	}
+	return nil
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,237 @@
+package engine
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSignAndVerifyWebhook(t *testing.T) {
+	body := []byte(`{"event":"post_created"}`)
+	signature := SignWebhook("secret", 1700000000, body)
+
+	if !VerifyWebhook("secret", "1700000000", signature, body) {
+		t.Fatal("a delivery's own signature does not verify")
+	}
+	for name, ok := range map[string]bool{
+		"tampered body":     VerifyWebhook("secret", "1700000000", signature, []byte(`{"event":"content_removed"}`)),
+		"wrong secret":      VerifyWebhook("other", "1700000000", signature, body),
+		"replayed later":    VerifyWebhook("secret", "1700000001", signature, body),
+		"garbage timestamp": VerifyWebhook("secret", "soon", signature, body),
+	} {
+		if ok {
+			t.Errorf("%s verifies", name)
+		}
+	}
+}
+
+// webhookReceiver is an httptest endpoint answering each delivery with the next status of
+// statuses, then 200, and recording when every attempt arrived.
+type webhookReceiver struct {
+	*httptest.Server
+	statuses []int
+	secret   string
+	attempts []time.Time
+	events   []string // the X-Webhook-Event of every attempt
+	invalid  int      // deliveries whose signature did not verify
+	mu       sync.Mutex
+}
+
+func newWebhookReceiver(t *testing.T, statuses ...int) *webhookReceiver {
+	receiver := &webhookReceiver{statuses: statuses}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receiver.mu.Lock()
+		defer receiver.mu.Unlock()
+		if !VerifyWebhook(receiver.secret, r.Header.Get(WebhookTimestampHeader), r.Header.Get(WebhookSignatureHeader), body) {
+			receiver.invalid++
+		}
+		status := http.StatusOK
+		if n := len(receiver.attempts); n < len(receiver.statuses) {
+			status = receiver.statuses[n]
+		}
+		receiver.attempts = append(receiver.attempts, time.Now())
+		receiver.events = append(receiver.events, r.Header.Get(WebhookEventHeader))
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(receiver.Close)
+	return receiver
+}
+
+func (r *webhookReceiver) received() []time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]time.Time(nil), r.attempts...)
+}
+
+// registerTestWebhook creates r/golang, moderated by user 1, with a post_created webhook to receiver
+func registerTestWebhook(t *testing.T, as *ActorSystem, receiver *webhookReceiver) Webhook {
+	t.Helper()
+	ctx := context.Background()
+	as.CreateSubreddit(ctx, CreateSubreddit{Name: "golang", CreatorID: 1})
+	var hook Webhook
+	eventually(t, "the webhook to register", func() bool {
+		var err error
+		hook, err = as.RegisterWebhook(ctx, RegisterWebhook{Subreddit: "golang", UserID: 1, URL: receiver.URL, Events: []string{WebhookPostCreated}})
+		return err == nil
+	})
+	receiver.mu.Lock()
+	receiver.secret = hook.Secret
+	receiver.mu.Unlock()
+	return hook
+}
+
+func listTestWebhook(t *testing.T, as *ActorSystem) Webhook {
+	t.Helper()
+	hooks, err := as.ListWebhooks(context.Background(), ListWebhooks{Subreddit: "golang", UserID: 1})
+	if err != nil || len(hooks) != 1 {
+		t.Fatalf("ListWebhooks = %v, %v; want one webhook", hooks, err)
+	}
+	return hooks[0]
+}
+
+func TestWebhookRetriesServerErrors(t *testing.T) {
+	const backoff = 20 * time.Millisecond
+	as := newTestActorSystem(t, NewMemoryStore(), func(as *ActorSystem) {
+		as.Webhooks = WebhookOptions{Client: http.DefaultClient, MaxAttempts: 5, Backoff: backoff, DisableAfter: 3, QueueSize: 10}
+	})
+	receiver := newWebhookReceiver(t, http.StatusInternalServerError, http.StatusBadGateway)
+	registerTestWebhook(t, as, receiver)
+
+	as.CreatePost(context.Background(), PostMessage{UserID: 1, Subreddit: "golang", Title: "hello"})
+	eventually(t, "the delivery to succeed", func() bool { return len(receiver.received()) == 3 })
+
+	attempts := receiver.received()
+	if first, second := attempts[1].Sub(attempts[0]), attempts[2].Sub(attempts[1]); first < backoff || second < 2*backoff {
+		t.Errorf("retried after %v and %v, want at least %v and %v", first, second, backoff, 2*backoff)
+	}
+	if receiver.invalid != 0 {
+		t.Errorf("%d deliveries carried a bad signature", receiver.invalid)
+	}
+	eventually(t, "the outcome to be recorded", func() bool { return !listTestWebhook(t, as).Disabled })
+	if hook := listTestWebhook(t, as); hook.Failures != 0 {
+		t.Errorf("webhook has %d failures after a successful retry, want 0", hook.Failures)
+	}
+}
+
+func TestWebhookDisabledAfterFailures(t *testing.T) {
+	as := newTestActorSystem(t, NewMemoryStore(), func(as *ActorSystem) {
+		as.Webhooks = WebhookOptions{Client: http.DefaultClient, MaxAttempts: 2, Backoff: time.Millisecond, DisableAfter: 2, QueueSize: 10}
+	})
+	failing := make([]int, 100)
+	for i := range failing {
+		failing[i] = http.StatusServiceUnavailable
+	}
+	receiver := newWebhookReceiver(t, failing...)
+	registerTestWebhook(t, as, receiver)
+
+	ctx := context.Background()
+	as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "first"})
+	as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "second"})
+	eventually(t, "the webhook to be disabled", func() bool { return listTestWebhook(t, as).Disabled })
+	if hook := listTestWebhook(t, as); hook.Failures != 2 {
+		t.Errorf("webhook disabled after %d failures, want 2", hook.Failures)
+	}
+
+	// Each failed delivery made every attempt, and nothing more is sent once disabled
+	as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "third"})
+	eventually(t, "the third post", func() bool {
+		_, err := as.livePost(ctx, 3, "")
+		return err == nil
+	})
+	time.Sleep(50 * time.Millisecond)
+	if attempts := len(receiver.received()); attempts != 4 {
+		t.Errorf("receiver got %d attempts, want 4", attempts)
+	}
+}
+
+func TestWebhookQueueBoundsSlowReceiver(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	requests := 0
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		<-release
+	}))
+	t.Cleanup(receiver.Close)
+	t.Cleanup(func() { close(release) })
+
+	as := newTestActorSystem(t, NewMemoryStore(), func(as *ActorSystem) {
+		as.Webhooks = WebhookOptions{Client: http.DefaultClient, MaxAttempts: 1, Backoff: time.Millisecond, DisableAfter: 3, QueueSize: 1}
+	})
+	registerTestWebhook(t, as, &webhookReceiver{Server: receiver})
+
+	ctx := context.Background()
+	for range 20 {
+		as.CreatePost(ctx, PostMessage{UserID: 1, Subreddit: "golang", Title: "flood"})
+	}
+	eventually(t, "the posts", func() bool {
+		_, err := as.livePost(ctx, 20, "")
+		return err == nil
+	})
+	time.Sleep(50 * time.Millisecond)
+
+	// One delivery is in flight and the receiver has not answered, so nothing else was sent
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 1 {
+		t.Fatalf("slow receiver got %d concurrent deliveries, want 1", requests)
+	}
+}
+
+func TestWebhookEventForDeletions(t *testing.T) {
+	tests := []struct {
+		event LiveEvent
+		want  string
+	}{
+		{LiveEvent{Type: EventPostDeleted, UserID: 2}, WebhookContentDeleted},
+		{LiveEvent{Type: EventCommentDeleted, UserID: 2}, WebhookContentDeleted},
+		{LiveEvent{Type: EventPostDeleted, UserID: 1, Removed: true}, WebhookContentRemoved},
+		{LiveEvent{Type: EventCommentDeleted, UserID: AutoModeratorID, Removed: true}, WebhookContentRemoved},
+		{LiveEvent{Type: EventPostEdited, UserID: 2}, ""},
+	}
+	for _, tt := range tests {
+		if got := webhookEvent(&tt.event); got != tt.want {
+			t.Errorf("webhookEvent(%+v) = %q, want %q", tt.event, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookSeparatesRemovalsFromAuthorDeletes(t *testing.T) {
+	ctx := context.Background()
+	as := newTestActorSystem(t, NewMemoryStore())
+	receiver := newWebhookReceiver(t)
+	as.CreateSubreddit(ctx, CreateSubreddit{Name: "golang", CreatorID: 1})
+	eventually(t, "the webhook to register", func() bool {
+		hook, err := as.RegisterWebhook(ctx, RegisterWebhook{Subreddit: "golang", UserID: 1, URL: receiver.URL, Events: []string{WebhookContentRemoved, WebhookContentDeleted}})
+		if err == nil {
+			receiver.mu.Lock()
+			receiver.secret = hook.Secret
+			receiver.mu.Unlock()
+		}
+		return err == nil
+	})
+	as.CreatePost(ctx, PostMessage{UserID: 2, Subreddit: "golang", Title: "mine", Content: "regret"})
+	as.CreatePost(ctx, PostMessage{UserID: 2, Subreddit: "golang", Title: "spam", Content: "buy now"})
+	eventually(t, "the posts", func() bool {
+		_, err := as.livePost(ctx, 2, "")
+		return err == nil
+	})
+
+	as.DeletePost(ctx, DeletePost{UserID: 2, PostID: 1})
+	as.send(ctx, as.PostActor, &RemoveContent{Target: ReportPost, ID: 2, ModeratorID: 1})
+	eventually(t, "both deliveries", func() bool { return len(receiver.received()) == 2 })
+
+	receiver.mu.Lock()
+	defer receiver.mu.Unlock()
+	if want := []string{WebhookContentDeleted, WebhookContentRemoved}; !slices.Equal(receiver.events, want) || receiver.invalid != 0 {
+		t.Fatalf("received %v (%d invalid), want %v", receiver.events, receiver.invalid, want)
+	}
+}